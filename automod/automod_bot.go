@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/cirelion/flint/commands"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/cirelion/flint/analytics"
@@ -34,6 +35,11 @@ func (p *Plugin) BotInit() {
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleAutomodExecution, eventsystem.EventAutoModerationActionExecution)
 
 	scheduledevents2.RegisterHandler("amod2_reset_channel_ratelimit", ResetChannelRatelimitData{}, handleResetChannelRatelimit)
+	scheduledevents2.RegisterHandler(evtEndLockdown, EndLockdownData{}, handleEndLockdown)
+
+	go gcRecentJoinsLoop()
+	go gcRecentUserMessagesLoop()
+	go gcSimulatedEffectsLoop()
 }
 
 type ResetChannelRatelimitData struct {
@@ -218,6 +224,7 @@ func (p *Plugin) handleGuildMemberJoin(evt *eventsystem.EventData) {
 
 	p.checkJoin(ms)
 	p.checkUsername(ms)
+	p.checkRaid(evt.GS, ms)
 }
 
 func (p *Plugin) checkNickname(ms *dstate.MemberState) {
@@ -280,19 +287,21 @@ func (p *Plugin) handleAutomodExecution(evt *eventsystem.EventData) {
 		return
 	}
 
-	redisKey := fmt.Sprintf("automodv2_rule_execution_%d", eventData.MessageID)
-
-	var exists string
-
-	if err := common.RedisPool.Do(radix.Cmd(&exists, "GET", redisKey)); err != nil {
-		return
+	// Discord sends one event per action of the rule (block, alert, timeout) so dedupe them to only run our rules once per execution.
+	// Blocked messages have no message id, and the alert message id is only set on the alert action event,
+	// so fall back to the content which is the same for all the actions of an execution
+	executionID := strconv.FormatInt(eventData.MessageID, 10)
+	if eventData.MessageID == 0 {
+		executionID = "c" + hashFeature(eventData.Content+"\x00"+eventData.MatchedContent)
 	}
-	if exists == "1" {
+	redisKey := fmt.Sprintf("automodv2_rule_execution:%d:%d:%d:%s", evt.GS.ID, eventData.RuleID, eventData.UserID, executionID)
+
+	var set string
+	if err := common.RedisPool.Do(radix.Cmd(&set, "SET", redisKey, "1", "EX", "5", "NX")); err != nil {
+		logger.WithError(err).WithField("guild", evt.GS.ID).Error("failed setting automod execution key")
 		return
 	}
-
-	// Expires a temporary value after 5 seconds
-	if err := common.RedisPool.Do(radix.Cmd(nil, "SET", redisKey, "1", "EX", "5")); err != nil {
+	if set != "OK" {
 		return
 	}
 
@@ -302,7 +311,10 @@ func (p *Plugin) handleAutomodExecution(evt *eventsystem.EventData) {
 		return
 	}
 
-	message, _ := common.BotSession.ChannelMessage(eventData.ChannelID, eventData.MessageID)
+	var message *discordgo.Message
+	if eventData.MessageID != 0 {
+		message, _ = common.BotSession.ChannelMessage(eventData.ChannelID, eventData.MessageID)
+	}
 
 	p.CheckTriggers(nil, evt.GS, ms, message, cs, func(trig *ParsedPart) (activated bool, err error) {
 		cast, ok := trig.Part.(AutomodListener)
@@ -324,7 +336,7 @@ func (p *Plugin) CheckTriggers(rulesets []*ParsedRuleset, gs *dstate.GuildSet, m
 		var err error
 		rulesets, err = p.FetchGuildRulesets(gs.ID)
 		if err != nil {
-			logger.WithError(err).WithField("guild", msg.GuildID).Error("failed fetching triggers")
+			logger.WithError(err).WithField("guild", gs.ID).Error("failed fetching triggers")
 			return false
 		}
 
@@ -400,7 +412,14 @@ func (p *Plugin) CheckTriggers(rulesets []*ParsedRuleset, gs *dstate.GuildSet, m
 		}
 
 		go p.RulesetRulesTriggered(ctxData, true)
-		activatededRules = true
+
+		for _, v := range triggeredRules {
+			// simulated rules don't take any action on the message
+			if !v.Model.Simulate {
+				activatededRules = true
+				break
+			}
+		}
 
 		logger.WithField("guild", ctxData.GS.ID).Info("automod triggered ", len(triggeredRules), " rules")
 	}
@@ -461,14 +480,20 @@ func (p *Plugin) CheckConditions(ctxData *TriggeredRuleData, conditions []*Parse
 
 func (p *Plugin) RulesetRulesTriggeredCondsPassed(ruleset *ParsedRuleset, triggeredRules []*ParsedRule, ctxData *TriggeredRuleData) {
 
-	loggedModels := make([]*models.AutomodTriggeredRule, len(triggeredRules))
+	loggedModels := make([]*models.AutomodTriggeredRule, 0, len(triggeredRules))
 
 	go analytics.RecordActiveUnit(ruleset.RSModel.GuildID, p, "rule_triggered")
 
 	// apply the effects
-	for i, rule := range triggeredRules {
+	for _, rule := range triggeredRules {
 		ctxData.CurrentRule = rule
 
+		if rule.Model.Simulate {
+			// only record what would have happened
+			go p.simulateRuleEffects(rule, ctxData.Clone())
+			continue
+		}
+
 		for _, effect := range rule.Effects {
 			go func(fx *ParsedPart, ctx *TriggeredRuleData) {
 				err := fx.Part.(Effect).Apply(ctx, fx.ParsedSettings)
@@ -507,7 +532,7 @@ func (p *Plugin) RulesetRulesTriggeredCondsPassed(ruleset *ParsedRuleset, trigge
 			}
 		}
 
-		loggedModels[i] = &models.AutomodTriggeredRule{
+		loggedModels = append(loggedModels, &models.AutomodTriggeredRule{
 			ChannelID:     cid,
 			ChannelName:   cname,
 			GuildID:       ctxData.GS.ID,
@@ -519,7 +544,11 @@ func (p *Plugin) RulesetRulesTriggeredCondsPassed(ruleset *ParsedRuleset, trigge
 			UserID:        ctxData.MS.User.ID,
 			UserName:      ctxData.MS.User.String(),
 			Extradata:     serializedExtraData,
-		}
+		})
+	}
+
+	if len(loggedModels) < 1 {
+		return
 	}
 
 	tx, err := common.PQ.BeginTx(context.Background(), nil)
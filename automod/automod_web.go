@@ -2,6 +2,7 @@ package automod
 
 import (
 	"context"
+	"database/sql"
 	_ "embed"
 	"encoding/json"
 	"fmt"
@@ -52,6 +53,8 @@ var (
 	panelLogKeyNewRule     = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "automodv2_new_rule", FormatString: "Updated automod: Created a new rule"})
 	panelLogKeyUpdatedRule = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "automodv2_updated_rule", FormatString: "Updated automod: Updated a rule"})
 	panelLogKeyRemovedRule = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "automodv2_removed_rule", FormatString: "Updated automod: Removed a rule"})
+
+	panelLogKeyUpdatedRaid = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "automodv2_updated_raid", FormatString: "Updated automod: Updated raid protection"})
 )
 
 func (p *Plugin) InitWeb() {
@@ -76,6 +79,11 @@ func (p *Plugin) InitWeb() {
 	muxer.Handle(pat.Get("/"), getIndexHandler)
 	muxer.Handle(pat.Get(""), getIndexHandler)
 	muxer.Handle(pat.Get("/logs"), web.ControllerHandler(p.handleGetLogs, "automod_index"))
+	muxer.Handle(pat.Get("/simulation"), web.ControllerHandler(p.handleGetSimulation, "automod_index"))
+
+	getRaidHandler := web.ControllerHandler(p.handleGetRaid, "automod_index")
+	muxer.Handle(pat.Get("/raid"), getRaidHandler)
+	muxer.Handle(pat.Post("/raid"), web.ControllerPostHandler(p.handlePostRaid, getRaidHandler, RaidSettingsData{}))
 
 	muxer.Handle(pat.Post("/new_ruleset"), web.ControllerPostHandler(p.handlePostAutomodCreateRuleset, getIndexHandler, CreateRulesetData{}))
 
@@ -124,6 +132,75 @@ func (p *Plugin) handleGetAutomodIndex(w http.ResponseWriter, r *http.Request) (
 	return tmpl, nil
 }
 
+type RaidSettingsData struct {
+	Enabled           bool
+	JoinThreshold     int `valid:"2,500"`
+	JoinWindow        int `valid:"1,600"`
+	NewAccountRatio   int `valid:"0,100"`
+	NewAccountDays    int `valid:"1,365"`
+	Action            int `valid:"0,2"`
+	TimeoutMinutes    int `valid:"1,40320"`
+	RaiseVerification bool
+	LockdownChannels  []int64 `valid:"channel,true"`
+	LockdownMode      int     `valid:"0,1"`
+	SlowmodeSeconds   int     `valid:"1,21600"`
+	RaidDuration      int     `valid:"0,10080"`
+	LogChannel        int64   `valid:"channel,true"`
+}
+
+func (p *Plugin) handleGetRaid(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	g, tmpl := web.GetBaseCPContextData(r.Context())
+	tmpl["InRaid"] = true
+
+	conf, err := models.FindAutomodRaidConfigG(r.Context(), g.ID)
+	if err == sql.ErrNoRows {
+		conf = DefaultRaidConfig(g.ID)
+	} else if err != nil {
+		return tmpl, err
+	}
+	tmpl["RaidConfig"] = conf
+
+	lockdown, err := models.FindAutomodLockdownG(r.Context(), g.ID)
+	if err != nil && err != sql.ErrNoRows {
+		return tmpl, err
+	}
+	tmpl["Lockdown"] = lockdown
+
+	return p.handleGetAutomodIndex(w, r)
+}
+
+func (p *Plugin) handlePostRaid(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	g, tmpl := web.GetBaseCPContextData(r.Context())
+	data := r.Context().Value(common.ContextKeyParsedForm).(*RaidSettingsData)
+
+	conf := &models.AutomodRaidConfig{
+		GuildID:           g.ID,
+		Enabled:           data.Enabled,
+		JoinThreshold:     data.JoinThreshold,
+		JoinWindow:        data.JoinWindow,
+		NewAccountRatio:   data.NewAccountRatio,
+		NewAccountDays:    data.NewAccountDays,
+		Action:            data.Action,
+		TimeoutMinutes:    data.TimeoutMinutes,
+		RaiseVerification: data.RaiseVerification,
+		LockdownChannels:  data.LockdownChannels,
+		LockdownMode:      data.LockdownMode,
+		SlowmodeSeconds:   data.SlowmodeSeconds,
+		RaidDuration:      data.RaidDuration,
+		LogChannel:        data.LogChannel,
+	}
+
+	err := conf.UpsertG(r.Context(), true, []string{"guild_id"}, boil.Infer(), boil.Infer())
+	if err != nil {
+		return tmpl, err
+	}
+
+	pubsub.EvictCacheSet(cachedRaidConfigs, g.ID)
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyUpdatedRaid))
+
+	return tmpl, nil
+}
+
 func (p *Plugin) handleGetLogs(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
 	g, tmpl := web.GetBaseCPContextData(r.Context())
 
@@ -157,6 +234,37 @@ func (p *Plugin) handleGetLogs(w http.ResponseWriter, r *http.Request) (web.Temp
 	return p.handleGetAutomodIndex(w, r)
 }
 
+func (p *Plugin) handleGetSimulation(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	g, tmpl := web.GetBaseCPContextData(r.Context())
+
+	tmpl["InSimulation"] = true
+
+	days, _ := strconv.Atoi(r.URL.Query().Get("days"))
+	if days < 1 {
+		days = 7
+	} else if days > SimulationMaxDays {
+		days = SimulationMaxDays
+	}
+
+	counts, matches, err := GetSimulationReport(r.Context(), g.ID, days, 50)
+	if err != nil {
+		return tmpl, err
+	}
+
+	simulating, err := models.AutomodRules(qm.Where("guild_id = ? AND simulate = true", g.ID), qm.OrderBy("id asc")).AllG(r.Context())
+	if err != nil {
+		return tmpl, err
+	}
+
+	tmpl["SimulationDays"] = days
+	tmpl["SimulationMaxDays"] = SimulationMaxDays
+	tmpl["SimulatedEffectCounts"] = counts
+	tmpl["SimulatedMatches"] = matches
+	tmpl["SimulatingRules"] = simulating
+
+	return p.handleGetAutomodIndex(w, r)
+}
+
 type CreateRulesetData struct {
 	Name string `valid:",1,100"`
 }
@@ -435,6 +543,7 @@ func (p *Plugin) handlePostAutomodDeleteRuleset(w http.ResponseWriter, r *http.R
 
 type UpdateRuleData struct {
 	Name       string `valid:",1,50"`
+	Simulate   bool
 	Triggers   []RuleRowData
 	Conditions []RuleRowData
 	Effects    []RuleRowData
@@ -534,7 +643,8 @@ func (p *Plugin) handlePostAutomodUpdateRule(w http.ResponseWriter, r *http.Requ
 	}
 
 	currentRule.Name = data.Name
-	_, err = currentRule.Update(r.Context(), tx, boil.Whitelist("name"))
+	currentRule.Simulate = data.Simulate
+	_, err = currentRule.Update(r.Context(), tx, boil.Whitelist("name", "simulate"))
 	if err != nil {
 		tx.Rollback()
 		return tmpl, err
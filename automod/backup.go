@@ -0,0 +1,364 @@
+package automod
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"reflect"
+
+	"github.com/cirelion/flint/automod/models"
+	"github.com/cirelion/flint/backup"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/pubsub"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+type automodBackup struct {
+	Rulesets   []*rulesetBackup          `json:"rulesets"`
+	Lists      []*models.AutomodList     `json:"lists"`
+	RaidConfig *models.AutomodRaidConfig `json:"raid_config,omitempty"`
+}
+
+type rulesetBackup struct {
+	Name       string            `json:"name"`
+	Enabled    bool              `json:"enabled"`
+	Conditions []*rulePartBackup `json:"conditions"`
+	Rules      []*ruleBackup     `json:"rules"`
+}
+
+type ruleBackup struct {
+	Name     string            `json:"name"`
+	Simulate bool              `json:"simulate,omitempty"`
+	Parts    []*rulePartBackup `json:"parts"`
+}
+
+type rulePartBackup struct {
+	TypeID   int             `json:"type_id"`
+	Settings json.RawMessage `json:"settings"`
+}
+
+var _ backup.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	rulesets, err := models.AutomodRulesets(qm.Where("guild_id=?", guildID), qm.OrderBy("id asc"),
+		qm.Load("RulesetAutomodRules.RuleAutomodRuleData"), qm.Load("RulesetAutomodRulesetConditions")).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := models.AutomodLists(qm.Where("guild_id=?", guildID), qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	raidConfig, err := models.FindAutomodRaidConfigG(ctx, guildID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	if len(rulesets) < 1 && len(lists) < 1 && raidConfig == nil {
+		return nil, nil
+	}
+
+	result := &automodBackup{
+		Lists:      lists,
+		RaidConfig: raidConfig,
+	}
+
+	for _, rs := range rulesets {
+		exported := &rulesetBackup{
+			Name:    rs.Name,
+			Enabled: rs.Enabled,
+		}
+
+		for _, cond := range rs.R.RulesetAutomodRulesetConditions {
+			exported.Conditions = append(exported.Conditions, &rulePartBackup{TypeID: cond.TypeID, Settings: json.RawMessage(cond.Settings)})
+		}
+
+		for _, rule := range rs.R.RulesetAutomodRules {
+			exportedRule := &ruleBackup{Name: rule.Name, Simulate: rule.Simulate}
+			for _, part := range rule.R.RuleAutomodRuleData {
+				exportedRule.Parts = append(exportedRule.Parts, &rulePartBackup{TypeID: part.TypeID, Settings: json.RawMessage(part.Settings)})
+			}
+
+			exported.Rules = append(exported.Rules, exportedRule)
+		}
+
+		result.Rulesets = append(result.Rulesets, exported)
+	}
+
+	return result, nil
+}
+
+// ImportBackup replaces all rulesets and lists, and the raid settings if they're included
+func (p *Plugin) ImportBackup(ctx context.Context, ic *backup.ImportContext, data json.RawMessage) error {
+	var exported automodBackup
+	err := json.Unmarshal(data, &exported)
+	if err != nil {
+		return err
+	}
+
+	guildID := ic.GS.ID
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = importAutomodBackup(ctx, tx, ic, &exported)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	pubsub.EvictCacheSet(cachedRulesets, guildID)
+	pubsub.EvictCacheSet(cachedLists, guildID)
+	pubsub.EvictCacheSet(cachedRaidConfigs, guildID)
+	return nil
+}
+
+func importAutomodBackup(ctx context.Context, tx *sql.Tx, ic *backup.ImportContext, exported *automodBackup) error {
+	guildID := ic.GS.ID
+
+	// rules, conditions and rule data are removed through the cascade
+	_, err := models.AutomodRulesets(qm.Where("guild_id=?", guildID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	_, err = models.AutomodLists(qm.Where("guild_id=?", guildID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	// maps the list ids in the backup to the new lists
+	listIDs := make(map[int64]int64)
+	for i, list := range exported.Lists {
+		if i >= GuildMaxLists(guildID) {
+			ic.Warn("Automod: only the first %d lists were imported", GuildMaxLists(guildID))
+			break
+		}
+
+		oldID := list.ID
+		list.ID = 0
+		list.GuildID = guildID
+		err = list.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return err
+		}
+
+		listIDs[oldID] = list.ID
+	}
+
+	limits := &automodImportLimits{
+		rules:             GuildMaxTotalRules(guildID),
+		messageTriggers:   GuildMaxMessageTriggers(guildID),
+		violationTriggers: GuildMaxViolationTriggers(guildID),
+	}
+
+	for i, rs := range exported.Rulesets {
+		if i >= GuildMaxRulesets(guildID) {
+			ic.Warn("Automod: only the first %d rulesets were imported", GuildMaxRulesets(guildID))
+			break
+		}
+
+		err = importRuleset(ctx, tx, ic, rs, listIDs, limits)
+		if err != nil {
+			return err
+		}
+	}
+
+	if exported.RaidConfig != nil {
+		conf := exported.RaidConfig
+		conf.GuildID = guildID
+		conf.LockdownChannels = ic.Remap.Channels(conf.LockdownChannels)
+		conf.LogChannel = ic.Remap.Channel(conf.LogChannel)
+
+		err = conf.Upsert(ctx, tx, true, []string{"guild_id"}, boil.Infer(), boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// automodImportLimits keeps track of how much of the premium dependant limits is left while importing
+type automodImportLimits struct {
+	rules             int
+	messageTriggers   int
+	violationTriggers int
+}
+
+func importRuleset(ctx context.Context, tx *sql.Tx, ic *backup.ImportContext, exported *rulesetBackup, listIDs map[int64]int64, limits *automodImportLimits) error {
+	rs := &models.AutomodRuleset{
+		GuildID: ic.GS.ID,
+		Name:    exported.Name,
+		Enabled: exported.Enabled,
+	}
+
+	err := rs.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		return err
+	}
+
+	for _, cond := range exported.Conditions {
+		part, settings, ok := remapRulePart(ic, cond, listIDs)
+		if !ok {
+			continue
+		}
+
+		model := &models.AutomodRulesetCondition{
+			GuildID:   ic.GS.ID,
+			RulesetID: rs.ID,
+			Kind:      int(part.Kind()),
+			TypeID:    cond.TypeID,
+			Settings:  settings,
+		}
+
+		err = model.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, exportedRule := range exported.Rules {
+		if limits.rules < 1 {
+			ic.Warn("Automod: skipped rule %s in %s, max number of rules reached", exportedRule.Name, exported.Name)
+			continue
+		}
+
+		parts := exportedRule.Parts
+		if len(parts) > MaxRuleParts {
+			parts = parts[:MaxRuleParts]
+			ic.Warn("Automod: truncated rule %s in %s down to %d triggers/conditions/effects", exportedRule.Name, exported.Name, MaxRuleParts)
+		}
+
+		messageTriggers, violationTriggers := 0, 0
+		for _, v := range parts {
+			switch RulePartMap[v.TypeID].(type) {
+			case MessageTrigger:
+				messageTriggers++
+			case ViolationListener:
+				violationTriggers++
+			}
+		}
+
+		if messageTriggers > limits.messageTriggers || violationTriggers > limits.violationTriggers {
+			ic.Warn("Automod: skipped rule %s in %s, max number of message or violation based triggers reached", exportedRule.Name, exported.Name)
+			continue
+		}
+
+		limits.rules--
+		limits.messageTriggers -= messageTriggers
+		limits.violationTriggers -= violationTriggers
+
+		rule := &models.AutomodRule{
+			GuildID:   ic.GS.ID,
+			RulesetID: rs.ID,
+			Name:      exportedRule.Name,
+			Simulate:  exportedRule.Simulate,
+		}
+
+		err = rule.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return err
+		}
+
+		for _, exportedPart := range parts {
+			part, settings, ok := remapRulePart(ic, exportedPart, listIDs)
+			if !ok {
+				continue
+			}
+
+			model := &models.AutomodRuleDatum{
+				GuildID:  ic.GS.ID,
+				RuleID:   rule.ID,
+				Kind:     int(part.Kind()),
+				TypeID:   exportedPart.TypeID,
+				Settings: settings,
+			}
+
+			err = model.Insert(ctx, tx, boil.Infer())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// remapRulePart maps the channels, roles and lists in the settings of the part using the kinds of its user settings,
+// ok is false if the part type is unknown
+func remapRulePart(ic *backup.ImportContext, exported *rulePartBackup, listIDs map[int64]int64) (part RulePart, settings []byte, ok bool) {
+	part, ok = RulePartMap[exported.TypeID]
+	if !ok {
+		return nil, nil, false
+	}
+
+	dst := part.DataType()
+	if dst == nil {
+		return part, []byte("{}"), true
+	}
+
+	if len(exported.Settings) > 0 {
+		err := json.Unmarshal(exported.Settings, dst)
+		if err != nil {
+			ic.Warn("Automod: skipped invalid settings for %s", part.Name())
+			return nil, nil, false
+		}
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(dst))
+	for _, def := range part.UserSettings() {
+		field := rv.FieldByName(def.Key)
+		if !field.IsValid() {
+			continue
+		}
+
+		var mapper func(int64) int64
+		switch def.Kind {
+		case SettingTypeRole, SettingTypeMultiRole:
+			mapper = ic.Remap.Role
+		case SettingTypeChannel, SettingTypeMultiChannel, SettingTypeMultiChannelCategories:
+			mapper = ic.Remap.Channel
+		case SettingTypeList:
+			mapper = func(id int64) int64 {
+				return listIDs[id]
+			}
+		default:
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.Int64:
+			field.SetInt(mapper(field.Int()))
+		case reflect.Slice:
+			if field.Type().Elem().Kind() != reflect.Int64 {
+				continue
+			}
+
+			var mapped []int64
+			for _, id := range field.Convert(reflect.TypeOf([]int64{})).Interface().([]int64) {
+				if newID := mapper(id); newID != 0 {
+					mapped = append(mapped, newID)
+				}
+			}
+			field.Set(reflect.ValueOf(mapped).Convert(field.Type()))
+		}
+	}
+
+	settings, err := json.Marshal(dst)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	return part, settings, true
+}
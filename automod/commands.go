@@ -379,4 +379,77 @@ func (p *Plugin) AddCommands() {
 	commands.RegisterSlashCommandsContainer(container, false, func(gs *dstate.GuildSet) ([]int64, error) {
 		return nil, nil
 	})
+	cmdLockdownStart := &commands.YAGCommand{
+		Name:        "Start",
+		CmdCategory: commands.CategoryModeration,
+		Description: "Locks down the server: applies slowmode or denies sending in the lockdown channels, raises the verification level and acts on new joiners as set up in the control panel",
+		Arguments: []*dcmd.ArgDef{
+			{Name: "Duration", Help: "How long until the lockdown ends by itself, 0 to keep it until ended", Type: &commands.DurationArg{}, Default: time.Duration(0)},
+			{Name: "Reason", Type: dcmd.String, Default: ""},
+		},
+		RequireDiscordPerms: []int64{discordgo.PermissionManageServer, discordgo.PermissionAdministrator, discordgo.PermissionManageChannels},
+		RequireBotPerms:     [][]int64{{discordgo.PermissionAdministrator}, {discordgo.PermissionManageChannels, discordgo.PermissionManageRoles}},
+		RunFunc: func(data *dcmd.Data) (interface{}, error) {
+			conf, err := FetchRaidConfig(data.GuildData.GS.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			reason := data.Args[1].Str()
+			if reason == "" {
+				reason = "No reason specified"
+			}
+
+			lockdown, locked, err := StartLockdown(data.GuildData.GS, conf, data.Author, reason, false, data.Args[0].Value.(time.Duration))
+			if err != nil {
+				return nil, err
+			}
+
+			msg := fmt.Sprintf("Server locked down, %d channels affected.", locked)
+			if lockdown.EndsAt.Valid {
+				msg += fmt.Sprintf(" The lockdown ends <t:%d:R>.", lockdown.EndsAt.Time.Unix())
+			}
+
+			return msg, nil
+		},
+	}
+
+	cmdLockdownEnd := &commands.YAGCommand{
+		Name:        "End",
+		CmdCategory: commands.CategoryModeration,
+		Description: "Ends the lockdown, restoring every channel and the verification level to how they were before",
+		Arguments: []*dcmd.ArgDef{
+			{Name: "Reason", Type: dcmd.String, Default: ""},
+		},
+		RequireDiscordPerms: []int64{discordgo.PermissionManageServer, discordgo.PermissionAdministrator, discordgo.PermissionManageChannels},
+		RequireBotPerms:     [][]int64{{discordgo.PermissionAdministrator}, {discordgo.PermissionManageChannels, discordgo.PermissionManageRoles}},
+		RunFunc: func(data *dcmd.Data) (interface{}, error) {
+			conf, err := FetchRaidConfig(data.GuildData.GS.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			reason := data.Args[0].Str()
+			if reason == "" {
+				reason = "No reason specified"
+			}
+
+			err = EndLockdown(data.GuildData.GS, conf, data.Author, reason)
+			if err != nil {
+				return nil, err
+			}
+
+			return "Lockdown ended, all channels were restored.", nil
+		},
+	}
+
+	lockdownContainer, _ := commands.CommandSystem.Root.Sub("lockdown")
+	lockdownContainer.NotFound = commands.CommonContainerNotFoundHandler(lockdownContainer, "")
+	lockdownContainer.Description = "Locks down the server during raids"
+
+	lockdownContainer.AddCommand(cmdLockdownStart, cmdLockdownStart.GetTrigger())
+	lockdownContainer.AddCommand(cmdLockdownEnd, cmdLockdownEnd.GetTrigger())
+	commands.RegisterSlashCommandsContainer(lockdownContainer, false, func(gs *dstate.GuildSet) ([]int64, error) {
+		return nil, nil
+	})
 }
@@ -21,6 +21,9 @@ CREATE TABLE IF NOT EXISTS automod_rules (
 	trigger_counter BIGINT NOT NULL
 );
 
+`, `
+ALTER TABLE automod_rules ADD COLUMN IF NOT EXISTS simulate BOOLEAN NOT NULL DEFAULT false;
+
 `, `
 CREATE INDEX IF NOT EXISTS automod_rules_guild_idx ON automod_rules(guild_id);
 
@@ -115,4 +118,73 @@ CREATE INDEX IF NOT EXISTS automod_triggered_rules_guild_idx ON automod_triggere
 CREATE INDEX IF NOT EXISTS automod_triggered_rules_rule_id_idx on automod_triggered_rules(rule_id);
 `, `
 CREATE INDEX IF NOT EXISTS automod_triggered_rules_trigger_idx ON automod_triggered_rules(trigger_id);
+`, `
+CREATE TABLE IF NOT EXISTS automod_raid_configs (
+	guild_id BIGINT PRIMARY KEY,
+	enabled BOOLEAN NOT NULL DEFAULT false,
+
+	-- raid mode is entered when join_threshold members join within join_window seconds,
+	-- and, if new_account_ratio is above 0, at least that percent of them have accounts younger than new_account_days
+	join_threshold INT NOT NULL DEFAULT 10,
+	join_window INT NOT NULL DEFAULT 10,
+	new_account_ratio INT NOT NULL DEFAULT 0,
+	new_account_days INT NOT NULL DEFAULT 7,
+
+	action INT NOT NULL DEFAULT 0,
+	timeout_minutes INT NOT NULL DEFAULT 60,
+	raise_verification BOOLEAN NOT NULL DEFAULT false,
+
+	lockdown_channels BIGINT[] NOT NULL DEFAULT '{}',
+	lockdown_mode INT NOT NULL DEFAULT 0,
+	slowmode_seconds INT NOT NULL DEFAULT 30,
+
+	raid_duration INT NOT NULL DEFAULT 30,
+	log_channel BIGINT NOT NULL DEFAULT 0
+);
+`, `
+CREATE TABLE IF NOT EXISTS automod_lockdowns (
+	guild_id BIGINT PRIMARY KEY,
+	started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	ends_at TIMESTAMP WITH TIME ZONE,
+	reason TEXT NOT NULL,
+	author_id BIGINT NOT NULL,
+	is_raid BOOLEAN NOT NULL,
+
+	-- -1 if the verification level was not changed
+	previous_verification_level INT NOT NULL
+);
+`, `
+CREATE TABLE IF NOT EXISTS automod_lockdown_overwrites (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+	channel_id BIGINT NOT NULL,
+
+	-- the @everyone overwrite and slowmode of the channel before the lockdown
+	had_overwrite BOOLEAN NOT NULL,
+	allow BIGINT NOT NULL,
+	deny BIGINT NOT NULL,
+	rate_limit_per_user INT NOT NULL,
+	lockdown_mode INT NOT NULL,
+
+	UNIQUE(guild_id, channel_id)
+);
+`, `
+CREATE TABLE IF NOT EXISTS automod_simulated_effects (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	-- no reference, simulated effects are kept as a history even if the rule is deleted
+	rule_id BIGINT NOT NULL,
+	rule_name TEXT NOT NULL,
+	ruleset_name TEXT NOT NULL,
+	effect_typeid INT NOT NULL,
+
+	user_id BIGINT NOT NULL,
+	user_name TEXT NOT NULL,
+	channel_id BIGINT NOT NULL,
+	message_content TEXT NOT NULL
+);
+`, `
+CREATE INDEX IF NOT EXISTS automod_simulated_effects_guild_created_idx ON automod_simulated_effects(guild_id, created_at);
 `}
@@ -0,0 +1,308 @@
+package automod
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/lib/confusables"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate"
+)
+
+const (
+	// max time window of the duplicate messages trigger, messages older than this are forgotten
+	maxDuplicateWindow = time.Minute * 10
+
+	// max number of messages remembered per user
+	maxTrackedUserMessages = 50
+)
+
+// messageFingerprint is the set of features of a message used to find near identical messages,
+// made up of the character trigrams of the normalised text, and hashes of the links and attachments
+type messageFingerprint map[string]struct{}
+
+// fingerprintMessage normalises the message so small changes such as casing, spacing, punctuation and
+// look-alike characters don't matter, returning the fingerprint, the length of the normalised text and if it had links or attachments
+func fingerprintMessage(content string, attachments []*discordgo.MessageAttachment) (fp messageFingerprint, textLength int, hasMedia bool) {
+	fp = make(messageFingerprint)
+
+	content = forwardSlashReplacer.Replace(content)
+	for _, v := range common.LinkRegex.FindAllStringSubmatch(content, -1) {
+		host := strings.TrimPrefix(strings.ToLower(v[2]), "www.")
+		fp["l:"+hashFeature(host+strings.TrimRight(v[3], "/"))] = struct{}{}
+	}
+	content = common.LinkRegex.ReplaceAllString(content, " ")
+	hasMedia = len(fp) > 0 || len(attachments) > 0
+
+	for _, v := range attachments {
+		fp["a:"+hashFeature(strings.ToLower(v.Filename)+":"+strconv.Itoa(v.Size))] = struct{}{}
+	}
+
+	normalised := make([]rune, 0, len(content))
+	for _, r := range confusables.SanitizeText(strings.ToLower(content)) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			normalised = append(normalised, unicode.ToLower(r))
+		}
+	}
+
+	if len(normalised) > 0 && len(normalised) < 3 {
+		fp["t:"+string(normalised)] = struct{}{}
+	}
+
+	for i := 0; i+3 <= len(normalised); i++ {
+		fp["t:"+string(normalised[i:i+3])] = struct{}{}
+	}
+
+	return fp, len(normalised), hasMedia
+}
+
+func hashFeature(s string) string {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return strconv.FormatUint(h.Sum64(), 36)
+}
+
+func isMediaFeature(feature string) bool {
+	return strings.HasPrefix(feature, "l:") || strings.HasPrefix(feature, "a:")
+}
+
+// similarity returns how similar the fingerprints are in percent (the jaccard index of the features),
+// messages with different links or attachments are never similar
+func (fp messageFingerprint) similarity(other messageFingerprint) int {
+	if len(fp) < 1 && len(other) < 1 {
+		return 100
+	}
+
+	shared := 0
+	sharedMedia := 0
+	for k := range fp {
+		if _, ok := other[k]; ok {
+			shared++
+			if isMediaFeature(k) {
+				sharedMedia++
+			}
+		}
+	}
+
+	if sharedMedia != fp.mediaCount() || sharedMedia != other.mediaCount() {
+		return 0
+	}
+
+	return shared * 100 / (len(fp) + len(other) - shared)
+}
+
+func (fp messageFingerprint) mediaCount() int {
+	n := 0
+	for k := range fp {
+		if isMediaFeature(k) {
+			n++
+		}
+	}
+
+	return n
+}
+
+type trackedMessage struct {
+	MessageID   int64
+	ChannelID   int64
+	T           time.Time
+	Fingerprint messageFingerprint
+	TextLength  int
+	HasMedia    bool
+}
+
+var (
+	// guild id -> user id -> recent messages, oldest first
+	recentUserMessages   = make(map[int64]map[int64][]*trackedMessage)
+	recentUserMessagesMU sync.Mutex
+)
+
+// trackUserMessage remembers the message and returns a copy of the recent messages of the user in the window, including this one.
+// Messages checked again (by several rules, or edits) replace the previous version.
+func trackUserMessage(guildID, userID int64, msg *trackedMessage, window time.Duration) []*trackedMessage {
+	recentUserMessagesMU.Lock()
+	defer recentUserMessagesMU.Unlock()
+
+	guildMessages, ok := recentUserMessages[guildID]
+	if !ok {
+		guildMessages = make(map[int64][]*trackedMessage)
+		recentUserMessages[guildID] = guildMessages
+	}
+
+	messages := guildMessages[userID]
+	found := false
+	for i, v := range messages {
+		if v.MessageID == msg.MessageID {
+			msg.T = v.T
+			messages[i] = msg
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		messages = append(messages, msg)
+	}
+
+	// forget the messages that are too old for any rule
+	now := time.Now()
+	for len(messages) > 0 && (now.Sub(messages[0].T) > maxDuplicateWindow || len(messages) > maxTrackedUserMessages) {
+		messages = messages[1:]
+	}
+	guildMessages[userID] = messages
+
+	result := make([]*trackedMessage, 0, len(messages))
+	for _, v := range messages {
+		if now.Sub(v.T) <= window {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+func gcRecentUserMessagesLoop() {
+	ticker := time.NewTicker(time.Minute)
+	for {
+		<-ticker.C
+
+		recentUserMessagesMU.Lock()
+		for guildID, users := range recentUserMessages {
+			for userID, messages := range users {
+				if len(messages) < 1 || time.Since(messages[len(messages)-1].T) > maxDuplicateWindow {
+					delete(users, userID)
+				}
+			}
+
+			if len(users) < 1 {
+				delete(recentUserMessages, guildID)
+			}
+		}
+		recentUserMessagesMU.Unlock()
+	}
+}
+
+/////////////////////////////////////////////////////////////
+
+var _ MessageTrigger = (*DuplicateMessagesTrigger)(nil)
+
+type DuplicateMessagesTrigger struct{}
+
+type DuplicateMessagesTriggerData struct {
+	Channels       int
+	TimeLimit      int
+	Similarity     int
+	MinLength      int
+	IgnoreChannels []int64
+	IgnoreRoles    []int64
+}
+
+func (dup *DuplicateMessagesTrigger) Kind() RulePartType {
+	return RulePartTrigger
+}
+
+func (dup *DuplicateMessagesTrigger) DataType() interface{} {
+	return &DuplicateMessagesTriggerData{}
+}
+
+func (dup *DuplicateMessagesTrigger) Name() string {
+	return "x near identical messages across channels"
+}
+
+func (dup *DuplicateMessagesTrigger) Description() string {
+	return "Triggers when a user posts near identical messages in x different channels within y seconds. " +
+		"Casing, spacing, punctuation and look-alike characters are ignored, links and attachments have to be the same."
+}
+
+func (dup *DuplicateMessagesTrigger) UserSettings() []*SettingDef {
+	return []*SettingDef{
+		{
+			Name:    "Different channels",
+			Key:     "Channels",
+			Kind:    SettingTypeInt,
+			Min:     2,
+			Max:     50,
+			Default: 3,
+		},
+		{
+			Name:    "Within seconds",
+			Key:     "TimeLimit",
+			Kind:    SettingTypeInt,
+			Min:     1,
+			Max:     int(maxDuplicateWindow.Seconds()),
+			Default: 30,
+		},
+		{
+			Name:    "Similarity in percent",
+			Key:     "Similarity",
+			Kind:    SettingTypeInt,
+			Min:     50,
+			Max:     100,
+			Default: 90,
+		},
+		{
+			Name:    "Ignore messages without links or attachments shorter than",
+			Key:     "MinLength",
+			Kind:    SettingTypeInt,
+			Min:     0,
+			Max:     2000,
+			Default: 10,
+		},
+		{
+			Name: "Ignore these channels",
+			Key:  "IgnoreChannels",
+			Kind: SettingTypeMultiChannel,
+		},
+		{
+			Name: "Ignore members with these roles",
+			Key:  "IgnoreRoles",
+			Kind: SettingTypeMultiRole,
+		},
+	}
+}
+
+func (dup *DuplicateMessagesTrigger) CheckMessage(triggerCtx *TriggerContext, cs *dstate.ChannelState, m *discordgo.Message, mdStripped string) (bool, error) {
+	settings := triggerCtx.Data.(*DuplicateMessagesTriggerData)
+
+	if triggerCtx.MS.Member != nil && common.ContainsInt64SliceOneOf(triggerCtx.MS.Member.Roles, settings.IgnoreRoles) {
+		return false, nil
+	}
+
+	fp, textLength, hasMedia := fingerprintMessage(m.Content, m.Attachments)
+	msg := &trackedMessage{
+		MessageID:   m.ID,
+		ChannelID:   cs.ID,
+		T:           time.Now(),
+		Fingerprint: fp,
+		TextLength:  textLength,
+		HasMedia:    hasMedia,
+	}
+
+	// track it even if it's ignored here, other rules might not ignore it
+	messages := trackUserMessage(cs.GuildID, triggerCtx.MS.User.ID, msg, time.Duration(settings.TimeLimit)*time.Second)
+	if common.ContainsInt64Slice(settings.IgnoreChannels, cs.ID) || msg.ignored(settings) {
+		return false, nil
+	}
+
+	channels := map[int64]bool{cs.ID: true}
+	for _, v := range messages {
+		if channels[v.ChannelID] || v.ignored(settings) || common.ContainsInt64Slice(settings.IgnoreChannels, v.ChannelID) {
+			continue
+		}
+
+		if fp.similarity(v.Fingerprint) >= settings.Similarity {
+			channels[v.ChannelID] = true
+		}
+	}
+
+	return len(channels) >= settings.Channels, nil
+}
+
+func (t *trackedMessage) ignored(settings *DuplicateMessagesTriggerData) bool {
+	return len(t.Fingerprint) < 1 || (!t.HasMedia && t.TextLength < settings.MinLength)
+}
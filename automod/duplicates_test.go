@@ -0,0 +1,66 @@
+package automod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/discordgo"
+)
+
+func TestFingerprintSimilarity(t *testing.T) {
+	base, _, _ := fingerprintMessage("Free nitro for everyone! https://disc0rd-gift.com/claim", nil)
+
+	cases := []struct {
+		content  string
+		min, max int
+	}{
+		{content: "free   NITRO for everyone https://www.disc0rd-gift.com/claim/", min: 100, max: 100},
+		{content: "Free nitro for everyone!! https://disc0rd-gift.com/claim", min: 100, max: 100},
+		{content: "Free nitro for every1! https://disc0rd-gift.com/claim", min: 80, max: 99},
+		{content: "Free nitro for everyone! https://disc0rd-gift.com/other", min: 0, max: 0},
+		{content: "Free nitro for everyone!", min: 0, max: 0},
+		{content: "does anyone know how to set up the bot?", min: 0, max: 10},
+	}
+
+	for _, c := range cases {
+		fp, _, _ := fingerprintMessage(c.content, nil)
+		similarity := base.similarity(fp)
+		if similarity < c.min || similarity > c.max {
+			t.Errorf("%q: similarity %d, expected between %d and %d", c.content, similarity, c.min, c.max)
+		}
+	}
+}
+
+func TestFingerprintAttachments(t *testing.T) {
+	a, textLength, hasMedia := fingerprintMessage("", []*discordgo.MessageAttachment{{Filename: "Scam.png", Size: 1234}})
+	if textLength != 0 || !hasMedia || len(a) != 1 {
+		t.Fatalf("unexpected fingerprint of attachment only message: %v %d %v", a, textLength, hasMedia)
+	}
+
+	b, _, _ := fingerprintMessage("", []*discordgo.MessageAttachment{{Filename: "scam.png", Size: 1234}})
+	c, _, _ := fingerprintMessage("", []*discordgo.MessageAttachment{{Filename: "scam.png", Size: 4321}})
+	if a.similarity(b) != 100 || a.similarity(c) != 0 {
+		t.Errorf("unexpected attachment similarities: %d and %d", a.similarity(b), a.similarity(c))
+	}
+}
+
+func TestTrackUserMessage(t *testing.T) {
+	fp, textLength, hasMedia := fingerprintMessage("hello there", nil)
+	track := func(msgID, channelID int64) []*trackedMessage {
+		return trackUserMessage(1, 2, &trackedMessage{MessageID: msgID, ChannelID: channelID, T: time.Now(), Fingerprint: fp, TextLength: textLength, HasMedia: hasMedia}, maxDuplicateWindow)
+	}
+
+	track(1, 10)
+	track(2, 11)
+	if messages := track(2, 11); len(messages) != 2 {
+		t.Fatalf("checking a message again should replace it, got %d messages", len(messages))
+	}
+
+	for i := int64(3); i < maxTrackedUserMessages+10; i++ {
+		track(i, 10)
+	}
+
+	if messages := track(100, 12); len(messages) != maxTrackedUserMessages {
+		t.Fatalf("expected %d tracked messages, got %d", maxTrackedUserMessages, len(messages))
+	}
+}
@@ -28,6 +28,7 @@ type AutomodRule struct {
 	RulesetID      int64  `boil:"ruleset_id" json:"ruleset_id" toml:"ruleset_id" yaml:"ruleset_id"`
 	Name           string `boil:"name" json:"name" toml:"name" yaml:"name"`
 	TriggerCounter int64  `boil:"trigger_counter" json:"trigger_counter" toml:"trigger_counter" yaml:"trigger_counter"`
+	Simulate       bool   `boil:"simulate" json:"simulate" toml:"simulate" yaml:"simulate"`
 
 	R *automodRuleR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L automodRuleL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -39,12 +40,14 @@ var AutomodRuleColumns = struct {
 	RulesetID      string
 	Name           string
 	TriggerCounter string
+	Simulate       string
 }{
 	ID:             "id",
 	GuildID:        "guild_id",
 	RulesetID:      "ruleset_id",
 	Name:           "name",
 	TriggerCounter: "trigger_counter",
+	Simulate:       "simulate",
 }
 
 // Generated where
@@ -55,12 +58,14 @@ var AutomodRuleWhere = struct {
 	RulesetID      whereHelperint64
 	Name           whereHelperstring
 	TriggerCounter whereHelperint64
+	Simulate       whereHelperbool
 }{
 	ID:             whereHelperint64{field: "\"automod_rules\".\"id\""},
 	GuildID:        whereHelperint64{field: "\"automod_rules\".\"guild_id\""},
 	RulesetID:      whereHelperint64{field: "\"automod_rules\".\"ruleset_id\""},
 	Name:           whereHelperstring{field: "\"automod_rules\".\"name\""},
 	TriggerCounter: whereHelperint64{field: "\"automod_rules\".\"trigger_counter\""},
+	Simulate:       whereHelperbool{field: "\"automod_rules\".\"simulate\""},
 }
 
 // AutomodRuleRels is where relationship names are stored.
@@ -93,9 +98,9 @@ func (*automodRuleR) NewStruct() *automodRuleR {
 type automodRuleL struct{}
 
 var (
-	automodRuleAllColumns            = []string{"id", "guild_id", "ruleset_id", "name", "trigger_counter"}
+	automodRuleAllColumns            = []string{"id", "guild_id", "ruleset_id", "name", "trigger_counter", "simulate"}
 	automodRuleColumnsWithoutDefault = []string{"guild_id", "ruleset_id", "name", "trigger_counter"}
-	automodRuleColumnsWithDefault    = []string{"id"}
+	automodRuleColumnsWithDefault    = []string{"id", "simulate"}
 	automodRulePrimaryKeyColumns     = []string{"id"}
 )
 
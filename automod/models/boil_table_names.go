@@ -4,19 +4,27 @@
 package models
 
 var TableNames = struct {
-	AutomodLists             string
-	AutomodRuleData          string
-	AutomodRules             string
-	AutomodRulesetConditions string
-	AutomodRulesets          string
-	AutomodTriggeredRules    string
-	AutomodViolations        string
+	AutomodLists              string
+	AutomodLockdownOverwrites string
+	AutomodLockdowns          string
+	AutomodRaidConfigs        string
+	AutomodRuleData           string
+	AutomodRules              string
+	AutomodRulesetConditions  string
+	AutomodRulesets           string
+	AutomodSimulatedEffects   string
+	AutomodTriggeredRules     string
+	AutomodViolations         string
 }{
-	AutomodLists:             "automod_lists",
-	AutomodRuleData:          "automod_rule_data",
-	AutomodRules:             "automod_rules",
-	AutomodRulesetConditions: "automod_ruleset_conditions",
-	AutomodRulesets:          "automod_rulesets",
-	AutomodTriggeredRules:    "automod_triggered_rules",
-	AutomodViolations:        "automod_violations",
+	AutomodLists:              "automod_lists",
+	AutomodLockdownOverwrites: "automod_lockdown_overwrites",
+	AutomodLockdowns:          "automod_lockdowns",
+	AutomodRaidConfigs:        "automod_raid_configs",
+	AutomodRuleData:           "automod_rule_data",
+	AutomodRules:              "automod_rules",
+	AutomodRulesetConditions:  "automod_ruleset_conditions",
+	AutomodRulesets:           "automod_rulesets",
+	AutomodSimulatedEffects:   "automod_simulated_effects",
+	AutomodTriggeredRules:     "automod_triggered_rules",
+	AutomodViolations:         "automod_violations",
 }
@@ -0,0 +1,477 @@
+package automod
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cirelion/flint/automod/models"
+	"github.com/cirelion/flint/bot"
+	"github.com/cirelion/flint/commands"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/pubsub"
+	"github.com/cirelion/flint/common/scheduledevents2"
+	seventsmodels "github.com/cirelion/flint/common/scheduledevents2/models"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+)
+
+// What happens to members joining while the server is locked down
+const (
+	RaidActionNone    = 0
+	RaidActionTimeout = 1
+	RaidActionKick    = 2
+)
+
+// How the lockdown channels are locked
+const (
+	LockdownModeSlowmode = 0
+	LockdownModeDenySend = 1
+)
+
+// the permissions denied to @everyone in the lockdown channels with LockdownModeDenySend
+const lockdownDeniedPerms = discordgo.PermissionSendMessages | discordgo.PermissionSendMessagesInThreads |
+	discordgo.PermissionUsePublicThreads | discordgo.PermissionUsePrivateThreads | discordgo.PermissionAddReactions
+
+const evtEndLockdown = "amod2_end_lockdown"
+
+var (
+	ErrLockdownActive = commands.NewUserError("The server is already locked down")
+	ErrNoLockdown     = commands.NewUserError("The server is not locked down")
+)
+
+type EndLockdownData struct {
+	StartedAt time.Time `json:"started_at"`
+}
+
+var (
+	cachedRaidConfigs = common.CacheSet.RegisterSlot("amod2_raid_configs", nil, int64(0))
+	cachedLockdowns   = common.CacheSet.RegisterSlot("amod2_lockdowns", nil, int64(0))
+)
+
+// DefaultRaidConfig is used for guilds that never saved their raid settings
+func DefaultRaidConfig(guildID int64) *models.AutomodRaidConfig {
+	return &models.AutomodRaidConfig{
+		GuildID:         guildID,
+		JoinThreshold:   10,
+		JoinWindow:      10,
+		NewAccountDays:  7,
+		TimeoutMinutes:  60,
+		SlowmodeSeconds: 30,
+		RaidDuration:    30,
+	}
+}
+
+func FetchRaidConfig(guildID int64) (*models.AutomodRaidConfig, error) {
+	v, err := cachedRaidConfigs.GetCustomFetch(guildID, func(key interface{}) (interface{}, error) {
+		conf, err := models.FindAutomodRaidConfigG(context.Background(), guildID)
+		if err == sql.ErrNoRows {
+			return DefaultRaidConfig(guildID), nil
+		}
+
+		return conf, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*models.AutomodRaidConfig), nil
+}
+
+// FetchActiveLockdown returns the current lockdown of the guild, or nil if there is none
+func FetchActiveLockdown(guildID int64) (*models.AutomodLockdown, error) {
+	v, err := cachedLockdowns.GetCustomFetch(guildID, func(key interface{}) (interface{}, error) {
+		lockdown, err := models.FindAutomodLockdownG(context.Background(), guildID)
+		if err == sql.ErrNoRows {
+			return (*models.AutomodLockdown)(nil), nil
+		}
+
+		return lockdown, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*models.AutomodLockdown), nil
+}
+
+type trackedJoin struct {
+	UserID     int64
+	T          time.Time
+	NewAccount bool
+}
+
+var (
+	recentJoins   = make(map[int64][]trackedJoin)
+	recentJoinsMU sync.Mutex
+)
+
+// trackJoin adds the join to the guild's window, returning the members in the window if it passed the raid thresholds
+func trackJoin(conf *models.AutomodRaidConfig, join trackedJoin) []trackedJoin {
+	recentJoinsMU.Lock()
+	defer recentJoinsMU.Unlock()
+
+	joins := pruneJoins(append(recentJoins[conf.GuildID], join), join.T, time.Duration(conf.JoinWindow)*time.Second)
+	if !raidThresholdsPassed(conf, joins) {
+		recentJoins[conf.GuildID] = joins
+		return nil
+	}
+
+	delete(recentJoins, conf.GuildID)
+	return joins
+}
+
+// pruneJoins removes the joins that are older than the window
+func pruneJoins(joins []trackedJoin, now time.Time, window time.Duration) []trackedJoin {
+	for i, v := range joins {
+		if now.Sub(v.T) <= window {
+			return joins[i:]
+		}
+	}
+
+	return nil
+}
+
+// raidThresholdsPassed returns true if enough members joined in the window, and enough of them
+// have new accounts if the new account ratio is set
+func raidThresholdsPassed(conf *models.AutomodRaidConfig, joins []trackedJoin) bool {
+	if conf.JoinThreshold < 1 || len(joins) < conf.JoinThreshold {
+		return false
+	}
+
+	if conf.NewAccountRatio < 1 {
+		return true
+	}
+
+	newAccounts := 0
+	for _, v := range joins {
+		if v.NewAccount {
+			newAccounts++
+		}
+	}
+
+	return newAccounts*100 >= conf.NewAccountRatio*len(joins)
+}
+
+func gcRecentJoinsLoop() {
+	ticker := time.NewTicker(time.Minute)
+	for {
+		<-ticker.C
+
+		recentJoinsMU.Lock()
+		for guildID, joins := range recentJoins {
+			// the max window is 10 minutes
+			if len(joins) < 1 || time.Since(joins[len(joins)-1].T) > time.Minute*10 {
+				delete(recentJoins, guildID)
+			}
+		}
+		recentJoinsMU.Unlock()
+	}
+}
+
+func (p *Plugin) checkRaid(gs *dstate.GuildSet, ms *dstate.MemberState) {
+	conf, err := FetchRaidConfig(gs.ID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", gs.ID).Error("failed retrieving raid config")
+		return
+	}
+
+	lockdown, err := FetchActiveLockdown(gs.ID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", gs.ID).Error("failed retrieving lockdown")
+		return
+	}
+
+	if lockdown != nil {
+		applyRaidAction(conf, ms.User.ID)
+		return
+	}
+
+	if !conf.Enabled {
+		return
+	}
+
+	now := time.Now()
+	raiders := trackJoin(conf, trackedJoin{
+		UserID:     ms.User.ID,
+		T:          now,
+		NewAccount: now.Sub(bot.SnowflakeToTime(ms.User.ID)) < time.Duration(conf.NewAccountDays)*time.Hour*24,
+	})
+	if raiders == nil {
+		return
+	}
+
+	reason := fmt.Sprintf("Raid detected: %d members joined within %d seconds", len(raiders), conf.JoinWindow)
+	_, _, err = StartLockdown(gs, conf, common.BotUser, reason, true, time.Duration(conf.RaidDuration)*time.Minute)
+	if err != nil {
+		if err != ErrLockdownActive {
+			logger.WithError(err).WithField("guild", gs.ID).Error("failed starting raid lockdown")
+		}
+		return
+	}
+
+	for _, v := range raiders {
+		applyRaidAction(conf, v.UserID)
+	}
+}
+
+func applyRaidAction(conf *models.AutomodRaidConfig, userID int64) {
+	var err error
+	switch conf.Action {
+	case RaidActionTimeout:
+		until := time.Now().Add(time.Duration(conf.TimeoutMinutes) * time.Minute)
+		err = common.BotSession.GuildMemberTimeout(conf.GuildID, userID, &until, "Joined during a server lockdown")
+	case RaidActionKick:
+		err = common.BotSession.GuildMemberDeleteWithReason(conf.GuildID, userID, "Joined during a server lockdown")
+	}
+
+	if err != nil && !common.IsDiscordErr(err, discordgo.ErrCodeUnknownMember, discordgo.ErrCodeMissingPermissions) {
+		logger.WithError(err).WithField("guild", conf.GuildID).Error("failed applying raid action")
+	}
+}
+
+// StartLockdown locks down the configured channels and raises the verification level, recording everything
+// it changes so EndLockdown can restore it. If duration is above 0 the lockdown ends automatically.
+func StartLockdown(gs *dstate.GuildSet, conf *models.AutomodRaidConfig, author *discordgo.User, reason string, isRaid bool, duration time.Duration) (lockdown *models.AutomodLockdown, locked int, err error) {
+	ctx := context.Background()
+
+	lockdown = &models.AutomodLockdown{
+		GuildID:                   gs.ID,
+		StartedAt:                 time.Now().Truncate(time.Microsecond),
+		Reason:                    reason,
+		AuthorID:                  author.ID,
+		IsRaid:                    isRaid,
+		PreviousVerificationLevel: -1,
+	}
+
+	if duration > 0 {
+		lockdown.EndsAt = null.TimeFrom(lockdown.StartedAt.Add(duration))
+	}
+
+	if conf.RaiseVerification && gs.VerificationLevel < discordgo.VerificationLevelHigh {
+		lockdown.PreviousVerificationLevel = int(gs.VerificationLevel)
+	}
+
+	// inserting first also makes sure there's only one lockdown at a time
+	err = lockdown.InsertG(ctx, boil.Infer())
+	if err != nil {
+		if common.ErrPQIsUniqueViolation(err) {
+			return nil, 0, ErrLockdownActive
+		}
+		return nil, 0, err
+	}
+
+	pubsub.EvictCacheSet(cachedLockdowns, gs.ID)
+
+	if lockdown.PreviousVerificationLevel != -1 {
+		level := discordgo.VerificationLevelHigh
+		_, err = common.BotSession.GuildEdit(gs.ID, discordgo.GuildParams{VerificationLevel: &level})
+		if err != nil {
+			logger.WithError(err).WithField("guild", gs.ID).Error("failed raising verification level")
+			lockdown.PreviousVerificationLevel = -1
+			lockdown.UpdateG(ctx, boil.Whitelist("previous_verification_level"))
+		}
+	}
+
+	for _, channelID := range conf.LockdownChannels {
+		cs := gs.GetChannel(channelID)
+		if cs == nil {
+			continue
+		}
+
+		err = lockChannel(ctx, gs, cs, conf)
+		if err != nil {
+			logger.WithError(err).WithField("guild", gs.ID).WithField("channel", channelID).Error("failed locking channel")
+			continue
+		}
+
+		locked++
+	}
+
+	if lockdown.EndsAt.Valid {
+		err = scheduledevents2.ScheduleEvent(evtEndLockdown, gs.ID, lockdown.EndsAt.Time, &EndLockdownData{StartedAt: lockdown.StartedAt})
+		if err != nil {
+			logger.WithError(err).WithField("guild", gs.ID).Error("failed scheduling lockdown end")
+		}
+	}
+
+	description := fmt.Sprintf("**Server locked down** by %s\nReason: %s\nChannels locked: %d", author.String(), reason, locked)
+	if lockdown.EndsAt.Valid {
+		description += fmt.Sprintf("\nEnds <t:%d:R>", lockdown.EndsAt.Time.Unix())
+	}
+	logLockdown(conf, description, 0xef4640)
+
+	return lockdown, locked, nil
+}
+
+// lockChannel records the @everyone overwrite and slowmode of the channel and then locks it
+func lockChannel(ctx context.Context, gs *dstate.GuildSet, cs *dstate.ChannelState, conf *models.AutomodRaidConfig) error {
+	record := &models.AutomodLockdownOverwrite{
+		GuildID:          gs.ID,
+		ChannelID:        cs.ID,
+		RateLimitPerUser: cs.RateLimitPerUser,
+		LockdownMode:     conf.LockdownMode,
+	}
+
+	for _, v := range cs.PermissionOverwrites {
+		if v.Type == discordgo.PermissionOverwriteTypeRole && v.ID == gs.ID {
+			record.HadOverwrite = true
+			record.Allow = v.Allow
+			record.Deny = v.Deny
+		}
+	}
+
+	// record before changing anything, so a failure halfway still gets restored
+	err := record.InsertG(ctx, boil.Infer())
+	if err != nil {
+		return err
+	}
+
+	if conf.LockdownMode == LockdownModeDenySend {
+		return common.BotSession.ChannelPermissionSet(cs.ID, gs.ID, discordgo.PermissionOverwriteTypeRole,
+			record.Allow&^lockdownDeniedPerms, record.Deny|lockdownDeniedPerms)
+	}
+
+	slowmode := conf.SlowmodeSeconds
+	_, err = common.BotSession.ChannelEditComplex(cs.ID, &discordgo.ChannelEdit{RateLimitPerUser: &slowmode})
+	return err
+}
+
+// EndLockdown restores every channel and the verification level to how they were before the lockdown
+func EndLockdown(gs *dstate.GuildSet, conf *models.AutomodRaidConfig, author *discordgo.User, reason string) error {
+	ctx := context.Background()
+
+	lockdown, err := models.FindAutomodLockdownG(ctx, gs.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNoLockdown
+		}
+		return err
+	}
+
+	records, err := models.AutomodLockdownOverwrites(models.AutomodLockdownOverwriteWhere.GuildID.EQ(gs.ID)).AllG(ctx)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, v := range records {
+		err = restoreChannel(v)
+		if err != nil && !common.IsDiscordErr(err, discordgo.ErrCodeUnknownChannel) {
+			// keep the record so ending the lockdown again can retry it
+			logger.WithError(err).WithField("guild", gs.ID).WithField("channel", v.ChannelID).Error("failed restoring channel")
+			failed++
+			continue
+		}
+
+		_, err = v.DeleteG(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return commands.NewUserErrorf("Failed restoring %d channels, make sure i have the Manage Channels and Manage Roles permissions in them and end the lockdown again", failed)
+	}
+
+	if lockdown.PreviousVerificationLevel != -1 {
+		level := discordgo.VerificationLevel(lockdown.PreviousVerificationLevel)
+		_, err = common.BotSession.GuildEdit(gs.ID, discordgo.GuildParams{VerificationLevel: &level})
+		if err != nil {
+			logger.WithError(err).WithField("guild", gs.ID).Error("failed restoring verification level")
+		}
+	}
+
+	_, err = lockdown.DeleteG(ctx)
+	if err != nil {
+		return err
+	}
+
+	pubsub.EvictCacheSet(cachedLockdowns, gs.ID)
+
+	_, err = seventsmodels.ScheduledEvents(
+		seventsmodels.ScheduledEventWhere.GuildID.EQ(gs.ID),
+		seventsmodels.ScheduledEventWhere.EventName.EQ(evtEndLockdown),
+		seventsmodels.ScheduledEventWhere.Processed.EQ(false)).DeleteAll(ctx, common.PQ)
+	if err != nil {
+		logger.WithError(err).WithField("guild", gs.ID).Error("failed clearing lockdown end event")
+	}
+
+	logLockdown(conf, fmt.Sprintf("**Lockdown ended** by %s\nReason: %s\nChannels restored: %d", author.String(), reason, len(records)), 0x49ed47)
+	return nil
+}
+
+// restoreChannel only restores what the lockdown changed, so edits made to the channel in the meantime are kept
+func restoreChannel(record *models.AutomodLockdownOverwrite) error {
+	if record.LockdownMode == LockdownModeDenySend {
+		var err error
+		if record.HadOverwrite {
+			err = common.BotSession.ChannelPermissionSet(record.ChannelID, record.GuildID, discordgo.PermissionOverwriteTypeRole, record.Allow, record.Deny)
+		} else {
+			err = common.BotSession.ChannelPermissionDelete(record.ChannelID, record.GuildID)
+		}
+
+		if err != nil && !common.IsDiscordErr(err, discordgo.ErrCodeUnknownOverwrite) {
+			return err
+		}
+
+		return nil
+	}
+
+	slowmode := record.RateLimitPerUser
+	_, err := common.BotSession.ChannelEditComplex(record.ChannelID, &discordgo.ChannelEdit{RateLimitPerUser: &slowmode})
+	return err
+}
+
+func logLockdown(conf *models.AutomodRaidConfig, description string, color int) {
+	if conf.LogChannel == 0 {
+		return
+	}
+
+	_, err := common.BotSession.ChannelMessageSendEmbed(conf.LogChannel, &discordgo.MessageEmbed{
+		Description: description,
+		Color:       color,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	})
+	if err != nil && !common.IsDiscordErr(err, discordgo.ErrCodeMissingPermissions, discordgo.ErrCodeUnknownChannel, discordgo.ErrCodeMissingAccess) {
+		logger.WithError(err).WithField("guild", conf.GuildID).Error("failed sending lockdown log message")
+	}
+}
+
+func handleEndLockdown(evt *seventsmodels.ScheduledEvent, data interface{}) (retry bool, err error) {
+	dataCast := data.(*EndLockdownData)
+
+	lockdown, err := models.FindAutomodLockdownG(context.Background(), evt.GuildID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return true, err
+	}
+
+	if !lockdown.StartedAt.Equal(dataCast.StartedAt) {
+		// this was for an earlier lockdown
+		return false, nil
+	}
+
+	gs := bot.State.GetGuild(evt.GuildID)
+	if gs == nil {
+		return false, nil
+	}
+
+	conf, err := FetchRaidConfig(evt.GuildID)
+	if err != nil {
+		return true, err
+	}
+
+	err = EndLockdown(gs, conf, common.BotUser, "Lockdown expired")
+	if err == ErrNoLockdown {
+		return false, nil
+	}
+
+	return scheduledevents2.CheckDiscordErrRetry(err), err
+}
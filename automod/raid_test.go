@@ -0,0 +1,56 @@
+package automod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/automod/models"
+)
+
+func TestRaidThresholdsPassed(t *testing.T) {
+	joins := func(total, newAccounts int) []trackedJoin {
+		result := make([]trackedJoin, total)
+		for i := range result {
+			result[i].NewAccount = i < newAccounts
+		}
+		return result
+	}
+
+	cases := []struct {
+		threshold int
+		ratio     int
+		joins     []trackedJoin
+		expected  bool
+	}{
+		{threshold: 5, ratio: 0, joins: joins(4, 4), expected: false},
+		{threshold: 5, ratio: 0, joins: joins(5, 0), expected: true},
+		{threshold: 5, ratio: 50, joins: joins(10, 4), expected: false},
+		{threshold: 5, ratio: 50, joins: joins(10, 5), expected: true},
+		{threshold: 5, ratio: 50, joins: joins(4, 4), expected: false},
+	}
+
+	for i, c := range cases {
+		conf := &models.AutomodRaidConfig{JoinThreshold: c.threshold, NewAccountRatio: c.ratio}
+		if passed := raidThresholdsPassed(conf, c.joins); passed != c.expected {
+			t.Errorf("case %d: expected %t, got %t", i, c.expected, passed)
+		}
+	}
+}
+
+func TestPruneJoins(t *testing.T) {
+	now := time.Now()
+	joins := []trackedJoin{
+		{UserID: 1, T: now.Add(-time.Second * 30)},
+		{UserID: 2, T: now.Add(-time.Second * 8)},
+		{UserID: 3, T: now.Add(-time.Second * 2)},
+	}
+
+	pruned := pruneJoins(joins, now, time.Second*10)
+	if len(pruned) != 2 || pruned[0].UserID != 2 {
+		t.Errorf("unexpected pruned joins: %v", pruned)
+	}
+
+	if pruned := pruneJoins(joins, now.Add(time.Minute), time.Second*10); len(pruned) != 0 {
+		t.Errorf("expected all joins to be pruned, got %v", pruned)
+	}
+}
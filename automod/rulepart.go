@@ -51,6 +51,19 @@ var RulePartMap = map[int]RulePart{
 	36: &SlowmodeTrigger{Links: true, ChannelBased: false},
 	37: &SlowmodeTrigger{Links: true, ChannelBased: true},
 	38: &AutomodExecution{},
+	39: &DuplicateMessagesTrigger{},
+	40: &MessageTextTrigger{&EmojiCheck{}},
+	41: &NicknameTextTrigger{&EmojiCheck{}},
+	42: &UsernameTextTrigger{&EmojiCheck{}},
+	43: &MessageTextTrigger{&ZalgoCheck{}},
+	44: &NicknameTextTrigger{&ZalgoCheck{}},
+	45: &UsernameTextTrigger{&ZalgoCheck{}},
+	46: &MessageTextTrigger{&InvisibleCharsCheck{}},
+	47: &NicknameTextTrigger{&InvisibleCharsCheck{}},
+	48: &UsernameTextTrigger{&InvisibleCharsCheck{}},
+	49: &MessageTextTrigger{&MixedScriptCheck{}},
+	50: &NicknameTextTrigger{&MixedScriptCheck{}},
+	51: &UsernameTextTrigger{&MixedScriptCheck{}},
 
 	// Conditions 2xx
 	200: &MemberRolesCondition{Blacklist: true},
@@ -0,0 +1,182 @@
+package automod
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cirelion/flint/automod/models"
+	"github.com/cirelion/flint/common"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+const (
+	// Simulated effects are kept for this long
+	SimulationMaxDays = 30
+
+	// Max number of simulated effects kept per guild, the oldest are deleted first
+	MaxSimulatedEffects = 10000
+
+	maxSimulatedMessageLength = 500
+)
+
+// simulateRuleEffects records the effects of a rule in simulation mode instead of applying them
+func (p *Plugin) simulateRuleEffects(rule *ParsedRule, ctxData *TriggeredRuleData) {
+	if len(rule.Effects) < 1 {
+		return
+	}
+
+	cid := int64(0)
+	if ctxData.CS != nil {
+		cid = ctxData.CS.ID
+	}
+
+	content := ""
+	if ctxData.Message != nil {
+		content = common.CutStringShort(ctxData.Message.Content, maxSimulatedMessageLength)
+	}
+
+	// all the effects of a match share the time so they can be grouped back together
+	now := time.Now()
+
+	tx, err := common.PQ.BeginTx(context.Background(), nil)
+	if err != nil {
+		logger.WithError(err).Error("failed creating transaction")
+		return
+	}
+
+	for _, effect := range rule.Effects {
+		m := &models.AutomodSimulatedEffect{
+			GuildID:        ctxData.GS.ID,
+			CreatedAt:      now,
+			RuleID:         rule.Model.ID,
+			RuleName:       rule.Model.Name,
+			RulesetName:    rule.Model.R.Ruleset.Name,
+			EffectTypeid:   effect.RuleModel.TypeID,
+			UserID:         ctxData.MS.User.ID,
+			UserName:       ctxData.MS.User.String(),
+			ChannelID:      cid,
+			MessageContent: content,
+		}
+
+		err = m.Insert(context.Background(), tx, boil.Infer())
+		if err != nil {
+			logger.WithError(err).Error("failed inserting simulated effect")
+			tx.Rollback()
+			return
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		logger.WithError(err).Error("failed committing simulated effects")
+	}
+}
+
+func gcSimulatedEffectsLoop() {
+	ticker := time.NewTicker(time.Hour)
+	for {
+		<-ticker.C
+		gcSimulatedEffects()
+	}
+}
+
+// gcSimulatedEffects deletes the simulated effects older than SimulationMaxDays and the oldest ones above MaxSimulatedEffects per guild
+func gcSimulatedEffects() {
+	_, err := common.PQ.Exec("DELETE FROM automod_simulated_effects WHERE created_at < $1;", time.Now().Add(-time.Hour*24*SimulationMaxDays))
+	if err != nil {
+		logger.WithError(err).Error("failed deleting old simulated effects")
+		return
+	}
+
+	_, err = common.PQ.Exec(`DELETE FROM automod_simulated_effects WHERE id IN (
+		SELECT id FROM (
+			SELECT id, row_number() OVER (PARTITION BY guild_id ORDER BY created_at DESC, id DESC) AS n FROM automod_simulated_effects
+		) AS ranked WHERE n > $1
+	);`, MaxSimulatedEffects)
+	if err != nil {
+		logger.WithError(err).Error("failed deleting simulated effects above the limit")
+	}
+}
+
+// SimulatedEffectCount is the number of times an effect would have been applied by a rule
+type SimulatedEffectCount struct {
+	RuleID       int64  `boil:"rule_id"`
+	RuleName     string `boil:"rule_name"`
+	RulesetName  string `boil:"ruleset_name"`
+	EffectTypeid int    `boil:"effect_typeid"`
+	Count        int64  `boil:"count"`
+}
+
+// SimulatedMatch is a single match of a simulated rule with all the effects that would have been applied
+type SimulatedMatch struct {
+	CreatedAt      time.Time
+	RuleID         int64
+	RuleName       string
+	RulesetName    string
+	UserID         int64
+	UserName       string
+	ChannelID      int64
+	MessageContent string
+	EffectTypeids  []int
+}
+
+// GetSimulationReport returns the effect counts per rule and the latest matches of simulated rules in the last days
+func GetSimulationReport(ctx context.Context, guildID int64, days int, maxMatches int) ([]*SimulatedEffectCount, []*SimulatedMatch, error) {
+	since := time.Now().Add(-time.Hour * 24 * time.Duration(days))
+
+	var counts []*SimulatedEffectCount
+	err := models.AutomodSimulatedEffects(
+		qm.Select("rule_id", "max(rule_name) AS rule_name", "max(ruleset_name) AS ruleset_name", "effect_typeid", "count(*) AS count"),
+		qm.Where("guild_id = ? AND created_at > ?", guildID, since),
+		qm.GroupBy("rule_id, effect_typeid"),
+	).Bind(ctx, common.PQ, &counts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].RuleID != counts[j].RuleID {
+			return counts[i].RuleID < counts[j].RuleID
+		}
+
+		return counts[i].Count > counts[j].Count
+	})
+
+	// the effects of a match are stored as separate rows, so fetch enough rows to fill the matches
+	rows, err := models.AutomodSimulatedEffects(qm.Where("guild_id = ? AND created_at > ?", guildID, since),
+		qm.OrderBy("created_at desc, id desc"), qm.Limit(maxMatches*MaxRuleParts)).All(ctx, common.PQ)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var matches []*SimulatedMatch
+	for _, v := range rows {
+		if len(matches) > 0 {
+			last := matches[len(matches)-1]
+			if last.CreatedAt.Equal(v.CreatedAt) && last.UserID == v.UserID && last.RuleID == v.RuleID {
+				last.EffectTypeids = append(last.EffectTypeids, v.EffectTypeid)
+				continue
+			}
+		}
+
+		if len(matches) >= maxMatches {
+			break
+		}
+
+		matches = append(matches, &SimulatedMatch{
+			CreatedAt:      v.CreatedAt,
+			RuleID:         v.RuleID,
+			RuleName:       v.RuleName,
+			RulesetName:    v.RulesetName,
+			UserID:         v.UserID,
+			UserName:       v.UserName,
+			ChannelID:      v.ChannelID,
+			MessageContent: v.MessageContent,
+			EffectTypeids:  []int{v.EffectTypeid},
+		})
+	}
+
+	return counts, matches, nil
+}
@@ -0,0 +1,442 @@
+package automod
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/cirelion/flint/lib/confusables"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate"
+)
+
+// TextCheck is a check on a piece of text, used by the message, nickname and username variants of a trigger
+type TextCheck interface {
+	DataType() interface{}
+	UserSettings() []*SettingDef
+
+	// CheckName completes "Message with ...", "Nickname with ..." etc
+	CheckName() string
+	// CheckDescription completes "Triggers on messages with ..." etc
+	CheckDescription() string
+
+	CheckText(text string, data interface{}) bool
+}
+
+var _ MessageTrigger = (*MessageTextTrigger)(nil)
+
+type MessageTextTrigger struct {
+	TextCheck
+}
+
+func (t *MessageTextTrigger) Kind() RulePartType {
+	return RulePartTrigger
+}
+
+func (t *MessageTextTrigger) Name() string {
+	return "Message with " + t.CheckName()
+}
+
+func (t *MessageTextTrigger) Description() string {
+	return "Triggers on messages with " + t.CheckDescription()
+}
+
+func (t *MessageTextTrigger) CheckMessage(triggerCtx *TriggerContext, cs *dstate.ChannelState, m *discordgo.Message, mdStripped string) (bool, error) {
+	return t.CheckText(m.Content, triggerCtx.Data), nil
+}
+
+var _ NicknameListener = (*NicknameTextTrigger)(nil)
+
+type NicknameTextTrigger struct {
+	TextCheck
+}
+
+func (t *NicknameTextTrigger) Kind() RulePartType {
+	return RulePartTrigger
+}
+
+func (t *NicknameTextTrigger) Name() string {
+	return "Nickname with " + t.CheckName()
+}
+
+func (t *NicknameTextTrigger) Description() string {
+	return "Triggers when a members nickname has " + t.CheckDescription()
+}
+
+func (t *NicknameTextTrigger) CheckNickname(triggerCtx *TriggerContext) (bool, error) {
+	return t.CheckText(triggerCtx.MS.Member.Nick, triggerCtx.Data), nil
+}
+
+var _ UsernameListener = (*UsernameTextTrigger)(nil)
+
+type UsernameTextTrigger struct {
+	TextCheck
+}
+
+func (t *UsernameTextTrigger) Kind() RulePartType {
+	return RulePartTrigger
+}
+
+func (t *UsernameTextTrigger) Name() string {
+	return "Join username with " + t.CheckName()
+}
+
+func (t *UsernameTextTrigger) Description() string {
+	return "Triggers when a member joins with a username that has " + t.CheckDescription()
+}
+
+func (t *UsernameTextTrigger) CheckUsername(triggerCtx *TriggerContext) (bool, error) {
+	return t.CheckText(triggerCtx.MS.User.Username, triggerCtx.Data), nil
+}
+
+/////////////////////////////////////////////////////////////
+
+var customEmojiRegex = regexp.MustCompile(`<a?:[\w~]{1,32}:\d{15,20}>`)
+
+func isEmojiRune(r rune) bool {
+	return (r >= 0x1F000 && r <= 0x1FAFF) || // emoticons, pictographs, transport, flags etc
+		(r >= 0x2600 && r <= 0x27BF) || // misc symbols and dingbats
+		(r >= 0x2B00 && r <= 0x2BFF) ||
+		(r >= 0x2300 && r <= 0x23FF)
+}
+
+func isEmojiModifier(r rune) bool {
+	return r == 0xFE0F || r == 0x20E3 || (r >= 0x1F3FB && r <= 0x1F3FF) || (r >= 0xE0020 && r <= 0xE007F)
+}
+
+// countEmoji returns the number of emoji in the text, with sequences such as flags, skin tones and
+// zero width joined emoji counted as one, and the number of other non space characters
+func countEmoji(text string) (emoji int, other int) {
+	emoji = len(customEmojiRegex.FindAllStringIndex(text, -1))
+	text = customEmojiRegex.ReplaceAllString(text, "")
+
+	prev := rune(0)
+	joined := false
+	for _, r := range text {
+		switch {
+		case isEmojiModifier(r):
+			// part of the previous emoji
+		case r == 0x200D && isEmojiRune(prev):
+			joined = true
+		case isEmojiRune(r):
+			// flags are made of two regional indicators
+			isSecondIndicator := r >= 0x1F1E6 && r <= 0x1F1FF && prev >= 0x1F1E6 && prev <= 0x1F1FF
+			if !joined && !isSecondIndicator {
+				emoji++
+			}
+
+			joined = false
+			if isSecondIndicator {
+				// the next indicator starts a new flag
+				prev = 0
+				continue
+			}
+		case !unicode.IsSpace(r):
+			other++
+		}
+
+		prev = r
+	}
+
+	return emoji, other
+}
+
+var _ TextCheck = (*EmojiCheck)(nil)
+
+type EmojiCheck struct{}
+
+type EmojiCheckData struct {
+	MaxEmoji  int
+	MaxRatio  int
+	MinLength int
+}
+
+func (e *EmojiCheck) DataType() interface{} {
+	return &EmojiCheckData{}
+}
+
+func (e *EmojiCheck) UserSettings() []*SettingDef {
+	return []*SettingDef{
+		{
+			Name:    "Max emoji (0 to disable)",
+			Key:     "MaxEmoji",
+			Kind:    SettingTypeInt,
+			Min:     0,
+			Max:     1000,
+			Default: 10,
+		},
+		{
+			Name:    "Max percentage of emoji (0 to disable)",
+			Key:     "MaxRatio",
+			Kind:    SettingTypeInt,
+			Min:     0,
+			Max:     100,
+			Default: 0,
+		},
+		{
+			Name:    "Min length for the percentage check",
+			Key:     "MinLength",
+			Kind:    SettingTypeInt,
+			Min:     0,
+			Max:     2000,
+			Default: 10,
+		},
+	}
+}
+
+func (e *EmojiCheck) CheckName() string {
+	return "too many emoji"
+}
+
+func (e *EmojiCheck) CheckDescription() string {
+	return "more than x emoji, or where more than x% of the characters are emoji"
+}
+
+func (e *EmojiCheck) CheckText(text string, data interface{}) bool {
+	settings := data.(*EmojiCheckData)
+
+	emoji, other := countEmoji(text)
+	if settings.MaxEmoji > 0 && emoji > settings.MaxEmoji {
+		return true
+	}
+
+	total := emoji + other
+	if settings.MaxRatio > 0 && total > 0 && total >= settings.MinLength && emoji*100/total > settings.MaxRatio {
+		return true
+	}
+
+	return false
+}
+
+/////////////////////////////////////////////////////////////
+
+var _ TextCheck = (*ZalgoCheck)(nil)
+
+type ZalgoCheck struct{}
+
+type ZalgoCheckData struct {
+	MaxMarks   int
+	MaxDensity int
+}
+
+func (z *ZalgoCheck) DataType() interface{} {
+	return &ZalgoCheckData{}
+}
+
+func (z *ZalgoCheck) UserSettings() []*SettingDef {
+	return []*SettingDef{
+		{
+			Name:    "Max combining marks on a single character",
+			Key:     "MaxMarks",
+			Kind:    SettingTypeInt,
+			Min:     1,
+			Max:     100,
+			Default: 3,
+		},
+		{
+			Name:    "Max combining marks per 100 characters (0 to disable)",
+			Key:     "MaxDensity",
+			Kind:    SettingTypeInt,
+			Min:     0,
+			Max:     1000,
+			Default: 100,
+		},
+	}
+}
+
+func (z *ZalgoCheck) CheckName() string {
+	return "zalgo text"
+}
+
+func (z *ZalgoCheck) CheckDescription() string {
+	return "zalgo text, characters stacked with combining marks (accents and such)"
+}
+
+func (z *ZalgoCheck) CheckText(text string, data interface{}) bool {
+	settings := data.(*ZalgoCheckData)
+
+	marks := 0
+	totalMarks := 0
+	base := 0
+	for _, r := range text {
+		if unicode.In(r, unicode.Mn, unicode.Me) && !isEmojiModifier(r) {
+			marks++
+			totalMarks++
+			if marks > settings.MaxMarks {
+				return true
+			}
+			continue
+		}
+
+		marks = 0
+		if !unicode.IsSpace(r) {
+			base++
+		}
+	}
+
+	if settings.MaxDensity > 0 && base > 0 && totalMarks*100/base > settings.MaxDensity {
+		return true
+	}
+
+	return false
+}
+
+/////////////////////////////////////////////////////////////
+
+var _ TextCheck = (*InvisibleCharsCheck)(nil)
+
+type InvisibleCharsCheck struct{}
+
+type InvisibleCharsCheckData struct {
+	MaxInvisible       int
+	DirectionOverrides bool
+}
+
+func (i *InvisibleCharsCheck) DataType() interface{} {
+	return &InvisibleCharsCheckData{}
+}
+
+func (i *InvisibleCharsCheck) UserSettings() []*SettingDef {
+	return []*SettingDef{
+		{
+			Name:    "Max invisible characters",
+			Key:     "MaxInvisible",
+			Kind:    SettingTypeInt,
+			Min:     0,
+			Max:     2000,
+			Default: 2,
+		},
+		{
+			Name:    "Always trigger on text direction overrides (such as right-to-left override)",
+			Key:     "DirectionOverrides",
+			Kind:    SettingTypeBool,
+			Default: true,
+		},
+	}
+}
+
+func (i *InvisibleCharsCheck) CheckName() string {
+	return "invisible characters"
+}
+
+func (i *InvisibleCharsCheck) CheckDescription() string {
+	return "more than x invisible or formatting characters, such as zero width spaces, blank fillers or text direction overrides"
+}
+
+func isDirectionOverride(r rune) bool {
+	return (r >= 0x202A && r <= 0x202E) || (r >= 0x2066 && r <= 0x2069)
+}
+
+func isInvisibleRune(r rune) bool {
+	switch r {
+	case 0x115F, 0x1160, 0x3164, 0xFFA0, 0x2800, 0x034F:
+		// fillers that render as blank space
+		return true
+	}
+
+	return unicode.Is(unicode.Cf, r)
+}
+
+func (i *InvisibleCharsCheck) CheckText(text string, data interface{}) bool {
+	settings := data.(*InvisibleCharsCheckData)
+
+	count := 0
+	prev := rune(0)
+	for _, r := range text {
+		if settings.DirectionOverrides && isDirectionOverride(r) {
+			return true
+		}
+
+		// zero width joiners and tags are also used in emoji sequences
+		inEmoji := (r == 0x200D || isEmojiModifier(r)) && (isEmojiRune(prev) || isEmojiModifier(prev))
+		if !inEmoji && isInvisibleRune(r) {
+			count++
+			if count > settings.MaxInvisible {
+				return true
+			}
+		}
+
+		if !inEmoji || r != 0x200D {
+			prev = r
+		}
+	}
+
+	return false
+}
+
+/////////////////////////////////////////////////////////////
+
+var _ TextCheck = (*MixedScriptCheck)(nil)
+
+type MixedScriptCheck struct{}
+
+type MixedScriptCheckData struct {
+	MinWords int
+}
+
+func (ms *MixedScriptCheck) DataType() interface{} {
+	return &MixedScriptCheckData{}
+}
+
+func (ms *MixedScriptCheck) UserSettings() []*SettingDef {
+	return []*SettingDef{
+		{
+			Name:    "Min number of mixed script words",
+			Key:     "MinWords",
+			Kind:    SettingTypeInt,
+			Min:     1,
+			Max:     100,
+			Default: 1,
+		},
+	}
+}
+
+func (ms *MixedScriptCheck) CheckName() string {
+	return "look-alike characters from other scripts"
+}
+
+func (ms *MixedScriptCheck) CheckDescription() string {
+	return "words mixing look-alike letters from different scripts, like a cyrillic \"а\" in an otherwise latin word"
+}
+
+// scripts with letters that are commonly mistaken for each other
+var confusableScripts = []*unicode.RangeTable{unicode.Latin, unicode.Cyrillic, unicode.Greek, unicode.Armenian, unicode.Cherokee}
+
+// isMixedScriptWord returns true if the word has letters from several of the confusable scripts, and
+// some of them are look-alikes of other characters
+func isMixedScriptWord(word string) bool {
+	var seen *unicode.RangeTable
+	mixed := false
+
+	for _, r := range word {
+		for _, script := range confusableScripts {
+			if !unicode.Is(script, r) {
+				continue
+			}
+
+			if seen != nil && seen != script {
+				mixed = true
+			}
+			seen = script
+			break
+		}
+	}
+
+	return mixed && confusables.SanitizeText(word) != word
+}
+
+func (ms *MixedScriptCheck) CheckText(text string, data interface{}) bool {
+	settings := data.(*MixedScriptCheckData)
+
+	count := 0
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.In(r, unicode.Mn) }) {
+		if isMixedScriptWord(word) {
+			count++
+			if count >= settings.MinWords {
+				return true
+			}
+		}
+	}
+
+	return false
+}
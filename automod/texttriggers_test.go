@@ -0,0 +1,102 @@
+package automod
+
+import (
+	"testing"
+
+	"github.com/cirelion/flint/lib/confusables"
+)
+
+func TestCountEmoji(t *testing.T) {
+	cases := []struct {
+		text  string
+		emoji int
+		other int
+	}{
+		{text: "hello", emoji: 0, other: 5},
+		{text: "hi 😀😀", emoji: 2, other: 2},
+		{text: "👍🏽 ❤️", emoji: 2, other: 0},
+		{text: "🇳🇱🇩🇪", emoji: 2, other: 0},
+		{text: "👨\u200d👩\u200d👧", emoji: 1, other: 0},
+		{text: "<:pog:123456789012345678> <a:dance:123456789012345678> ok", emoji: 2, other: 2},
+	}
+
+	for _, c := range cases {
+		emoji, other := countEmoji(c.text)
+		if emoji != c.emoji || other != c.other {
+			t.Errorf("%q: got %d emoji and %d other, expected %d and %d", c.text, emoji, other, c.emoji, c.other)
+		}
+	}
+}
+
+func TestEmojiCheck(t *testing.T) {
+	check := &EmojiCheck{}
+
+	if !check.CheckText("😀😀😀😀", &EmojiCheckData{MaxEmoji: 3}) {
+		t.Error("expected more than max emoji to trigger")
+	}
+
+	if check.CheckText("😀😀😀", &EmojiCheckData{MaxEmoji: 3}) {
+		t.Error("expected max emoji not to trigger")
+	}
+
+	if !check.CheckText("ok 😀😀😀😀😀😀😀😀", &EmojiCheckData{MaxRatio: 50, MinLength: 5}) {
+		t.Error("expected the emoji ratio to trigger")
+	}
+
+	if check.CheckText("😀", &EmojiCheckData{MaxRatio: 50, MinLength: 5}) {
+		t.Error("expected short messages to be ignored by the ratio")
+	}
+}
+
+func TestZalgoCheck(t *testing.T) {
+	check := &ZalgoCheck{}
+	settings := &ZalgoCheckData{MaxMarks: 3, MaxDensity: 100}
+
+	if check.CheckText("Tiếng Việt có dấu", settings) {
+		t.Error("expected normal accents not to trigger")
+	}
+
+	if !check.CheckText("h̀́̂̃ello", settings) {
+		t.Error("expected stacked marks to trigger")
+	}
+
+	if !check.CheckText("h̀é̂l̃̄l̅̆", &ZalgoCheckData{MaxMarks: 3, MaxDensity: 100}) {
+		t.Error("expected dense marks to trigger")
+	}
+}
+
+func TestInvisibleCharsCheck(t *testing.T) {
+	check := &InvisibleCharsCheck{}
+	settings := &InvisibleCharsCheckData{MaxInvisible: 1, DirectionOverrides: true}
+
+	if check.CheckText("family 👨\u200d👩\u200d👧 🏴\U000e0067\U000e0062\U000e0065\U000e006e\U000e0067\U000e007f", settings) {
+		t.Error("expected emoji sequences not to trigger")
+	}
+
+	if !check.CheckText("free\u200b\u200bnitro", settings) {
+		t.Error("expected zero width spaces to trigger")
+	}
+
+	if !check.CheckText("\u3164\u3164", settings) {
+		t.Error("expected blank fillers to trigger")
+	}
+
+	if !check.CheckText("file\u202egnp.exe", settings) {
+		t.Error("expected a right-to-left override to trigger")
+	}
+}
+
+func TestMixedScriptCheck(t *testing.T) {
+	confusables.Init()
+
+	check := &MixedScriptCheck{}
+	settings := &MixedScriptCheckData{MinWords: 1}
+
+	if !check.CheckText("free nitro at disc\u043erd", settings) {
+		t.Error("expected a cyrillic o in a latin word to trigger")
+	}
+
+	if check.CheckText("привет hello Pokémon", settings) {
+		t.Error("expected single script words not to trigger")
+	}
+}
@@ -1601,7 +1601,7 @@ func (am *AutomodExecution) Name() (name string) {
 }
 
 func (am *AutomodExecution) Description() (description string) {
-	return "Triggers when a message is detected by Discord Automod"
+	return "Triggers once per execution of a Discord Automod rule, including when the message was blocked"
 }
 func (am *AutomodExecution) UserSettings() []*SettingDef {
 	return []*SettingDef{
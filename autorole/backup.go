@@ -0,0 +1,31 @@
+package autorole
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cirelion/flint/backup"
+)
+
+var _ backup.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	return GetGeneralConfig(guildID)
+}
+
+func (p *Plugin) ImportBackup(ctx context.Context, ic *backup.ImportContext, data json.RawMessage) error {
+	var config GeneralConfig
+	err := json.Unmarshal(data, &config)
+	if err != nil {
+		return err
+	}
+
+	ic.Remap.Tagged(&config)
+	form := &Form{GeneralConfig: config}
+	err = ic.ValidateForm("Autorole", form)
+	if err != nil {
+		return err
+	}
+
+	return form.Save(ic.GS.ID)
+}
@@ -0,0 +1,270 @@
+// Package backup exports the settings of every plugin for a server into a single snapshot,
+// which can be restored later or cloned into another server.
+//
+// Plugins take part by implementing PluginWithBackup.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/featureflags"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate"
+	"github.com/cirelion/flint/web"
+)
+
+// SnapshotVersion is the current version of the snapshot format, bump this when making incompatible changes
+const SnapshotVersion = 1
+
+// MaxSnapshotSize is the max size of a snapshot file accepted for restoring
+const MaxSnapshotSize = 5 * 1024 * 1024
+
+var logger = common.GetPluginLogger(&Plugin{})
+
+type Plugin struct{}
+
+func (p *Plugin) PluginInfo() *common.PluginInfo {
+	return &common.PluginInfo{
+		Name:     "Backup",
+		SysName:  "backup",
+		Category: common.PluginCategoryCore,
+	}
+}
+
+func RegisterPlugin() {
+	common.RegisterPlugin(&Plugin{})
+}
+
+// PluginWithBackup is implemented by plugins whose settings are included in server backups
+type PluginWithBackup interface {
+	common.Plugin
+
+	// ExportBackup returns the settings of the plugin on the server, it's included in the snapshot as json
+	ExportBackup(ctx context.Context, guildID int64) (interface{}, error)
+
+	// ImportBackup replaces the settings of the plugin on the server with the exported ones,
+	// channel and role IDs in data have to be passed through ic.Remap
+	ImportBackup(ctx context.Context, ic *ImportContext, data json.RawMessage) error
+}
+
+// Snapshot is the settings of all plugins on a server at a point in time
+type Snapshot struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+
+	GuildID   int64  `json:"guild_id,string"`
+	GuildName string `json:"guild_name"`
+
+	// The channels and roles of the server at the time, used to find the matching ones by name when restoring on another server
+	Channels []*SnapshotChannel `json:"channels"`
+	Roles    []*SnapshotRole    `json:"roles"`
+
+	// Plugin settings keyed by the SysName of the plugin
+	Plugins map[string]json.RawMessage `json:"plugins"`
+}
+
+type SnapshotChannel struct {
+	ID   int64                 `json:"id,string"`
+	Name string                `json:"name"`
+	Type discordgo.ChannelType `json:"type"`
+}
+
+type SnapshotRole struct {
+	ID   int64  `json:"id,string"`
+	Name string `json:"name"`
+}
+
+// BackupPlugins returns the registered plugins that are included in backups
+func BackupPlugins() []PluginWithBackup {
+	var result []PluginWithBackup
+	for _, v := range common.Plugins {
+		if cast, ok := v.(PluginWithBackup); ok {
+			result = append(result, cast)
+		}
+	}
+
+	return result
+}
+
+// Export creates a snapshot of the settings of all plugins on the server
+func Export(ctx context.Context, gs *dstate.GuildSet) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		Version:   SnapshotVersion,
+		CreatedAt: time.Now().UTC(),
+		GuildID:   gs.ID,
+		GuildName: gs.Name,
+		Plugins:   make(map[string]json.RawMessage),
+	}
+
+	for _, c := range gs.Channels {
+		snapshot.Channels = append(snapshot.Channels, &SnapshotChannel{ID: c.ID, Name: c.Name, Type: c.Type})
+	}
+
+	for _, r := range gs.Roles {
+		snapshot.Roles = append(snapshot.Roles, &SnapshotRole{ID: r.ID, Name: r.Name})
+	}
+
+	for _, p := range BackupPlugins() {
+		sysName := p.PluginInfo().SysName
+
+		data, err := p.ExportBackup(ctx, gs.ID)
+		if err != nil {
+			return nil, errors.WithMessage(err, sysName)
+		}
+
+		if data == nil {
+			continue
+		}
+
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return nil, errors.WithMessage(err, sysName)
+		}
+
+		snapshot.Plugins[sysName] = encoded
+	}
+
+	return snapshot, nil
+}
+
+// Validate returns an error if the snapshot can't be imported
+func (s *Snapshot) Validate() error {
+	if s.Version < 1 || s.Version > SnapshotVersion {
+		return ErrUnsupportedVersion
+	}
+
+	if s.GuildID == 0 || len(s.Plugins) < 1 {
+		return ErrInvalidSnapshot
+	}
+
+	return nil
+}
+
+var (
+	ErrUnsupportedVersion = errors.New("Unsupported backup version, it might have been made by a newer version of the bot")
+	ErrInvalidSnapshot    = errors.New("Not a valid backup file")
+)
+
+// ImportContext is passed to plugins when importing a snapshot
+type ImportContext struct {
+	// The server the snapshot is imported into
+	GS *dstate.GuildSet
+
+	// The user that started the import
+	UserID   int64
+	Username string
+
+	Remap *Remapper
+
+	warnings []string
+}
+
+// Warn adds a message to the import result, for settings that could not be imported as is
+func (ic *ImportContext) Warn(format string, args ...interface{}) {
+	ic.warnings = append(ic.warnings, fmt.Sprintf(format, args...))
+}
+
+// ValidateForm runs the control panel validation on the imported settings, adding the problems as warnings
+// prefixed with the plugin name. Returns an error if the settings are invalid and should not be imported.
+func (ic *ImportContext) ValidateForm(pluginName string, form interface{}) error {
+	tmpl := web.TemplateData{}
+	if web.ValidateForm(ic.GS, tmpl, form) {
+		return nil
+	}
+
+	for _, v := range tmpl.Alerts() {
+		ic.Warn("%s: %s", pluginName, v.Message)
+	}
+
+	return errors.New("invalid " + pluginName + " settings")
+}
+
+// ImportResult summarizes what was done during an import
+type ImportResult struct {
+	Imported []string
+	Failed   []string
+	Warnings []string
+}
+
+func (r *ImportResult) String() string {
+	out := fmt.Sprintf("Imported the settings of %d plugins: %s.", len(r.Imported), strings.Join(r.Imported, ", "))
+	if len(r.Failed) > 0 {
+		out += "\nFailed importing: " + strings.Join(r.Failed, ", ")
+	}
+
+	for _, v := range r.Warnings {
+		out += "\n" + v
+	}
+
+	return out
+}
+
+// Import replaces the settings of the plugins included in the snapshot on the server,
+// channels and roles are mapped to the ones on the server with the same ID or name.
+// If onlyPlugins is not empty only the plugins with those SysNames are imported.
+func Import(ctx context.Context, snapshot *Snapshot, gs *dstate.GuildSet, userID int64, username string, onlyPlugins []string) (*ImportResult, error) {
+	err := snapshot.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	ic := &ImportContext{
+		GS:       gs,
+		UserID:   userID,
+		Username: username,
+		Remap:    NewRemapper(snapshot, gs),
+	}
+
+	result := &ImportResult{}
+	for _, p := range BackupPlugins() {
+		info := p.PluginInfo()
+		data, ok := snapshot.Plugins[info.SysName]
+		if !ok || (len(onlyPlugins) > 0 && !common.ContainsStringSlice(onlyPlugins, info.SysName)) {
+			continue
+		}
+
+		err := p.ImportBackup(ctx, ic, data)
+		if err != nil {
+			logger.WithError(err).WithField("guild", gs.ID).WithField("plugin", info.SysName).Error("failed importing backup")
+			result.Failed = append(result.Failed, info.Name)
+			continue
+		}
+
+		result.Imported = append(result.Imported, info.Name)
+	}
+
+	result.Warnings = append(ic.warnings, ic.Remap.missingWarnings()...)
+
+	featureflags.MarkGuildDirty(gs.ID)
+	return result, nil
+}
+
+func (r *Remapper) missingWarnings() []string {
+	var result []string
+	if len(r.missingChannels) > 0 {
+		result = append(result, "Channels not found on this server, settings using them were cleared: "+joinSorted(r.missingChannels))
+	}
+
+	if len(r.missingRoles) > 0 {
+		result = append(result, "Roles not found on this server, settings using them were cleared: "+joinSorted(r.missingRoles))
+	}
+
+	return result
+}
+
+func joinSorted(set map[string]bool) string {
+	result := make([]string, 0, len(set))
+	for k := range set {
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+	return strings.Join(result, ", ")
+}
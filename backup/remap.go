@@ -0,0 +1,212 @@
+package backup
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/cirelion/flint/lib/dstate"
+)
+
+// Remapper maps the channel and role IDs in a snapshot to the server it's imported into.
+// IDs that exist on the server are kept as is, otherwise the channel or role with the same name is used.
+// IDs without a match are cleared and reported in the import result.
+type Remapper struct {
+	gs *dstate.GuildSet
+
+	channels map[int64]int64
+	roles    map[int64]int64
+
+	channelNames map[int64]string
+	roleNames    map[int64]string
+
+	missingChannels map[string]bool
+	missingRoles    map[string]bool
+}
+
+// NewRemapper creates a remapper for importing the snapshot into the server
+func NewRemapper(snapshot *Snapshot, gs *dstate.GuildSet) *Remapper {
+	r := &Remapper{
+		gs:              gs,
+		channels:        make(map[int64]int64),
+		roles:           make(map[int64]int64),
+		channelNames:    make(map[int64]string),
+		roleNames:       make(map[int64]string),
+		missingChannels: make(map[string]bool),
+		missingRoles:    make(map[string]bool),
+	}
+
+	for _, c := range snapshot.Channels {
+		r.channelNames[c.ID] = c.Name
+		if gs.GetChannel(c.ID) != nil {
+			r.channels[c.ID] = c.ID
+			continue
+		}
+
+		for _, v := range gs.Channels {
+			if v.Type == c.Type && strings.EqualFold(v.Name, c.Name) {
+				r.channels[c.ID] = v.ID
+				break
+			}
+		}
+	}
+
+	// the everyone role has the same ID as the server
+	r.roles[snapshot.GuildID] = gs.ID
+
+	for _, role := range snapshot.Roles {
+		r.roleNames[role.ID] = role.Name
+		if role.ID == snapshot.GuildID {
+			continue
+		}
+
+		if gs.GetRole(role.ID) != nil {
+			r.roles[role.ID] = role.ID
+			continue
+		}
+
+		for _, v := range gs.Roles {
+			if v.ID != gs.ID && strings.EqualFold(v.Name, role.Name) {
+				r.roles[role.ID] = v.ID
+				break
+			}
+		}
+	}
+
+	return r
+}
+
+// Channel returns the ID of the matching channel on the server, or 0 if there is none
+func (r *Remapper) Channel(id int64) int64 {
+	if id == 0 {
+		return 0
+	}
+
+	if mapped, ok := r.channels[id]; ok {
+		return mapped
+	}
+
+	if r.gs.GetChannel(id) != nil {
+		return id
+	}
+
+	r.missingChannels["#"+nameOrID(r.channelNames, id)] = true
+	return 0
+}
+
+// Role returns the ID of the matching role on the server, or 0 if there is none
+func (r *Remapper) Role(id int64) int64 {
+	if id == 0 {
+		return 0
+	}
+
+	if mapped, ok := r.roles[id]; ok {
+		return mapped
+	}
+
+	if r.gs.GetRole(id) != nil {
+		return id
+	}
+
+	r.missingRoles["@"+nameOrID(r.roleNames, id)] = true
+	return 0
+}
+
+// Channels maps the channels, leaving out the ones without a match
+func (r *Remapper) Channels(ids []int64) []int64 {
+	return remapSlice(ids, r.Channel)
+}
+
+// Roles maps the roles, leaving out the ones without a match
+func (r *Remapper) Roles(ids []int64) []int64 {
+	return remapSlice(ids, r.Role)
+}
+
+// ChannelString is Channel for IDs stored as strings, an empty string is returned if there's no match
+func (r *Remapper) ChannelString(id string) string {
+	return remapString(id, r.Channel)
+}
+
+// RoleString is Role for IDs stored as strings, an empty string is returned if there's no match
+func (r *Remapper) RoleString(id string) string {
+	return remapString(id, r.Role)
+}
+
+// Tagged maps the fields of the struct v points to that have a `valid:"channel..."` or `valid:"role..."` tag,
+// the same tags used for validating control panel forms. Supports int64, string and []int64 fields.
+func (r *Remapper) Tagged(v interface{}) {
+	r.taggedValue(reflect.Indirect(reflect.ValueOf(v)))
+}
+
+func (r *Remapper) taggedValue(rv reflect.Value) {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		tField := rt.Field(i)
+		vField := rv.Field(i)
+		if !vField.CanSet() {
+			continue
+		}
+
+		if tField.Anonymous && tField.Type.Kind() == reflect.Struct {
+			r.taggedValue(vField)
+			continue
+		}
+
+		var mapper func(int64) int64
+		switch strings.SplitN(tField.Tag.Get("valid"), ",", 2)[0] {
+		case "channel":
+			mapper = r.Channel
+		case "role":
+			mapper = r.Role
+		default:
+			continue
+		}
+
+		switch vField.Kind() {
+		case reflect.Int64:
+			vField.SetInt(mapper(vField.Int()))
+		case reflect.String:
+			vField.SetString(remapString(vField.String(), mapper))
+		case reflect.Slice:
+			if vField.Type().Elem().Kind() != reflect.Int64 || vField.IsNil() {
+				continue
+			}
+
+			ids := vField.Convert(reflect.TypeOf([]int64{})).Interface().([]int64)
+			vField.Set(reflect.ValueOf(remapSlice(ids, mapper)).Convert(vField.Type()))
+		}
+	}
+}
+
+func remapSlice(ids []int64, mapper func(int64) int64) []int64 {
+	result := make([]int64, 0, len(ids))
+	for _, v := range ids {
+		if mapped := mapper(v); mapped != 0 {
+			result = append(result, mapped)
+		}
+	}
+
+	return result
+}
+
+func remapString(id string, mapper func(int64) int64) string {
+	parsed, _ := strconv.ParseInt(id, 10, 64)
+	if parsed == 0 {
+		return ""
+	}
+
+	if mapped := mapper(parsed); mapped != 0 {
+		return strconv.FormatInt(mapped, 10)
+	}
+
+	return ""
+}
+
+func nameOrID(names map[int64]string, id int64) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,117 @@
+package backup
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate"
+)
+
+func testRemapper() *Remapper {
+	snapshot := &Snapshot{
+		GuildID: 1,
+		Channels: []*SnapshotChannel{
+			{ID: 10, Name: "general", Type: discordgo.ChannelTypeGuildText},
+			{ID: 11, Name: "Mod-Log", Type: discordgo.ChannelTypeGuildText},
+			{ID: 12, Name: "lounge", Type: discordgo.ChannelTypeGuildText},
+			{ID: 13, Name: "only-here", Type: discordgo.ChannelTypeGuildText},
+		},
+		Roles: []*SnapshotRole{
+			{ID: 1, Name: "@everyone"},
+			{ID: 20, Name: "Staff"},
+			{ID: 21, Name: "Muted"},
+		},
+	}
+
+	gs := &dstate.GuildSet{
+		GuildState: dstate.GuildState{ID: 2},
+		Channels: []dstate.ChannelState{
+			{ID: 10, Name: "general", Type: discordgo.ChannelTypeGuildText},
+			{ID: 110, Name: "mod-log", Type: discordgo.ChannelTypeGuildText},
+			// same name but a different type
+			{ID: 112, Name: "lounge", Type: discordgo.ChannelTypeGuildVoice},
+		},
+		Roles: []discordgo.Role{
+			{ID: 2, Name: "@everyone"},
+			{ID: 120, Name: "staff"},
+		},
+	}
+
+	return NewRemapper(snapshot, gs)
+}
+
+func TestRemapper(t *testing.T) {
+	r := testRemapper()
+
+	channels := []struct{ in, expected int64 }{
+		{0, 0},
+		{10, 10},
+		{11, 110},
+		{12, 0},
+		{13, 0},
+	}
+	for _, c := range channels {
+		if got := r.Channel(c.in); got != c.expected {
+			t.Errorf("channel %d: expected %d, got %d", c.in, c.expected, got)
+		}
+	}
+
+	roles := []struct{ in, expected int64 }{
+		{1, 2},
+		{20, 120},
+		{21, 0},
+		{99, 0},
+	}
+	for _, c := range roles {
+		if got := r.Role(c.in); got != c.expected {
+			t.Errorf("role %d: expected %d, got %d", c.in, c.expected, got)
+		}
+	}
+
+	warnings := r.missingWarnings()
+	expected := []string{
+		"Channels not found on this server, settings using them were cleared: #lounge, #only-here",
+		"Roles not found on this server, settings using them were cleared: @99, @Muted",
+	}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("unexpected warnings: %#v", warnings)
+	}
+}
+
+type TaggedEmbedded struct {
+	LogChannel string `valid:"channel,true"`
+}
+
+type taggedConfig struct {
+	TaggedEmbedded
+
+	Role          int64   `valid:"role,true"`
+	IgnoreRoles   []int64 `valid:"role,true"`
+	AllowChannels []int64 `valid:"channel,true"`
+	Other         int64   `valid:",0,100"`
+}
+
+func TestRemapperTagged(t *testing.T) {
+	r := testRemapper()
+
+	conf := &taggedConfig{
+		TaggedEmbedded: TaggedEmbedded{LogChannel: "11"},
+		Role:           20,
+		IgnoreRoles:    []int64{20, 21, 1},
+		AllowChannels:  []int64{10, 13},
+		Other:          20,
+	}
+	r.Tagged(conf)
+
+	expected := &taggedConfig{
+		TaggedEmbedded: TaggedEmbedded{LogChannel: "110"},
+		Role:           120,
+		IgnoreRoles:    []int64{120, 2},
+		AllowChannels:  []int64{10},
+		Other:          20,
+	}
+	if !reflect.DeepEqual(conf, expected) {
+		t.Errorf("expected %#v, got %#v", expected, conf)
+	}
+}
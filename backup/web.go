@@ -0,0 +1,198 @@
+package backup
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/cirelion/flint/bot/botrest"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/cplogs"
+	"github.com/cirelion/flint/web"
+	"goji.io/pat"
+)
+
+//go:embed assets/backup.html
+var PageHTML string
+
+var (
+	panelLogKeyRestored   = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "backup_restored", FormatString: "Restored a settings backup of %s"})
+	panelLogKeyClonedTo   = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "backup_cloned_to", FormatString: "Cloned the settings to %s"})
+	panelLogKeyClonedFrom = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "backup_cloned_from", FormatString: "Cloned the settings from %s"})
+)
+
+var _ web.Plugin = (*Plugin)(nil)
+
+func (p *Plugin) InitWeb() {
+	web.AddHTMLTemplate("backup/assets/backup.html", PageHTML)
+	web.AddSidebarItem(web.SidebarCategoryCore, &web.SidebarItem{
+		Name: "Backup",
+		URL:  "backup",
+		Icon: "fas fa-file-archive",
+	})
+
+	getHandler := web.ControllerHandler(handleGetBackup, "cp_backup")
+
+	web.CPMux.Handle(pat.Get("/backup"), getHandler)
+	web.CPMux.Handle(pat.Get("/backup/"), getHandler)
+	web.CPMux.Handle(pat.Get("/backup/download"), http.HandlerFunc(handleDownload))
+	web.CPMux.Handle(pat.Post("/backup/restore"), web.ControllerPostHandler(handleRestore, getHandler, nil))
+	web.CPMux.Handle(pat.Post("/backup/clone"), web.ControllerPostHandler(handleClone, getHandler, nil))
+}
+
+type backupPluginInfo struct {
+	Name    string
+	SysName string
+}
+
+func handleGetBackup(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	var plugins []*backupPluginInfo
+	for _, v := range BackupPlugins() {
+		info := v.PluginInfo()
+		plugins = append(plugins, &backupPluginInfo{Name: info.Name, SysName: info.SysName})
+	}
+	templateData["BackupPlugins"] = plugins
+
+	if web.GetIsReadOnly(ctx) {
+		return templateData, nil
+	}
+
+	managed, err := web.GetManagedGuilds(ctx, true)
+	if err != nil {
+		return templateData, err
+	}
+
+	targets := make([]*common.GuildWithConnected, 0, len(managed))
+	for _, v := range managed {
+		if v.Connected && v.ID != activeGuild.ID {
+			targets = append(targets, v)
+		}
+	}
+	templateData["CloneTargets"] = targets
+
+	return templateData, nil
+}
+
+func handleDownload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	activeGuild := web.ContextGuild(ctx)
+
+	if web.GetIsReadOnly(ctx) {
+		http.Error(w, "Only users that can change the settings can download backups", http.StatusForbidden)
+		return
+	}
+
+	snapshot, err := Export(ctx, activeGuild)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed exporting backup")
+		http.Error(w, "Failed creating the backup", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="backup-%d-%s.json"`, activeGuild.ID, snapshot.CreatedAt.Format("2006-01-02")))
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(snapshot)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed writing backup")
+	}
+}
+
+func handleRestore(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	f, _, err := r.FormFile("snapshot")
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("No backup file provided")), nil
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, MaxSnapshotSize+1))
+	if err != nil {
+		return templateData, err
+	}
+
+	if len(data) > MaxSnapshotSize {
+		return templateData.AddAlerts(web.ErrorAlert("Backup file is too big, max 5MB")), nil
+	}
+
+	var snapshot Snapshot
+	err = json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Failed parsing backup: ", err.Error())), nil
+	}
+
+	user := web.ContextUser(ctx)
+	result, err := Import(ctx, &snapshot, activeGuild, user.ID, user.Username, r.Form["plugins"])
+	if err != nil {
+		if err == ErrInvalidSnapshot || err == ErrUnsupportedVersion {
+			return templateData.AddAlerts(web.ErrorAlert(err.Error())), nil
+		}
+
+		return templateData, err
+	}
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyRestored, &cplogs.Param{Type: cplogs.ParamTypeString, Value: snapshot.GuildName}))
+
+	templateData.AddAlerts(web.SucessAlert(result.String()))
+	return templateData, nil
+}
+
+func handleClone(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	targetID, _ := strconv.ParseInt(r.FormValue("TargetGuild"), 10, 64)
+	if targetID == 0 || targetID == activeGuild.ID {
+		return templateData.AddAlerts(web.ErrorAlert("No server selected")), nil
+	}
+
+	// make sure they can change the settings on the target server as well
+	managed, err := web.GetManagedGuilds(ctx, true)
+	if err != nil {
+		return templateData, err
+	}
+
+	var target *common.GuildWithConnected
+	for _, v := range managed {
+		if v.ID == targetID && v.Connected {
+			target = v
+			break
+		}
+	}
+
+	if target == nil {
+		return templateData.AddAlerts(web.ErrorAlert("You can't change the settings of that server, or the bot isn't on it")), nil
+	}
+
+	targetGS, err := botrest.GetGuild(targetID)
+	if err != nil {
+		return templateData, err
+	}
+
+	snapshot, err := Export(ctx, activeGuild)
+	if err != nil {
+		return templateData, err
+	}
+
+	user := web.ContextUser(ctx)
+	result, err := Import(ctx, snapshot, targetGS, user.ID, user.Username, r.Form["plugins"])
+	if err != nil {
+		return templateData, err
+	}
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyClonedTo, &cplogs.Param{Type: cplogs.ParamTypeString, Value: targetGS.Name}))
+	go cplogs.RetryAddEntry(cplogs.NewEntry(targetGS.ID, user.ID, user.Username, panelLogKeyClonedFrom, &cplogs.Param{Type: cplogs.ParamTypeString, Value: activeGuild.Name}))
+
+	templateData.AddAlerts(web.SucessAlert(fmt.Sprintf("Cloned the settings to %s. ", targetGS.Name), result.String()))
+	return templateData, nil
+}
@@ -0,0 +1,133 @@
+// Package eventreplay replays events recorded by the event system recorder against an in-memory state
+// and a fake discord api that captures every call the plugins make.
+//
+// The plugins have to be registered and common initialized (for the database and redis) before creating a harness,
+// see the -replay flag of the bot. Only discord is faked, the plugins still write to the database and redis,
+// so replays should only be run against scratch ones.
+package eventreplay
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/cirelion/flint/bot"
+	"github.com/cirelion/flint/bot/eventsystem"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate/inmemorytracker"
+)
+
+var logger = common.GetFixedPrefixLogger("eventreplay")
+
+// Harness feeds recorded events through the event system
+type Harness struct {
+	REST    *FakeREST
+	Tracker *inmemorytracker.InMemoryTracker
+	Session *discordgo.Session
+
+	// If set, the time between the events in the recording is kept when replaying, up to a second per event
+	KeepTiming bool
+
+	oldEndpoint string
+}
+
+var (
+	harnessCreated bool
+	harnessMU      sync.Mutex
+)
+
+// NewHarness sets up the bot with the state from the recording, using a fake discord api.
+// Only one harness can be created per process, as the bot can only be set up once.
+func NewHarness(recording *eventsystem.Recording) (*Harness, error) {
+	harnessMU.Lock()
+	defer harnessMU.Unlock()
+	if harnessCreated {
+		return nil, errors.New("a harness was already created in this process")
+	}
+
+	state := recording.State
+	if state == nil || state.Guild == nil {
+		return nil, errors.New("recording has no guild state")
+	}
+
+	h := &Harness{
+		REST:        NewFakeREST(),
+		oldEndpoint: discordgo.EndpointDiscord,
+	}
+	discordgo.CreateEndpoints(h.REST.URL())
+
+	session, err := discordgo.New("Bot replay")
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+	session.StateEnabled = false
+	session.ShardCount = 1
+	h.Session = session
+
+	common.BotSession = session
+	if state.BotUser != nil {
+		common.BotUser = state.BotUser
+	} else if common.BotUser == nil {
+		common.BotUser = &discordgo.User{ID: 1, Username: "replay", Bot: true}
+	}
+
+	h.Tracker = inmemorytracker.NewInMemoryTracker(inmemorytracker.TrackerConfig{
+		BotMemberID: common.BotUser.ID,
+	}, 1)
+	h.Tracker.SetGuild(state.Guild)
+	for _, ms := range state.Members {
+		h.Tracker.SetMember(ms)
+	}
+
+	bot.SetupReplay(h.Tracker)
+
+	harnessCreated = true
+	return h, nil
+}
+
+// Run feeds the events through the event system, then waits until no api calls were made for quiet,
+// or until timeout has passed since the last event was fed
+func (h *Harness) Run(events []*eventsystem.RecordedEvent, quiet, timeout time.Duration) {
+	for i, v := range events {
+		decoded, err := v.Decode()
+		if err != nil {
+			logger.WithError(err).Warnf("skipped event %d (%s)", i, v.Type)
+			continue
+		}
+
+		if h.KeepTiming && i > 0 {
+			wait := v.Time.Sub(events[i-1].Time)
+			if wait > time.Second {
+				wait = time.Second
+			}
+
+			if wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+
+		eventsystem.HandleEvent(h.Session, decoded)
+	}
+
+	started := time.Now()
+	for {
+		time.Sleep(100 * time.Millisecond)
+
+		lastActivity := h.REST.LastCall()
+		if lastActivity.Before(started) {
+			lastActivity = started
+		}
+
+		if time.Since(lastActivity) >= quiet || time.Since(started) >= timeout {
+			return
+		}
+	}
+}
+
+// Close stops the fake api and points discordgo back at the real one
+func (h *Harness) Close() {
+	h.REST.Close()
+	discordgo.CreateEndpoints(h.oldEndpoint)
+}
@@ -0,0 +1,112 @@
+package eventreplay
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cirelion/flint/lib/discordgo"
+)
+
+// APICall is a request made to the fake discord api
+type APICall struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	Path   string    `json:"path"`
+	Body   string    `json:"body,omitempty"`
+}
+
+// FakeREST is a local http server standing in for the discord api, it records every request made to it
+type FakeREST struct {
+	Server *httptest.Server
+
+	// Responder returns the status code and body of the response to a call,
+	// DefaultResponder is used if it's nil
+	Responder func(call *APICall) (status int, body string)
+
+	mu       sync.Mutex
+	calls    []*APICall
+	lastCall time.Time
+}
+
+// NewFakeREST starts a new fake api server, remember to close it
+func NewFakeREST() *FakeREST {
+	f := &FakeREST{}
+	f.Server = httptest.NewServer(f)
+	return f
+}
+
+// URL returns the base url to pass to discordgo.CreateEndpoints
+func (f *FakeREST) URL() string {
+	return f.Server.URL + "/"
+}
+
+func (f *FakeREST) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	call := &APICall{
+		Time:   time.Now(),
+		Method: r.Method,
+		Path:   strings.TrimPrefix(r.URL.Path, "/api/v"+discordgo.APIVersion),
+		Body:   string(body),
+	}
+
+	f.mu.Lock()
+	f.calls = append(f.calls, call)
+	f.lastCall = call.Time
+	responder := f.Responder
+	f.mu.Unlock()
+
+	if responder == nil {
+		responder = DefaultResponder
+	}
+
+	status, respBody := responder(call)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write([]byte(respBody))
+}
+
+// Calls returns the calls made so far
+func (f *FakeREST) Calls() []*APICall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]*APICall{}, f.calls...)
+}
+
+// LastCall returns the time of the last call, or the zero time if none has been made
+func (f *FakeREST) LastCall() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.lastCall
+}
+
+func (f *FakeREST) Close() {
+	f.Server.Close()
+}
+
+// endpoints that return lists when fetched
+var listSuffixes = []string{"/messages", "/roles", "/channels", "/members", "/bans", "/webhooks", "/invites", "/pins", "/threads/active", "/emojis", "/commands"}
+
+// DefaultResponder responds to every call with success, with an empty list for endpoints returning lists
+// and an empty object otherwise
+func DefaultResponder(call *APICall) (status int, body string) {
+	if call.Method == http.MethodDelete {
+		return http.StatusNoContent, ""
+	}
+
+	if call.Method == http.MethodGet {
+		for _, v := range listSuffixes {
+			if strings.HasSuffix(call.Path, v) {
+				return http.StatusOK, "[]"
+			}
+		}
+	}
+
+	return http.StatusOK, "{}"
+}
@@ -0,0 +1,48 @@
+package eventreplay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cirelion/flint/lib/discordgo"
+)
+
+func TestFakeRESTCapturesCalls(t *testing.T) {
+	rest := NewFakeREST()
+	defer rest.Close()
+
+	oldEndpoint := discordgo.EndpointDiscord
+	discordgo.CreateEndpoints(rest.URL())
+	defer discordgo.CreateEndpoints(oldEndpoint)
+
+	session, err := discordgo.New("Bot test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = session.ChannelMessageSend(10, "hello there")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roles, err := session.GuildRoles(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 0 {
+		t.Errorf("expected no roles, got %d", len(roles))
+	}
+
+	calls := rest.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 calls, got %d", len(calls))
+	}
+
+	if calls[0].Method != "POST" || calls[0].Path != "/channels/10/messages" || !strings.Contains(calls[0].Body, "hello there") {
+		t.Errorf("unexpected call: %#v", calls[0])
+	}
+
+	if calls[1].Method != "GET" || calls[1].Path != "/guilds/1/roles" {
+		t.Errorf("unexpected call: %#v", calls[1])
+	}
+}
@@ -693,3 +693,152 @@ func fillEvent(evtData *EventData) {
 
 	return
 }
+
+// NewEventInterface returns a new instance of the discordgo struct for the event, or nil if it's not a discord event
+func NewEventInterface(evt Event) interface{} {
+
+	switch evt {
+	case EventApplicationCommandCreate:
+		return new(discordgo.ApplicationCommandCreate)
+	case EventApplicationCommandDelete:
+		return new(discordgo.ApplicationCommandDelete)
+	case EventApplicationCommandPermissionsUpdate:
+		return new(discordgo.ApplicationCommandPermissionsUpdate)
+	case EventApplicationCommandUpdate:
+		return new(discordgo.ApplicationCommandUpdate)
+	case EventAutoModerationActionExecution:
+		return new(discordgo.AutoModerationActionExecution)
+	case EventAutoModerationRuleCreate:
+		return new(discordgo.AutoModerationRuleCreate)
+	case EventAutoModerationRuleDelete:
+		return new(discordgo.AutoModerationRuleDelete)
+	case EventAutoModerationRuleUpdate:
+		return new(discordgo.AutoModerationRuleUpdate)
+	case EventChannelCreate:
+		return new(discordgo.ChannelCreate)
+	case EventChannelDelete:
+		return new(discordgo.ChannelDelete)
+	case EventChannelPinsUpdate:
+		return new(discordgo.ChannelPinsUpdate)
+	case EventChannelTopicUpdate:
+		return new(discordgo.ChannelTopicUpdate)
+	case EventChannelUpdate:
+		return new(discordgo.ChannelUpdate)
+	case EventConnect:
+		return new(discordgo.Connect)
+	case EventDisconnect:
+		return new(discordgo.Disconnect)
+	case EventGuildAuditLogEntryCreate:
+		return new(discordgo.GuildAuditLogEntryCreate)
+	case EventGuildBanAdd:
+		return new(discordgo.GuildBanAdd)
+	case EventGuildBanRemove:
+		return new(discordgo.GuildBanRemove)
+	case EventGuildCreate:
+		return new(discordgo.GuildCreate)
+	case EventGuildDelete:
+		return new(discordgo.GuildDelete)
+	case EventGuildEmojisUpdate:
+		return new(discordgo.GuildEmojisUpdate)
+	case EventGuildIntegrationsUpdate:
+		return new(discordgo.GuildIntegrationsUpdate)
+	case EventGuildJoinRequestDelete:
+		return new(discordgo.GuildJoinRequestDelete)
+	case EventGuildJoinRequestUpdate:
+		return new(discordgo.GuildJoinRequestUpdate)
+	case EventGuildMemberAdd:
+		return new(discordgo.GuildMemberAdd)
+	case EventGuildMemberRemove:
+		return new(discordgo.GuildMemberRemove)
+	case EventGuildMemberUpdate:
+		return new(discordgo.GuildMemberUpdate)
+	case EventGuildMembersChunk:
+		return new(discordgo.GuildMembersChunk)
+	case EventGuildRoleCreate:
+		return new(discordgo.GuildRoleCreate)
+	case EventGuildRoleDelete:
+		return new(discordgo.GuildRoleDelete)
+	case EventGuildRoleUpdate:
+		return new(discordgo.GuildRoleUpdate)
+	case EventGuildStickersUpdate:
+		return new(discordgo.GuildStickersUpdate)
+	case EventGuildUpdate:
+		return new(discordgo.GuildUpdate)
+	case EventInteractionCreate:
+		return new(discordgo.InteractionCreate)
+	case EventInviteCreate:
+		return new(discordgo.InviteCreate)
+	case EventInviteDelete:
+		return new(discordgo.InviteDelete)
+	case EventMessageAck:
+		return new(discordgo.MessageAck)
+	case EventMessageCreate:
+		return new(discordgo.MessageCreate)
+	case EventMessageDelete:
+		return new(discordgo.MessageDelete)
+	case EventMessageDeleteBulk:
+		return new(discordgo.MessageDeleteBulk)
+	case EventMessageReactionAdd:
+		return new(discordgo.MessageReactionAdd)
+	case EventMessageReactionRemove:
+		return new(discordgo.MessageReactionRemove)
+	case EventMessageReactionRemoveAll:
+		return new(discordgo.MessageReactionRemoveAll)
+	case EventMessageReactionRemoveEmoji:
+		return new(discordgo.MessageReactionRemoveEmoji)
+	case EventMessageUpdate:
+		return new(discordgo.MessageUpdate)
+	case EventPresenceUpdate:
+		return new(discordgo.PresenceUpdate)
+	case EventPresencesReplace:
+		return new(discordgo.PresencesReplace)
+	case EventRateLimit:
+		return new(discordgo.RateLimit)
+	case EventReady:
+		return new(discordgo.Ready)
+	case EventRelationshipAdd:
+		return new(discordgo.RelationshipAdd)
+	case EventRelationshipRemove:
+		return new(discordgo.RelationshipRemove)
+	case EventResumed:
+		return new(discordgo.Resumed)
+	case EventStageInstanceCreate:
+		return new(discordgo.StageInstanceCreate)
+	case EventStageInstanceDelete:
+		return new(discordgo.StageInstanceDelete)
+	case EventStageInstanceUpdate:
+		return new(discordgo.StageInstanceUpdate)
+	case EventThreadCreate:
+		return new(discordgo.ThreadCreate)
+	case EventThreadDelete:
+		return new(discordgo.ThreadDelete)
+	case EventThreadListSync:
+		return new(discordgo.ThreadListSync)
+	case EventThreadMemberUpdate:
+		return new(discordgo.ThreadMemberUpdate)
+	case EventThreadMembersUpdate:
+		return new(discordgo.ThreadMembersUpdate)
+	case EventThreadUpdate:
+		return new(discordgo.ThreadUpdate)
+	case EventTypingStart:
+		return new(discordgo.TypingStart)
+	case EventUserGuildSettingsUpdate:
+		return new(discordgo.UserGuildSettingsUpdate)
+	case EventUserNoteUpdate:
+		return new(discordgo.UserNoteUpdate)
+	case EventUserSettingsUpdate:
+		return new(discordgo.UserSettingsUpdate)
+	case EventUserUpdate:
+		return new(discordgo.UserUpdate)
+	case EventVoiceChannelStatusUpdate:
+		return new(discordgo.VoiceChannelStatusUpdate)
+	case EventVoiceServerUpdate:
+		return new(discordgo.VoiceServerUpdate)
+	case EventVoiceStateUpdate:
+		return new(discordgo.VoiceStateUpdate)
+	case EventWebhooksUpdate:
+		return new(discordgo.WebhooksUpdate)
+	}
+
+	return nil
+}
@@ -413,6 +413,8 @@ func handleEvent(evtData *EventData) {
 		}
 	}()
 
+	recordEvent(evtData)
+
 	EmitEvent(evtData, EventAllPre)
 	EmitEvent(evtData, evtData.Type)
 	EmitEvent(evtData, EventAllPost)
@@ -73,6 +73,17 @@ func fillEvent(evtData *EventData) {
 
 	return 
 }
+
+// NewEventInterface returns a new instance of the discordgo struct for the event, or nil if it's not a discord event
+func NewEventInterface(evt Event) interface{} {
+
+	switch evt { {{range $k, $v := .}}{{if .Discord}}
+	case Event{{.Name}}:
+		return new(discordgo.{{.Name}}){{end}}{{end}}
+	}
+
+	return nil
+}
 `
 
 type Event struct {
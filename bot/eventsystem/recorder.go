@@ -0,0 +1,291 @@
+package eventsystem
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// RecordingStateType is the type of the first entry in a recording, holding the state of the guild when it was started
+	RecordingStateType = "State"
+
+	// Max events in a single recording, after this the recording is stopped
+	MaxRecordedEvents = 100000
+
+	// Max members included in the state at the start of a recording
+	MaxRecordedMembers = 1000
+)
+
+// RecordedEvent is a single entry in a recording, recordings are stored as one json encoded entry per line
+type RecordedEvent struct {
+	Time time.Time       `json:"time"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Decode returns the discordgo event struct of the entry
+func (e *RecordedEvent) Decode() (interface{}, error) {
+	evt, ok := EventFromName(e.Type)
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %q", e.Type)
+	}
+
+	dst := NewEventInterface(evt)
+	if dst == nil {
+		return nil, fmt.Errorf("%q is not a discord event", e.Type)
+	}
+
+	err := json.Unmarshal(e.Data, dst)
+	return dst, err
+}
+
+// RecordingState is the state of the guild at the start of a recording
+type RecordingState struct {
+	BotUser *discordgo.User       `json:"bot_user"`
+	Guild   *dstate.GuildSet      `json:"guild"`
+	Members []*dstate.MemberState `json:"members"`
+}
+
+// Recording is a recording read back from a file
+type Recording struct {
+	State  *RecordingState
+	Events []*RecordedEvent
+}
+
+// ReadRecording reads a recording written by a recorder
+func ReadRecording(r io.Reader) (*Recording, error) {
+	scanner := bufio.NewScanner(r)
+	// messages with big embeds can exceed the default buffer size
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	recording := &Recording{}
+	for scanner.Scan() {
+		if len(scanner.Bytes()) < 1 {
+			continue
+		}
+
+		var entry RecordedEvent
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, err
+		}
+
+		if entry.Type == RecordingStateType {
+			var state RecordingState
+			err = json.Unmarshal(entry.Data, &state)
+			if err != nil {
+				return nil, err
+			}
+
+			recording.State = &state
+			continue
+		}
+
+		recording.Events = append(recording.Events, &entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if recording.State == nil || recording.State.Guild == nil {
+		return nil, errors.New("recording has no guild state")
+	}
+
+	return recording, nil
+}
+
+// EventFromName returns the event with the name, as returned by Event.String
+func EventFromName(name string) (Event, bool) {
+	for i, v := range EventNames {
+		if v == name {
+			return Event(i), true
+		}
+	}
+
+	return 0, false
+}
+
+type recorder struct {
+	mu sync.Mutex
+
+	guildID int64
+	path    string
+	file    *os.File
+	encoder *json.Encoder
+	until   time.Time
+	events  int
+	stopped bool
+}
+
+var (
+	recorders   = make(map[int64]*recorder)
+	recordersMU sync.RWMutex
+
+	// number of active recorders, checked first so events aren't slowed down by the lock when nothing is being recorded
+	numRecorders int32
+)
+
+// StartRecording starts writing all events of the guild to a new file in dir, until it's stopped or maxDuration has passed.
+// The guild has to be in the state of this process.
+func StartRecording(guildID int64, dir string, maxDuration time.Duration) (path string, err error) {
+	gs := DiscordState.GetGuild(guildID)
+	if gs == nil {
+		return "", errors.New("guild is not in state on this node")
+	}
+
+	recordersMU.Lock()
+	defer recordersMU.Unlock()
+
+	if existing, ok := recorders[guildID]; ok {
+		return existing.path, errors.New("already recording events of this guild")
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	path = filepath.Join(dir, fmt.Sprintf("events_%d_%s.jsonl", guildID, time.Now().UTC().Format("20060102_150405")))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+
+	state := &RecordingState{BotUser: common.BotUser, Guild: gs}
+	DiscordState.IterateMembers(guildID, func(chunk []*dstate.MemberState) bool {
+		for _, v := range chunk {
+			if len(state.Members) >= MaxRecordedMembers {
+				return false
+			}
+
+			state.Members = append(state.Members, v)
+		}
+
+		return true
+	})
+
+	r := &recorder{
+		guildID: guildID,
+		path:    path,
+		file:    f,
+		encoder: json.NewEncoder(f),
+		until:   time.Now().Add(maxDuration),
+	}
+
+	err = r.write(RecordingStateType, state)
+	if err != nil {
+		f.Close()
+		return "", err
+	}
+
+	recorders[guildID] = r
+	atomic.AddInt32(&numRecorders, 1)
+
+	logrus.WithField("guild", guildID).WithField("path", path).Info("Started recording events")
+	return path, nil
+}
+
+// StopRecording stops recording the events of the guild, returning the file and the number of events recorded
+func StopRecording(guildID int64) (path string, events int, ok bool) {
+	recordersMU.Lock()
+	r, ok := recorders[guildID]
+	if ok {
+		delete(recorders, guildID)
+		atomic.AddInt32(&numRecorders, -1)
+	}
+	recordersMU.Unlock()
+
+	if !ok {
+		return "", 0, false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stopped = true
+	r.file.Close()
+	logrus.WithField("guild", guildID).WithField("path", r.path).Infof("Stopped recording events, recorded %d events", r.events)
+	return r.path, r.events, true
+}
+
+// IsRecording returns true if the events of the guild are being recorded
+func IsRecording(guildID int64) bool {
+	if atomic.LoadInt32(&numRecorders) < 1 {
+		return false
+	}
+
+	recordersMU.RLock()
+	_, ok := recorders[guildID]
+	recordersMU.RUnlock()
+	return ok
+}
+
+func (r *recorder) write(t string, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return r.encoder.Encode(&RecordedEvent{
+		Time: time.Now().UTC(),
+		Type: t,
+		Data: encoded,
+	})
+}
+
+// recordEvent writes the event to the recording of its guild, if there is one
+func recordEvent(evtData *EventData) {
+	if atomic.LoadInt32(&numRecorders) < 1 {
+		return
+	}
+
+	guildEvt, ok := evtData.EvtInterface.(discordgo.GuildEvent)
+	if !ok {
+		return
+	}
+
+	guildID := guildEvt.GetGuildID()
+
+	recordersMU.RLock()
+	r, ok := recorders[guildID]
+	recordersMU.RUnlock()
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	if r.stopped {
+		// stopped while we were waiting for the lock
+		r.mu.Unlock()
+		return
+	}
+
+	err := r.write(evtData.Type.String(), evtData.EvtInterface)
+	if err == nil {
+		r.events++
+	}
+	done := r.events >= MaxRecordedEvents || time.Now().After(r.until)
+	r.mu.Unlock()
+
+	if err != nil {
+		logrus.WithError(err).WithField("guild", guildID).Error("failed recording event")
+	}
+
+	if done {
+		StopRecording(guildID)
+	}
+}
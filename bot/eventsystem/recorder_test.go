@@ -0,0 +1,81 @@
+package eventsystem
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate/inmemorytracker"
+)
+
+func TestRecordAndRead(t *testing.T) {
+	tracker := inmemorytracker.NewInMemoryTracker(inmemorytracker.TrackerConfig{}, 1)
+	tracker.HandleEvent(&discordgo.Session{ShardCount: 1}, &discordgo.GuildCreate{
+		Guild: &discordgo.Guild{
+			ID:   1,
+			Name: "recorded guild",
+			Channels: []*discordgo.Channel{
+				{ID: 10, GuildID: 1, Name: "general", Type: discordgo.ChannelTypeGuildText},
+			},
+			Members: []*discordgo.Member{
+				{GuildID: 1, User: &discordgo.User{ID: 100, Username: "someone"}},
+			},
+		},
+	})
+
+	oldState := DiscordState
+	DiscordState = tracker
+	defer func() { DiscordState = oldState }()
+
+	path, err := StartRecording(1, t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsRecording(1) || IsRecording(2) {
+		t.Error("expected only guild 1 to be recorded")
+	}
+
+	recordEvent(NewEventData(nil, EventMessageCreate, &discordgo.MessageCreate{Message: &discordgo.Message{
+		ID: 1000, GuildID: 1, ChannelID: 10, Content: "hello", Author: &discordgo.User{ID: 100},
+	}}))
+	// other guilds are not recorded
+	recordEvent(NewEventData(nil, EventMessageCreate, &discordgo.MessageCreate{Message: &discordgo.Message{
+		ID: 1001, GuildID: 2, ChannelID: 20, Content: "not recorded",
+	}}))
+
+	stoppedPath, events, ok := StopRecording(1)
+	if !ok || stoppedPath != path || events != 1 {
+		t.Fatalf("unexpected stop result: %q %d %v", stoppedPath, events, ok)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	recording, err := ReadRecording(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if recording.State.Guild.Name != "recorded guild" || len(recording.State.Guild.Channels) != 1 || len(recording.State.Members) != 1 {
+		t.Errorf("unexpected state: %#v", recording.State)
+	}
+
+	if len(recording.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(recording.Events))
+	}
+
+	decoded, err := recording.Events[0].Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mc, ok := decoded.(*discordgo.MessageCreate)
+	if !ok || mc.Content != "hello" || mc.GuildID != 1 || mc.Author.ID != 100 {
+		t.Errorf("unexpected decoded event: %#v", decoded)
+	}
+}
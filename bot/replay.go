@@ -0,0 +1,36 @@
+package bot
+
+import (
+	"github.com/cirelion/flint/bot/eventsystem"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/lib/dstate/inmemorytracker"
+)
+
+// SetupReplay sets up the bot for replaying recorded events instead of connecting to discord,
+// tracker is used as the state and the plugins are initialized like they normally are.
+// Should only be called once, in place of Run.
+func SetupReplay(tracker *inmemorytracker.InMemoryTracker) {
+	setup()
+
+	totalShardCount = 1
+	eventsystem.DiscordState = tracker
+	stateTracker = tracker
+	State = tracker
+
+	EventLogger.init(totalShardCount)
+	eventsystem.InitWorkers(totalShardCount)
+
+	Running = true
+
+	for _, plugin := range common.Plugins {
+		if initBot, ok := plugin.(BotInitHandler); ok {
+			initBot.BotInit()
+		}
+	}
+
+	for _, plugin := range common.Plugins {
+		if initBot, ok := plugin.(LateBotInitHandler); ok {
+			initBot.LateBotInit()
+		}
+	}
+}
@@ -17,19 +17,23 @@ func (p *Plugin) AddCommands() {
 		Name:        "Create",
 		CmdCategory: commands.CategoryFun,
 		Aliases:     []string{"c"},
-		Description: "Creates a Cards Against Humanity game in this channel, add packs after commands, or * for all packs. (-v for vote mode without a card czar).",
+		Description: "Creates a Cards Against Humanity game in this channel, add packs after commands, or * for all packs. (-v for vote mode without a card czar, -e to only show hands in this channel instead of in dm's).",
 		Arguments: []*dcmd.ArgDef{
 			{Name: "packs", Type: dcmd.String, Default: "main", Help: "Packs separated by space, or * for all of them."},
 		},
 		ArgSwitches: []*dcmd.ArgDef{
 			{Name: "v", Help: "Vote mode - players vote instead of having a card czar."},
+			{Name: "e", Help: "Ephemeral hands - cards are only shown in this channel, with the hand button, instead of in dm's."},
 		},
 		RunFunc: func(data *dcmd.Data) (interface{}, error) {
-			voteMode := data.Switch("v").Bool()
+			options := cardsagainstdiscord.GameOptions{
+				VoteMode:       data.Switch("v").Bool(),
+				EphemeralHands: data.Switch("e").Bool(),
+			}
 			pStr := data.Args[0].Str()
 			packs := strings.Fields(pStr)
 
-			_, err := p.Manager.CreateGame(data.GuildData.GS.ID, data.GuildData.CS.ID, data.Author.ID, data.Author.Username, voteMode, packs...)
+			_, err := p.Manager.CreateGame(data.GuildData.GS.ID, data.GuildData.CS.ID, data.Author.ID, data.Author.Username, options, packs...)
 			if err == nil {
 				logrus.Info("[cah] Created a new game in ", data.GuildData.CS.ID, ":", data.GuildData.GS.ID)
 				return "", nil
@@ -101,6 +105,18 @@ func (p *Plugin) AddCommands() {
 				resp += "`" + v.Name + "` - " + v.Description + "\n"
 			}
 
+			decks, err := GetCustomDecks(data.GuildData.GS.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(decks) > 0 {
+				resp += "\nCustom packs on this server: \n\n"
+				for _, v := range decks {
+					resp += "`" + v.Name + "` - " + v.Description + "\n"
+				}
+			}
+
 			return resp, nil
 		},
 	}
@@ -0,0 +1,90 @@
+package cah
+
+import (
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/lib/cardsagainstdiscord"
+	"github.com/lib/pq"
+)
+
+const MaxGuildDecks = 10
+
+// CustomDeck is a pack of cards made by a server in the control panel
+type CustomDeck struct {
+	ID          int64 `gorm:"primary_key"`
+	GuildID     int64 `gorm:"index"`
+	Name        string
+	Description string
+	Prompts     pq.StringArray `gorm:"type:text[]"`
+	Responses   pq.StringArray `gorm:"type:text[]"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (d CustomDeck) TableName() string {
+	return "cah_custom_decks"
+}
+
+// PackData returns the deck in the json format used for importing and exporting
+func (d *CustomDeck) PackData() *cardsagainstdiscord.CustomPackData {
+	return &cardsagainstdiscord.CustomPackData{
+		Name:        d.Name,
+		Description: d.Description,
+		Prompts:     d.Prompts,
+		Responses:   d.Responses,
+	}
+}
+
+func GetCustomDecks(guildID int64) ([]*CustomDeck, error) {
+	var decks []*CustomDeck
+	err := common.GORM.Where("guild_id = ?", guildID).Order("name asc").Find(&decks).Error
+	return decks, err
+}
+
+func (p *Plugin) CustomPacksForGuild(guildID int64) ([]*cardsagainstdiscord.CardPack, error) {
+	decks, err := GetCustomDecks(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	packs := make([]*cardsagainstdiscord.CardPack, 0, len(decks))
+	for _, v := range decks {
+		pack, err := cardsagainstdiscord.NewCustomPack(v.PackData())
+		if err != nil {
+			// were validated when saved, so this should only happen if the limits were lowered since
+			logger.WithError(err).WithField("guild", guildID).Error("invalid custom deck ", v.Name)
+			continue
+		}
+
+		packs = append(packs, pack)
+	}
+
+	return packs, nil
+}
+
+// splitCards splits the cards in a textarea, one card per line
+func splitCards(s string) []string {
+	lines := strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n")
+	cards := make([]string, 0, len(lines))
+	for _, v := range lines {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			cards = append(cards, v)
+		}
+	}
+
+	return cards
+}
+
+// PromptsText returns the prompts one per line, for editing in the control panel
+func (d *CustomDeck) PromptsText() string {
+	return strings.Join(d.Prompts, "\n")
+}
+
+// ResponsesText returns the responses one per line, for editing in the control panel
+func (d *CustomDeck) ResponsesText() string {
+	return strings.Join(d.Responses, "\n")
+}
@@ -19,9 +19,14 @@ func init() {
 	dshardorchestrator.RegisterUserEvent("CAHGame", ShardMigrationEvtGame, cardsagainstdiscord.Game{})
 }
 
+var logger = common.GetPluginLogger(&Plugin{})
+
 func RegisterPlugin() {
+	common.GORM.AutoMigrate(&CustomDeck{})
+
 	p := &Plugin{}
 	p.Manager = cardsagainstdiscord.NewGameManager(p)
+	p.Manager.CustomPackProvider = p
 	common.RegisterPlugin(p)
 
 }
@@ -47,6 +52,8 @@ var (
 	_ bot.ShardMigrationReceiver = (*Plugin)(nil)
 	_ bot.ShardMigrationSender   = (*Plugin)(nil)
 	_ commands.CommandProvider   = (*Plugin)(nil)
+
+	_ cardsagainstdiscord.CustomPackProvider = (*Plugin)(nil)
 )
 
 func (p *Plugin) BotInit() {
@@ -0,0 +1,286 @@
+package cah
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/cplogs"
+	"github.com/cirelion/flint/lib/cardsagainstdiscord"
+	"github.com/cirelion/flint/web"
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	"goji.io"
+	"goji.io/pat"
+)
+
+//go:embed assets/cah.html
+var PageHTML string
+
+const (
+	// Max size of an imported deck file
+	maxImportSize = 1000000
+
+	maxDescriptionLength = 200
+)
+
+type DeckForm struct {
+	ID          int64
+	Name        string `valid:",1,32,trimspace"`
+	Description string `valid:",200"`
+
+	// One card per line
+	Prompts   string `valid:",150000"`
+	Responses string `valid:",500000"`
+}
+
+func (f *DeckForm) PackData() *cardsagainstdiscord.CustomPackData {
+	return &cardsagainstdiscord.CustomPackData{
+		Name:        f.Name,
+		Description: f.Description,
+		Prompts:     splitCards(f.Prompts),
+		Responses:   splitCards(f.Responses),
+	}
+}
+
+var (
+	panelLogKeyAddedDeck    = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "cah_added_deck", FormatString: "Added Cards Against Humanity deck %s"})
+	panelLogKeyUpdatedDeck  = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "cah_updated_deck", FormatString: "Updated Cards Against Humanity deck %s"})
+	panelLogKeyRemovedDeck  = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "cah_removed_deck", FormatString: "Removed Cards Against Humanity deck %s"})
+	panelLogKeyImportedDeck = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "cah_imported_deck", FormatString: "Imported Cards Against Humanity deck %s"})
+)
+
+func (p *Plugin) InitWeb() {
+	web.AddHTMLTemplate("cah/assets/cah.html", PageHTML)
+	web.AddSidebarItem(web.SidebarCategoryFun, &web.SidebarItem{
+		Name: "Cards Against Humanity",
+		URL:  "cah/",
+		Icon: "fas fa-layer-group",
+	})
+
+	cpMux := goji.SubMux()
+
+	web.CPMux.Handle(pat.New("/cah/*"), cpMux)
+	web.CPMux.Handle(pat.New("/cah"), cpMux)
+
+	getHandler := web.ControllerHandler(HandleGetDecks, "cp_cah")
+
+	cpMux.Handle(pat.Get("/"), getHandler)
+	cpMux.Handle(pat.Get(""), getHandler)
+	cpMux.Handle(pat.Get("/decks/:deck/export"), http.HandlerFunc(HandleExportDeck))
+	cpMux.Handle(pat.Post("/new"), web.ControllerPostHandler(HandleNewDeck, getHandler, DeckForm{}))
+	cpMux.Handle(pat.Post("/update"), web.ControllerPostHandler(HandleUpdateDeck, getHandler, DeckForm{}))
+	cpMux.Handle(pat.Post("/delete"), web.ControllerPostHandler(HandleDeleteDeck, getHandler, DeckForm{}))
+	cpMux.Handle(pat.Post("/import"), web.ControllerPostHandler(HandleImportDeck, getHandler, nil))
+}
+
+func HandleGetDecks(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	g, tmpl := web.GetBaseCPContextData(r.Context())
+
+	decks, err := GetCustomDecks(g.ID)
+	if err != nil {
+		return tmpl, err
+	}
+
+	tmpl["CustomDecks"] = decks
+	tmpl["MaxDecks"] = MaxGuildDecks
+	tmpl["MaxPrompts"] = cardsagainstdiscord.MaxCustomPackPrompts
+	tmpl["MaxResponses"] = cardsagainstdiscord.MaxCustomPackResponses
+	return tmpl, nil
+}
+
+func HandleNewDeck(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	g, tmpl := web.GetBaseCPContextData(ctx)
+	form := ctx.Value(common.ContextKeyParsedForm).(*DeckForm)
+
+	deck, errMsg, err := saveDeck(g.ID, 0, form.PackData())
+	if err != nil {
+		return tmpl, err
+	}
+	if errMsg != "" {
+		return tmpl.AddAlerts(web.ErrorAlert(errMsg)), nil
+	}
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyAddedDeck, &cplogs.Param{Type: cplogs.ParamTypeString, Value: deck.Name}))
+	return tmpl, nil
+}
+
+func HandleUpdateDeck(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	g, tmpl := web.GetBaseCPContextData(ctx)
+	form := ctx.Value(common.ContextKeyParsedForm).(*DeckForm)
+
+	deck, errMsg, err := saveDeck(g.ID, form.ID, form.PackData())
+	if err != nil {
+		return tmpl, err
+	}
+	if errMsg != "" {
+		return tmpl.AddAlerts(web.ErrorAlert(errMsg)), nil
+	}
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyUpdatedDeck, &cplogs.Param{Type: cplogs.ParamTypeString, Value: deck.Name}))
+	return tmpl, nil
+}
+
+func HandleDeleteDeck(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	g, tmpl := web.GetBaseCPContextData(ctx)
+	form := ctx.Value(common.ContextKeyParsedForm).(*DeckForm)
+
+	var deck CustomDeck
+	err := common.GORM.Where("guild_id = ? AND id = ?", g.ID, form.ID).First(&deck).Error
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return tmpl.AddAlerts(web.ErrorAlert("Unknown deck")), nil
+		}
+		return tmpl, err
+	}
+
+	err = common.GORM.Delete(&deck).Error
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyRemovedDeck, &cplogs.Param{Type: cplogs.ParamTypeString, Value: deck.Name}))
+	}
+	return tmpl, err
+}
+
+func HandleImportDeck(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	g, tmpl := web.GetBaseCPContextData(ctx)
+
+	f, _, err := r.FormFile("deck")
+	if err != nil {
+		return tmpl.AddAlerts(web.ErrorAlert("No deck file provided")), nil
+	}
+	defer f.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(f, maxImportSize+1))
+	if err != nil {
+		return tmpl, err
+	}
+
+	if len(raw) > maxImportSize {
+		return tmpl.AddAlerts(web.ErrorAlert("Deck file is too big")), nil
+	}
+
+	var data cardsagainstdiscord.CustomPackData
+	err = json.Unmarshal(raw, &data)
+	if err != nil {
+		return tmpl.AddAlerts(web.ErrorAlert("Invalid deck file: ", err.Error())), nil
+	}
+
+	data.Name = strings.TrimSpace(data.Name)
+
+	// Importing a deck with the name of an existing one replaces it
+	var existing CustomDeck
+	err = common.GORM.Where("guild_id = ? AND name = ?", g.ID, data.Name).First(&existing).Error
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return tmpl, err
+	}
+
+	deck, errMsg, err := saveDeck(g.ID, existing.ID, &data)
+	if err != nil {
+		return tmpl, err
+	}
+	if errMsg != "" {
+		return tmpl.AddAlerts(web.ErrorAlert(errMsg)), nil
+	}
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyImportedDeck, &cplogs.Param{Type: cplogs.ParamTypeString, Value: deck.Name}))
+	return tmpl, nil
+}
+
+// saveDeck validates the deck and creates it, or updates the deck with the id if it's not 0,
+// errMsg is set if the deck is invalid
+func saveDeck(guildID int64, id int64, data *cardsagainstdiscord.CustomPackData) (deck *CustomDeck, errMsg string, err error) {
+	if utf8.RuneCountInString(data.Description) > maxDescriptionLength {
+		return nil, fmt.Sprintf("Description can be max %d characters long", maxDescriptionLength), nil
+	}
+
+	pack, err := cardsagainstdiscord.NewCustomPack(data)
+	if err != nil {
+		return nil, err.Error(), nil
+	}
+
+	var count int
+	err = common.GORM.Model(&CustomDeck{}).Where("guild_id = ? AND name = ? AND id != ?", guildID, data.Name, id).Count(&count).Error
+	if err != nil {
+		return nil, "", err
+	}
+	if count > 0 {
+		return nil, "Name already used", nil
+	}
+
+	deck = &CustomDeck{}
+	if id != 0 {
+		err = common.GORM.Where("guild_id = ? AND id = ?", guildID, id).First(deck).Error
+		if err != nil {
+			if gorm.IsRecordNotFoundError(err) {
+				return nil, "Unknown deck", nil
+			}
+			return nil, "", err
+		}
+	} else {
+		err = common.GORM.Model(&CustomDeck{}).Where("guild_id = ?", guildID).Count(&count).Error
+		if err != nil {
+			return nil, "", err
+		}
+		if count >= MaxGuildDecks {
+			return nil, fmt.Sprintf("Max %d decks per server", MaxGuildDecks), nil
+		}
+
+		deck.GuildID = guildID
+	}
+
+	deck.Name = pack.Name
+	deck.Description = data.Description
+	deck.Prompts = make(pq.StringArray, 0, len(data.Prompts))
+	for _, v := range data.Prompts {
+		deck.Prompts = append(deck.Prompts, strings.TrimSpace(v))
+	}
+	deck.Responses = make(pq.StringArray, 0, len(data.Responses))
+	for _, v := range data.Responses {
+		deck.Responses = append(deck.Responses, strings.TrimSpace(v))
+	}
+
+	err = common.GORM.Save(deck).Error
+	return deck, "", err
+}
+
+func HandleExportDeck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	activeGuild := web.ContextGuild(ctx)
+
+	deckID, _ := strconv.ParseInt(pat.Param(r, "deck"), 10, 64)
+
+	var deck CustomDeck
+	err := common.GORM.Where("guild_id = ? AND id = ?", activeGuild.ID, deckID).First(&deck).Error
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			http.Error(w, "Unknown deck", http.StatusNotFound)
+			return
+		}
+
+		web.CtxLogger(ctx).WithError(err).Error("failed retrieving cah deck")
+		http.Error(w, "Failed retrieving deck", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="cah-deck-%s.json"`, deck.Name))
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(deck.PackData())
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed writing cah deck export")
+	}
+}
+
+var _ web.Plugin = (*Plugin)(nil)
@@ -5,6 +5,7 @@ import (
 	"github.com/cirelion/flint/antiphishing"
 	"github.com/cirelion/flint/applications"
 	"github.com/cirelion/flint/autorole"
+	"github.com/cirelion/flint/backup"
 	"github.com/cirelion/flint/common/featureflags"
 	"github.com/cirelion/flint/common/prom"
 	"github.com/cirelion/flint/common/run"
@@ -85,6 +86,7 @@ func main() {
 	internalapi.RegisterPlugin()
 	prom.RegisterPlugin()
 	featureflags.RegisterPlugin()
+	backup.RegisterPlugin()
 
 	// Register confusables replacer
 	confusables.Init()
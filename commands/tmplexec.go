@@ -21,6 +21,8 @@ func init() {
 		ctx.ContextFuncs["execAdmin"] = execBot
 		ctx.ContextFuncs["userArg"] = tmplUserArg(ctx)
 	})
+
+	templates.RegisterSideEffectFuncs("exec", "execAdmin")
 }
 
 // Returns a user from either id, mention string or if the input is just a user, a user...
@@ -23,6 +23,10 @@ CREATE TABLE IF NOT EXISTS core_configs (
 
 `
 
+const coreServerConfLanguageDBSchema = `
+ALTER TABLE core_configs ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT '';
+`
+
 var CoreServerConfigCache = rcache.NewInt(coreServerConfigCacheFetcher, time.Minute)
 
 func GetCoreServerConfCached(guildID int64) *models.CoreConfig {
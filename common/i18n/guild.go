@@ -0,0 +1,63 @@
+package i18n
+
+import (
+	"embed"
+
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/lib/dcmd"
+	"github.com/cirelion/flint/lib/discordgo"
+)
+
+//go:embed catalogs/*.json
+var catalogFS embed.FS
+
+func init() {
+	MustRegisterCatalogFS(catalogFS, "catalogs")
+}
+
+// GuildLanguage returns the language set for the server in the core config, or DefaultLanguage
+func GuildLanguage(guildID int64) string {
+	if guildID == 0 {
+		return DefaultLanguage
+	}
+
+	conf := common.GetCoreServerConfCached(guildID)
+	if conf.Language != "" && HasLanguage(conf.Language) {
+		return conf.Language
+	}
+
+	return DefaultLanguage
+}
+
+// InteractionLanguage returns the language to respond to the interaction in: the locale of the user
+// if there's a catalog for it, otherwise the language of the server
+func InteractionLanguage(ic *discordgo.Interaction) string {
+	if locale := string(ic.Locale); locale != "" && HasLanguage(locale) {
+		return normalizeLanguage(locale)
+	}
+
+	return GuildLanguage(ic.GuildID)
+}
+
+// DataLanguage returns the language to respond to the command in, see InteractionLanguage for slash commands
+func DataLanguage(data *dcmd.Data) string {
+	if data.SlashCommandTriggerData != nil {
+		return InteractionLanguage(data.SlashCommandTriggerData.Interaction)
+	}
+
+	if data.GuildData != nil {
+		return GuildLanguage(data.GuildData.GS.ID)
+	}
+
+	return DefaultLanguage
+}
+
+// T translates the message for the command, see DataLanguage and Translate
+func T(data *dcmd.Data, id string, args ...interface{}) string {
+	return Translate(DataLanguage(data), id, args...)
+}
+
+// TPlural translates the message with plural forms for the command, see DataLanguage and TranslatePlural
+func TPlural(data *dcmd.Data, id string, n int, args ...interface{}) string {
+	return TranslatePlural(DataLanguage(data), id, n, args...)
+}
@@ -0,0 +1,207 @@
+// Package i18n translates bot responses.
+//
+// Plugins register catalogs of messages keyed by ID per language (see RegisterCatalogFS), and resolve them with T and TPlural inside
+// their command handlers. The language is taken from the locale of the user on slash commands if there's a catalog for it,
+// and otherwise from the language set for the server in the core config.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"emperror.dev/errors"
+	"github.com/cirelion/flint/common"
+)
+
+// DefaultLanguage is used when the server hasn't set a language, and for messages missing from the catalog of a language
+const DefaultLanguage = "en"
+
+var logger = common.GetFixedPrefixLogger("i18n")
+
+// Message is a single catalog entry, with a form for each plural category the language uses.
+// Messages that don't depend on a count only need Other, in catalog files they can be given as a plain string.
+type Message struct {
+	Zero  string `json:"zero,omitempty"`
+	One   string `json:"one,omitempty"`
+	Two   string `json:"two,omitempty"`
+	Few   string `json:"few,omitempty"`
+	Many  string `json:"many,omitempty"`
+	Other string `json:"other,omitempty"`
+}
+
+func (m *Message) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		m.Other = s
+		return nil
+	}
+
+	type plain Message
+	return json.Unmarshal(b, (*plain)(m))
+}
+
+// Form returns the text for the plural form, falling back to Other (and One) when the form isn't set
+func (m *Message) Form(form PluralForm) string {
+	var s string
+	switch form {
+	case PluralZero:
+		s = m.Zero
+	case PluralOne:
+		s = m.One
+	case PluralTwo:
+		s = m.Two
+	case PluralFew:
+		s = m.Few
+	case PluralMany:
+		s = m.Many
+	}
+
+	if s == "" {
+		s = m.Other
+	}
+
+	if s == "" {
+		s = m.One
+	}
+
+	return s
+}
+
+var (
+	catalogsMU sync.RWMutex
+	catalogs   = make(map[string]map[string]*Message)
+)
+
+// RegisterCatalog adds the messages to the catalog of the language, replacing existing messages with the same IDs
+func RegisterCatalog(lang string, messages map[string]*Message) {
+	lang = normalizeLanguage(lang)
+
+	catalogsMU.Lock()
+	defer catalogsMU.Unlock()
+
+	catalog, ok := catalogs[lang]
+	if !ok {
+		catalog = make(map[string]*Message, len(messages))
+		catalogs[lang] = catalog
+	}
+
+	for id, m := range messages {
+		catalog[id] = m
+	}
+}
+
+// RegisterCatalogFS registers every <language>.json file in dir, usually an embedded directory of a plugin
+func RegisterCatalogFS(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		b, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+
+		var messages map[string]*Message
+		if err := json.Unmarshal(b, &messages); err != nil {
+			return errors.WithMessage(err, file)
+		}
+
+		RegisterCatalog(strings.TrimSuffix(path.Base(file), ".json"), messages)
+	}
+
+	return nil
+}
+
+// MustRegisterCatalogFS is RegisterCatalogFS but panics on errors, for use in plugin init functions
+func MustRegisterCatalogFS(fsys fs.FS, dir string) {
+	if err := RegisterCatalogFS(fsys, dir); err != nil {
+		panic("i18n: " + err.Error())
+	}
+}
+
+// HasLanguage returns true if there's a catalog for the language
+func HasLanguage(lang string) bool {
+	catalogsMU.RLock()
+	_, ok := catalogs[normalizeLanguage(lang)]
+	catalogsMU.RUnlock()
+	return ok
+}
+
+// Language is a language there's a catalog for
+type Language struct {
+	Code string
+	Name string
+}
+
+// Languages returns the languages there are catalogs for, sorted by code
+func Languages() []*Language {
+	catalogsMU.RLock()
+	codes := make([]string, 0, len(catalogs))
+	for code := range catalogs {
+		codes = append(codes, code)
+	}
+	catalogsMU.RUnlock()
+
+	sort.Strings(codes)
+
+	result := make([]*Language, 0, len(codes))
+	for _, code := range codes {
+		result = append(result, &Language{Code: code, Name: Translate(code, "language.name")})
+	}
+
+	return result
+}
+
+// Translate returns the message in the language formatted with args, the english message if the
+// language doesn't have it, or the ID itself if the message doesn't exist at all
+func Translate(lang, id string, args ...interface{}) string {
+	return translate(lang, id, PluralOther, args)
+}
+
+// TranslatePlural is Translate for messages with plural forms, the form is picked using the language's rules for n
+func TranslatePlural(lang, id string, n int, args ...interface{}) string {
+	return translate(lang, id, PluralFormFor(lang, n), args)
+}
+
+func translate(lang, id string, form PluralForm, args []interface{}) string {
+	m := findMessage(normalizeLanguage(lang), id)
+	if m == nil {
+		logger.Warnf("missing message %q", id)
+		return id
+	}
+
+	s := m.Form(form)
+	if len(args) < 1 {
+		return s
+	}
+
+	return fmt.Sprintf(s, args...)
+}
+
+func findMessage(lang, id string) *Message {
+	catalogsMU.RLock()
+	defer catalogsMU.RUnlock()
+
+	if m, ok := catalogs[lang][id]; ok {
+		return m
+	}
+
+	return catalogs[DefaultLanguage][id]
+}
+
+// normalizeLanguage turns a discord locale into the language code catalogs are registered under, e.g "pt-BR" -> "pt"
+func normalizeLanguage(locale string) string {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexByte(lang, '-'); i != -1 {
+		lang = lang[:i]
+	}
+
+	return lang
+}
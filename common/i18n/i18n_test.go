@@ -0,0 +1,64 @@
+package i18n
+
+import "testing"
+
+func TestPluralFormFor(t *testing.T) {
+	cases := []struct {
+		lang     string
+		n        int
+		expected PluralForm
+	}{
+		{"en", 1, PluralOne},
+		{"en", 0, PluralOther},
+		{"en-US", 2, PluralOther},
+		{"fr", 0, PluralOne},
+		{"fr", 2, PluralOther},
+		{"ru", 1, PluralOne},
+		{"ru", 3, PluralFew},
+		{"ru", 11, PluralMany},
+		{"ru", 21, PluralOne},
+		{"pl", 22, PluralFew},
+		{"pl", 25, PluralMany},
+		{"ja", 1, PluralOther},
+	}
+
+	for _, c := range cases {
+		if form := PluralFormFor(c.lang, c.n); form != c.expected {
+			t.Errorf("%s %d: expected form %d, got %d", c.lang, c.n, c.expected, form)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	RegisterCatalog("en", map[string]*Message{
+		"test.greeting": {Other: "Hello %s"},
+		"test.only_en":  {Other: "English only"},
+		"test.items":    {One: "%d item", Other: "%d items"},
+	})
+	RegisterCatalog("xx", map[string]*Message{
+		"test.greeting": {Other: "Hallo %s"},
+		"test.items":    {One: "%d ding", Other: "%d dingen"},
+	})
+
+	cases := []struct {
+		got, expected string
+	}{
+		{Translate("xx", "test.greeting", "bob"), "Hallo bob"},
+		{Translate("xx-YY", "test.greeting", "bob"), "Hallo bob"},
+		{Translate("xx", "test.only_en"), "English only"},
+		{Translate("zz", "test.greeting", "bob"), "Hello bob"},
+		{Translate("xx", "test.missing"), "test.missing"},
+		{TranslatePlural("xx", "test.items", 1, 1), "1 ding"},
+		{TranslatePlural("en", "test.items", 5, 5), "5 items"},
+	}
+
+	for i, c := range cases {
+		if c.got != c.expected {
+			t.Errorf("case %d: expected %q, got %q", i, c.expected, c.got)
+		}
+	}
+
+	if !HasLanguage("XX") || HasLanguage("zz") {
+		t.Error("HasLanguage returned the wrong result")
+	}
+}
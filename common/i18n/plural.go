@@ -0,0 +1,124 @@
+package i18n
+
+// PluralForm is a CLDR plural category
+type PluralForm int
+
+const (
+	PluralOther PluralForm = iota
+	PluralZero
+	PluralOne
+	PluralTwo
+	PluralFew
+	PluralMany
+)
+
+// pluralRules holds the CLDR cardinal rules for integers of the languages discord supports,
+// languages not in here use the english rule
+var pluralRules = map[string]func(n int) PluralForm{
+	"fr": pluralOneUpToOne,
+	"pt": pluralOneUpToOne,
+	"hi": pluralOneUpToOne,
+
+	"ru": pluralSlavic,
+	"uk": pluralSlavic,
+	"hr": pluralSlavic,
+
+	"pl": pluralPolish,
+	"cs": pluralCzech,
+	"lt": pluralLithuanian,
+	"ro": pluralRomanian,
+
+	"ja": pluralNone,
+	"ko": pluralNone,
+	"zh": pluralNone,
+	"th": pluralNone,
+	"vi": pluralNone,
+}
+
+// PluralFormFor returns the plural form of n in the language
+func PluralFormFor(lang string, n int) PluralForm {
+	if n < 0 {
+		n = -n
+	}
+
+	if rule, ok := pluralRules[normalizeLanguage(lang)]; ok {
+		return rule(n)
+	}
+
+	if n == 1 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
+
+func pluralNone(n int) PluralForm {
+	return PluralOther
+}
+
+func pluralOneUpToOne(n int) PluralForm {
+	if n <= 1 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
+
+func pluralSlavic(n int) PluralForm {
+	mod10, mod100 := n%10, n%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+func pluralPolish(n int) PluralForm {
+	mod10, mod100 := n%10, n%100
+	switch {
+	case n == 1:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+func pluralCzech(n int) PluralForm {
+	switch {
+	case n == 1:
+		return PluralOne
+	case n >= 2 && n <= 4:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+func pluralLithuanian(n int) PluralForm {
+	mod10, mod100 := n%10, n%100
+	switch {
+	case mod10 == 1 && (mod100 < 11 || mod100 > 19):
+		return PluralOne
+	case mod10 >= 2 && (mod100 < 11 || mod100 > 19):
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+func pluralRomanian(n int) PluralForm {
+	mod100 := n % 100
+	switch {
+	case n == 1:
+		return PluralOne
+	case n == 0 || (mod100 >= 2 && mod100 <= 19):
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
@@ -0,0 +1,55 @@
+package run
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/cirelion/flint/bot"
+	"github.com/cirelion/flint/bot/eventreplay"
+	"github.com/cirelion/flint/bot/eventsystem"
+	log "github.com/sirupsen/logrus"
+)
+
+// runReplay replays the recording set with -replay against a fake discord api and writes the captured calls
+func runReplay() {
+	f, err := os.Open(flagReplay)
+	if err != nil {
+		log.WithError(err).Fatal("Failed opening recording")
+	}
+
+	recording, err := eventsystem.ReadRecording(f)
+	f.Close()
+	if err != nil {
+		log.WithError(err).Fatal("Failed reading recording")
+	}
+
+	bot.Enabled = true
+	harness, err := eventreplay.NewHarness(recording)
+	if err != nil {
+		log.WithError(err).Fatal("Failed setting up replay")
+	}
+	defer harness.Close()
+
+	log.Infof("Replaying %d events of %s (%d)", len(recording.Events), recording.State.Guild.Name, recording.State.Guild.ID)
+	harness.Run(recording.Events, 5*time.Second, time.Minute)
+
+	out := os.Stdout
+	if flagReplayOut != "" {
+		out, err = os.Create(flagReplayOut)
+		if err != nil {
+			log.WithError(err).Fatal("Failed creating output file")
+		}
+		defer out.Close()
+	}
+
+	calls := harness.REST.Calls()
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(calls)
+	if err != nil {
+		log.WithError(err).Error("Failed writing api calls")
+	}
+
+	log.Infof("Done, captured %d api calls", len(calls))
+}
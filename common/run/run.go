@@ -47,10 +47,16 @@ var (
 	flagNodeID string
 
 	flagVersion bool
+
+	flagReplay    string
+	flagReplayOut string
 )
 
 var confSentryDSN = config.RegisterOption("yagpdb.sentry_dsn", "Sentry credentials for sentry logging hook", nil)
 
+// replaying only fakes the discord api, plugins still read and write postgres and redis like normal
+var confReplayScratch = config.RegisterOption("yagpdb.replay_scratch_databases", "Set if the configured postgres database and redis are separate ones only used for replaying recordings, required by -replay", false)
+
 func init() {
 	flag.BoolVar(&flagRunBot, "bot", false, "Set to run discord bot and bot related stuff")
 	flag.BoolVar(&flagRunWeb, "web", false, "Set to run webserver")
@@ -67,6 +73,9 @@ func init() {
 
 	flag.StringVar(&flagNodeID, "nodeid", "", "The id of this node, used when running with a sharding orchestrator")
 	flag.BoolVar(&flagVersion, "version", false, "Print the version and exit")
+
+	flag.StringVar(&flagReplay, "replay", "", "Replay the events of a recording against a fake discord api and exit, the captured api calls are written to -replayout. Requires yagpdb.replay_scratch_databases")
+	flag.StringVar(&flagReplayOut, "replayout", "", "File to write the api calls captured while replaying to, defaults to stdout")
 }
 
 func Init() {
@@ -93,7 +102,7 @@ func Init() {
 		AddSyslogHooks()
 	}
 
-	if !flagRunBot && !flagRunWeb && flagRunFeeds == "" && !flagRunEverything && !flagDryRun && !flagRunBWC && !flagGenConfigDocs {
+	if !flagRunBot && !flagRunWeb && flagRunFeeds == "" && !flagRunEverything && !flagDryRun && !flagRunBWC && !flagGenConfigDocs && flagReplay == "" {
 		log.Error("Didnt specify what to run, see -h for more info")
 		os.Exit(1)
 	}
@@ -105,6 +114,11 @@ func Init() {
 		log.WithError(err).Fatal("Failed running core init ")
 	}
 
+	if flagReplay != "" && !confReplayScratch.GetBool() {
+		log.Fatal("Replaying writes moderation cases, logs and such to the configured postgres database and redis like normal, " +
+			"point the bot at separate scratch ones and set yagpdb.replay_scratch_databases to replay")
+	}
+
 	if confSentryDSN.GetString() != "" {
 		addSentryHook()
 	}
@@ -149,6 +163,11 @@ func Run() {
 		return
 	}
 
+	if flagReplay != "" {
+		runReplay()
+		return
+	}
+
 	if flagRunWeb || flagRunEverything {
 		go web.Run()
 	}
@@ -176,6 +176,10 @@ type Context struct {
 
 	IsExecedByEvalCC bool
 
+	// If set, context funcs with side effects are not ran and the calls are recorded in DryRunActions instead
+	DryRun        bool
+	DryRunActions []*DryRunAction
+
 	contextFuncsAdded bool
 }
 
@@ -226,6 +230,10 @@ func (c *Context) setupContextFuncs() {
 		f(c)
 	}
 
+	if c.DryRun {
+		c.setupDryRunFuncs()
+	}
+
 	c.contextFuncsAdded = true
 }
 
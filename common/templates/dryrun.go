@@ -0,0 +1,126 @@
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// DryRunAction is a call to a context func with side effects that was recorded instead of performed during a dry run
+type DryRunAction struct {
+	Func string   `json:"func"`
+	Args []string `json:"args"`
+}
+
+// context funcs that change something on discord, sleep, or write to the database
+var sideEffectFuncs = map[string]bool{
+	"editMessage":              true,
+	"editMessageNoEscape":      true,
+	"pinMessage":               true,
+	"unpinMessage":             true,
+	"publishMessage":           true,
+	"sendDM":                   true,
+	"sendMessage":              true,
+	"sendMessageNoEscape":      true,
+	"sendMessageNoEscapeRetID": true,
+	"sendMessageRetID":         true,
+	"sendTemplate":             true,
+	"sendTemplateDM":           true,
+
+	"addRoleID":      true,
+	"removeRoleID":   true,
+	"setRoles":       true,
+	"addRoleName":    true,
+	"removeRoleName": true,
+	"giveRoleID":     true,
+	"giveRoleName":   true,
+	"takeRoleID":     true,
+	"takeRoleName":   true,
+
+	"addMessageReactions":       true,
+	"addReactions":              true,
+	"deleteAllMessageReactions": true,
+	"deleteMessage":             true,
+	"deleteMessageReaction":     true,
+	"deleteTrigger":             true,
+
+	"editChannelName":  true,
+	"editChannelTopic": true,
+	"editNickname":     true,
+	"sleep":            true,
+}
+
+// RegisterSideEffectFuncs marks context funcs added by plugins as having side effects,
+// they're not ran during a dry run and the calls are recorded instead.
+// Call this in your package init function.
+func RegisterSideEffectFuncs(names ...string) {
+	for _, v := range names {
+		sideEffectFuncs[v] = true
+	}
+}
+
+// replaces all the context funcs with side effects with stubs recording the call
+func (c *Context) setupDryRunFuncs() {
+	for name, f := range c.ContextFuncs {
+		if !sideEffectFuncs[name] {
+			continue
+		}
+
+		c.ContextFuncs[name] = c.dryRunStub(name, f)
+	}
+}
+
+// dryRunStub returns a func with the same signature as f that records the call and returns zero values
+func (c *Context) dryRunStub(name string, f interface{}) interface{} {
+	ft := reflect.TypeOf(f)
+	if ft.Kind() != reflect.Func {
+		return f
+	}
+
+	stub := func(in []reflect.Value) []reflect.Value {
+		args := make([]string, 0, len(in))
+		for i, v := range in {
+			if ft.IsVariadic() && i == len(in)-1 {
+				for j := 0; j < v.Len(); j++ {
+					args = append(args, formatDryRunArg(v.Index(j)))
+				}
+				continue
+			}
+
+			args = append(args, formatDryRunArg(v))
+		}
+
+		c.DryRunActions = append(c.DryRunActions, &DryRunAction{Func: name, Args: args})
+
+		out := make([]reflect.Value, ft.NumOut())
+		for i := range out {
+			out[i] = reflect.Zero(ft.Out(i))
+		}
+		return out
+	}
+
+	return reflect.MakeFunc(ft, stub).Interface()
+}
+
+func formatDryRunArg(v reflect.Value) string {
+	if !v.IsValid() || (v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr) && v.IsNil() {
+		return "nil"
+	}
+
+	i := v.Interface()
+	if s, ok := i.(string); ok {
+		return strconv.Quote(s)
+	}
+
+	if s, ok := i.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	encoded, err := json.Marshal(i)
+	if err != nil {
+		return fmt.Sprint(i)
+	}
+
+	return string(encoded)
+}
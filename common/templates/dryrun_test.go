@@ -0,0 +1,59 @@
+package templates
+
+import (
+	"testing"
+)
+
+func TestDryRunStub(t *testing.T) {
+	called := false
+	c := &Context{
+		DryRun: true,
+		ContextFuncs: map[string]interface{}{
+			"sendMessage": func(channel interface{}, msg interface{}) interface{} {
+				called = true
+				return "sent"
+			},
+			"addReactions": func(values ...interface{}) (string, error) {
+				called = true
+				return "", nil
+			},
+			"getRole": func(role interface{}) (string, error) {
+				return "role", nil
+			},
+		},
+	}
+
+	c.setupDryRunFuncs()
+
+	send := c.ContextFuncs["sendMessage"].(func(interface{}, interface{}) interface{})
+	if out := send(nil, "hello"); out != nil {
+		t.Error("expected stub to return nil, got: ", out)
+	}
+
+	react := c.ContextFuncs["addReactions"].(func(...interface{}) (string, error))
+	if _, err := react("👍", 5); err != nil {
+		t.Error("expected stub to return no error, got: ", err)
+	}
+
+	if out, _ := c.ContextFuncs["getRole"].(func(interface{}) (string, error))(1); out != "role" {
+		t.Error("funcs without side effects should not be replaced")
+	}
+
+	if called {
+		t.Error("side effect funcs should not be called during a dry run")
+	}
+
+	if len(c.DryRunActions) != 2 {
+		t.Fatalf("expected 2 recorded actions, got %d", len(c.DryRunActions))
+	}
+
+	first := c.DryRunActions[0]
+	if first.Func != "sendMessage" || len(first.Args) != 2 || first.Args[0] != "nil" || first.Args[1] != `"hello"` {
+		t.Errorf("unexpected recorded action: %#v", first)
+	}
+
+	second := c.DryRunActions[1]
+	if second.Func != "addReactions" || len(second.Args) != 2 || second.Args[1] != "5" {
+		t.Errorf("unexpected recorded action: %#v", second)
+	}
+}
@@ -0,0 +1,228 @@
+package customcommands
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/cirelion/flint/backup"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/featureflags"
+	"github.com/cirelion/flint/common/pubsub"
+	schEventsModels "github.com/cirelion/flint/common/scheduledevents2/models"
+	"github.com/cirelion/flint/customcommands/models"
+	"github.com/cirelion/flint/lib/dstate"
+	"github.com/cirelion/flint/premium"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+var _ backup.PluginWithBackup = (*Plugin)(nil)
+
+// ExportBackup exports all custom commands and groups as a bundle
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	bundle, err := ExportBundle(ctx, guildID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bundle.Commands) < 1 && len(bundle.Groups) < 1 {
+		return nil, nil
+	}
+
+	return bundle, nil
+}
+
+// ImportBackup replaces all custom commands and groups with the ones in the backup, keeping the ids of the commands.
+// Groups get new ids as those are shared between all servers.
+func (p *Plugin) ImportBackup(ctx context.Context, ic *backup.ImportContext, data json.RawMessage) error {
+	var bundle Bundle
+	err := json.Unmarshal(data, &bundle)
+	if err != nil {
+		return err
+	}
+
+	for _, g := range bundle.Groups {
+		g.IgnoreRoles = ic.Remap.Roles(g.IgnoreRoles)
+		g.WhitelistRoles = ic.Remap.Roles(g.WhitelistRoles)
+		g.IgnoreChannels = ic.Remap.Channels(g.IgnoreChannels)
+		g.WhitelistChannels = ic.Remap.Channels(g.WhitelistChannels)
+	}
+
+	for _, c := range bundle.Commands {
+		c.Roles = ic.Remap.Roles(c.Roles)
+		c.Channels = ic.Remap.Channels(c.Channels)
+		c.ContextChannel = ic.Remap.Channel(c.ContextChannel)
+	}
+
+	isPremium, err := premium.IsGuildPremium(ic.GS.ID)
+	if err != nil {
+		return err
+	}
+
+	result := &ImportResult{}
+	err = importBackupBundle(ctx, ic.GS, &bundle, isPremium, result)
+	if err != nil {
+		if bErr, ok := errors.Cause(err).(BundleError); ok {
+			ic.Warn("Custom commands: %s", bErr.Error())
+		}
+
+		return err
+	}
+
+	for _, v := range result.Warnings {
+		ic.Warn("Custom commands: %s", v)
+	}
+
+	return nil
+}
+
+func importBackupBundle(ctx context.Context, gs *dstate.GuildSet, bundle *Bundle, isPremium bool, result *ImportResult) error {
+	err := bundle.Validate()
+	if err != nil {
+		return err
+	}
+
+	maxCommands := MaxCommands
+	if isPremium {
+		maxCommands = MaxCommandsPremium
+	}
+
+	if len(bundle.Commands) > maxCommands {
+		return BundleError(fmt.Sprintf("The backup has more than the limit of %d custom commands (or %d for premium servers)", MaxCommands, MaxCommandsPremium))
+	}
+
+	seenIDs := make(map[int64]bool)
+	cmds := make([]*models.CustomCommand, len(bundle.Commands))
+	for i, bc := range bundle.Commands {
+		if bc.ID < 1 || seenIDs[bc.ID] {
+			return BundleError(fmt.Sprintf("Command #%d: invalid or duplicate id", bc.ID))
+		}
+		seenIDs[bc.ID] = true
+
+		cmds[i] = bc.toDBModel(gs, isPremium, result)
+		cmds[i].LocalID = bc.ID
+	}
+
+	if countLowIntervalCommands(cmds, nil) > 5 {
+		return BundleError("The backup has more than the limit of 5 triggers on less than 10 minute intervals")
+	}
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WithStackIf(err)
+	}
+
+	err = replaceCommands(ctx, tx, gs, bundle, cmds, result)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.WithStackIf(err)
+	}
+
+	// make sure commands created after this don't get the id of a restored one
+	var maxLocalID int64
+	for _, cmd := range cmds {
+		if cmd.LocalID > maxLocalID {
+			maxLocalID = cmd.LocalID
+		}
+	}
+
+	err = raiseLocalIDCounter(gs.ID, maxLocalID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", gs.ID).Error("failed updating the custom command local id counter")
+	}
+
+	for _, cmd := range cmds {
+		if err := UpdateCommandNextRunTime(cmd, false, false); err != nil {
+			logger.WithError(err).WithField("guild", gs.ID).Error("failed updating next custom command run time")
+		}
+	}
+
+	featureflags.MarkGuildDirty(gs.ID)
+	pubsub.EvictCacheSet(cachedCommandsMessage, gs.ID)
+
+	return nil
+}
+
+// replaceCommands deletes all the custom commands, groups and interval runs of the server and inserts the ones from the backup
+func replaceCommands(ctx context.Context, tx *sql.Tx, gs *dstate.GuildSet, bundle *Bundle, cmds []*models.CustomCommand, result *ImportResult) error {
+	_, err := models.CustomCommands(qm.Where("guild_id = ?", gs.ID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return errors.WithStackIf(err)
+	}
+
+	_, err = models.CustomCommandGroups(qm.Where("guild_id = ?", gs.ID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return errors.WithStackIf(err)
+	}
+
+	_, err = schEventsModels.ScheduledEvents(qm.Where("event_name='cc_next_run' AND guild_id = ?", gs.ID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return errors.WithStackIf(err)
+	}
+
+	groupIDs := make(map[int64]int64)
+	for _, bg := range bundle.Groups {
+		group := &models.CustomCommandGroup{
+			GuildID: gs.ID,
+			Name:    strings.TrimSpace(bg.Name),
+		}
+
+		var dropped, d bool
+		group.IgnoreRoles, d = filterGuildRoles(gs, bg.IgnoreRoles)
+		dropped = dropped || d
+		group.WhitelistRoles, d = filterGuildRoles(gs, bg.WhitelistRoles)
+		dropped = dropped || d
+		group.IgnoreChannels, d = filterGuildChannels(gs, bg.IgnoreChannels)
+		dropped = dropped || d
+		group.WhitelistChannels, d = filterGuildChannels(gs, bg.WhitelistChannels)
+		dropped = dropped || d
+		if dropped {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Group %s: channels or roles not found on this server were removed", group.Name))
+		}
+
+		err = group.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return errors.WithStackIf(err)
+		}
+
+		groupIDs[bg.ID] = group.ID
+	}
+
+	for i, cmd := range cmds {
+		if groupID, ok := groupIDs[bundle.Commands[i].GroupID]; ok {
+			cmd.GroupID = null.Int64From(groupID)
+		}
+
+		err = cmd.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return errors.WithStackIf(err)
+		}
+	}
+
+	return nil
+}
+
+// raiseLocalIDCounter makes sure the next generated custom command id is above id
+func raiseLocalIDCounter(guildID int64, id int64) error {
+	key := "local_ids:" + strconv.FormatInt(guildID, 10)
+
+	var current int64
+	err := common.RedisPool.Do(radix.Cmd(&current, "HGET", key, "custom_command"))
+	if err != nil || current >= id {
+		return err
+	}
+
+	return common.RedisPool.Do(radix.FlatCmd(nil, "HSET", key, "custom_command", id))
+}
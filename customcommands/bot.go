@@ -3,8 +3,11 @@ package customcommands
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"math/rand"
+	"net/http"
 	"regexp"
 	"runtime/debug"
 	"sort"
@@ -61,7 +64,7 @@ var _ bot.BotInitHandler = (*Plugin)(nil)
 var _ commands.CommandProvider = (*Plugin)(nil)
 
 func (p *Plugin) AddCommands() {
-	commands.AddRootCommands(p, cmdListCommands, cmdFixCommands, cmdEvalCommand)
+	commands.AddRootCommands(p, cmdListCommands, cmdFixCommands, cmdEvalCommand, cmdExportCommands, cmdImportCommands)
 }
 
 func (p *Plugin) BotInit() {
@@ -130,22 +133,12 @@ var cmdEvalCommand = &commands.YAGCommand{
 	ApplicationCommandEnabled: false,
 	DefaultEnabled:            true,
 	RunFunc: func(data *dcmd.Data) (interface{}, error) {
-		hasCoreWriteRole := false
-
-		writeRoles := common.GetCoreServerConfCached(data.GuildData.GS.ID).AllowedWriteRoles
-		for _, r := range data.GuildData.MS.Member.Roles {
-			if common.ContainsInt64Slice(writeRoles, r) {
-				hasCoreWriteRole = true
-				break
-			}
-		}
-
-		adminOrPerms, err := bot.AdminOrPermMS(data.GuildData.GS.ID, data.GuildData.CS.ID, data.GuildData.MS, discordgo.PermissionManageServer)
+		ok, err := hasManageCCPerms(data)
 		if err != nil {
 			return nil, err
 		}
 
-		if !(adminOrPerms || hasCoreWriteRole) {
+		if !ok {
 			return "You need `Manage Server` permissions or control panel write access for this command", nil
 		}
 
@@ -183,6 +176,149 @@ var cmdEvalCommand = &commands.YAGCommand{
 	},
 }
 
+// hasManageCCPerms returns true if the member has manage server permissions or control panel write access
+func hasManageCCPerms(data *dcmd.Data) (bool, error) {
+	writeRoles := common.GetCoreServerConfCached(data.GuildData.GS.ID).AllowedWriteRoles
+	for _, r := range data.GuildData.MS.Member.Roles {
+		if common.ContainsInt64Slice(writeRoles, r) {
+			return true, nil
+		}
+	}
+
+	return bot.AdminOrPermMS(data.GuildData.GS.ID, data.GuildData.CS.ID, data.GuildData.MS, discordgo.PermissionManageServer)
+}
+
+var cmdExportCommands = &commands.YAGCommand{
+	CmdCategory: commands.CategoryTool,
+	Name:        "CCExport",
+	Description: "Exports custom commands and groups as a bundle that can be imported on another server, exports everything if no commands or groups are specified",
+	Arguments: []*dcmd.ArgDef{
+		{Name: "IDs", Type: dcmd.String, Help: "Comma separated custom command IDs"},
+	},
+	ArgSwitches: []*dcmd.ArgDef{
+		{Name: "groups", Type: dcmd.String, Help: "Comma separated group IDs"},
+	},
+	ApplicationCommandEnabled: false,
+	DefaultEnabled:            true,
+	RunFunc: func(data *dcmd.Data) (interface{}, error) {
+		ok, err := hasManageCCPerms(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return "You need `Manage Server` permissions or control panel write access for this command", nil
+		}
+
+		if data.Context().Value(commands.CtxKeyExecutedByCC) == true {
+			return "", nil
+		}
+
+		ccIDs := parseIDList([]string{data.Args[0].Str()})
+		groupIDs := parseIDList([]string{data.Switch("groups").Str()})
+
+		bundle, err := ExportBundle(data.Context(), data.GuildData.GS.ID, ccIDs, groupIDs)
+		if err != nil {
+			return "Failed exporting custom commands", err
+		}
+
+		if len(bundle.Commands) == 0 && len(bundle.Groups) == 0 {
+			return "Nothing to export", nil
+		}
+
+		encoded, err := json.MarshalIndent(bundle, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		return &discordgo.MessageSend{
+			Content: fmt.Sprintf("Exported %d custom commands and %d groups", len(bundle.Commands), len(bundle.Groups)),
+			Files: []*discordgo.File{
+				{
+					Name:   fmt.Sprintf("custom-commands-%d.json", data.GuildData.GS.ID),
+					Reader: bytes.NewReader(encoded),
+				},
+			},
+		}, nil
+	},
+}
+
+var cmdImportCommands = &commands.YAGCommand{
+	CmdCategory: commands.CategoryTool,
+	Name:        "CCImport",
+	Description: "Imports a custom command bundle attached to the message. Mode decides what happens on conflicts with existing commands: skip (default), overwrite or rename",
+	Arguments: []*dcmd.ArgDef{
+		{Name: "Mode", Type: dcmd.String, Default: "skip"},
+	},
+	ApplicationCommandEnabled: false,
+	DefaultEnabled:            true,
+	RunFunc: func(data *dcmd.Data) (interface{}, error) {
+		ok, err := hasManageCCPerms(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return "You need `Manage Server` permissions or control panel write access for this command", nil
+		}
+
+		if data.Context().Value(commands.CtxKeyExecutedByCC) == true {
+			return "", nil
+		}
+
+		mode, ok := ParseImportMode(data.Args[0].Str())
+		if !ok {
+			return "Unknown mode, use one of `skip`, `overwrite` or `rename`", nil
+		}
+
+		if data.TraditionalTriggerData == nil || len(data.TraditionalTriggerData.Message.Attachments) < 1 {
+			return "Attach the bundle file to the message", nil
+		}
+
+		attachment := data.TraditionalTriggerData.Message.Attachments[0]
+		if attachment.Size > MaxBundleSize {
+			return "Bundle is too big, max 1MB", nil
+		}
+
+		resp, err := bundleDownloadClient.Get(attachment.URL)
+		if err != nil {
+			return "Failed downloading the bundle", err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return "Failed downloading the bundle", errors.Errorf("unexpected status code downloading bundle: %d", resp.StatusCode)
+		}
+
+		var bundle Bundle
+		err = json.NewDecoder(io.LimitReader(resp.Body, MaxBundleSize)).Decode(&bundle)
+		if err != nil {
+			return "Failed parsing bundle: " + err.Error(), nil
+		}
+
+		isPremium, err := premium.IsGuildPremium(data.GuildData.GS.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		author := data.Author
+		result, err := ImportBundle(data.Context(), data.GuildData.GS, &bundle, mode, isPremium, author.ID, author.Username)
+		if err != nil {
+			if bErr, ok := errors.Cause(err).(BundleError); ok {
+				return bErr.Error(), nil
+			}
+
+			return "Failed importing custom commands", err
+		}
+
+		return result.String(), nil
+	},
+}
+
+var bundleDownloadClient = &http.Client{
+	Timeout: time.Second * 15,
+}
+
 var cmdListCommands = &commands.YAGCommand{
 	CmdCategory:    commands.CategoryTool,
 	Name:           "CustomCommands",
@@ -0,0 +1,684 @@
+package customcommands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"emperror.dev/errors"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/featureflags"
+	"github.com/cirelion/flint/common/pubsub"
+	"github.com/cirelion/flint/customcommands/models"
+	"github.com/cirelion/flint/lib/dstate"
+	"github.com/cirelion/flint/web"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// BundleVersion is the current version of the bundle format, bump this when making incompatible changes
+const BundleVersion = 1
+
+// Max size of a bundle file accepted for import
+const MaxBundleSize = 1024 * 1024
+
+// Bundle is a portable export of custom commands and groups that can be imported on another server
+type Bundle struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+
+	Groups   []*BundleGroup   `json:"groups"`
+	Commands []*BundleCommand `json:"commands"`
+}
+
+type BundleGroup struct {
+	// ID is only used to reference the group from commands within the bundle
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+
+	IgnoreRoles       []int64 `json:"ignore_roles,omitempty"`
+	IgnoreChannels    []int64 `json:"ignore_channels,omitempty"`
+	WhitelistRoles    []int64 `json:"whitelist_roles,omitempty"`
+	WhitelistChannels []int64 `json:"whitelist_channels,omitempty"`
+}
+
+type BundleCommand struct {
+	ID      int64 `json:"id"`
+	GroupID int64 `json:"group_id,omitempty"`
+
+	Name string `json:"name,omitempty"`
+
+	TriggerType              int    `json:"trigger_type"`
+	TextTrigger              string `json:"text_trigger,omitempty"`
+	TextTriggerCaseSensitive bool   `json:"text_trigger_case_sensitive,omitempty"`
+	TriggerOnEdit            bool   `json:"trigger_on_edit,omitempty"`
+	ReactionTriggerMode      int16  `json:"reaction_trigger_mode,omitempty"`
+
+	TimeTriggerInterval       int     `json:"time_trigger_interval,omitempty"`
+	TimeTriggerExcludingDays  []int64 `json:"time_trigger_excluding_days,omitempty"`
+	TimeTriggerExcludingHours []int64 `json:"time_trigger_excluding_hours,omitempty"`
+	ContextChannel            int64   `json:"context_channel,omitempty"`
+
+	Responses []string `json:"responses"`
+
+	Channels              []int64 `json:"channels,omitempty"`
+	ChannelsWhitelistMode bool    `json:"channels_whitelist_mode,omitempty"`
+	Roles                 []int64 `json:"roles,omitempty"`
+	RolesWhitelistMode    bool    `json:"roles_whitelist_mode,omitempty"`
+
+	ShowErrors bool `json:"show_errors"`
+	Disabled   bool `json:"disabled,omitempty"`
+}
+
+// ImportMode decides what happens to commands and groups in a bundle that conflict with existing ones
+type ImportMode string
+
+const (
+	ImportModeSkip      ImportMode = "skip"
+	ImportModeOverwrite ImportMode = "overwrite"
+	ImportModeRename    ImportMode = "rename"
+)
+
+// ParseImportMode returns the import mode from user input, defaulting to skip
+func ParseImportMode(s string) (ImportMode, bool) {
+	switch ImportMode(strings.ToLower(strings.TrimSpace(s))) {
+	case "", ImportModeSkip:
+		return ImportModeSkip, true
+	case ImportModeOverwrite:
+		return ImportModeOverwrite, true
+	case ImportModeRename:
+		return ImportModeRename, true
+	}
+
+	return "", false
+}
+
+// BundleError is a problem with the bundle or limits that should be shown to the user
+type BundleError string
+
+func (b BundleError) Error() string {
+	return string(b)
+}
+
+// ImportResult summarizes what was done during an import
+type ImportResult struct {
+	Created     []int64
+	Overwritten []int64
+	Skipped     []string
+
+	GroupsCreated     int
+	GroupsOverwritten int
+
+	Warnings []string
+}
+
+func (r *ImportResult) String() string {
+	out := fmt.Sprintf("Created %d and overwrote %d custom commands, skipped %d. Created %d and overwrote %d groups.",
+		len(r.Created), len(r.Overwritten), len(r.Skipped), r.GroupsCreated, r.GroupsOverwritten)
+
+	if len(r.Skipped) > 0 {
+		out += "\nSkipped: " + strings.Join(r.Skipped, ", ")
+	}
+
+	for _, v := range r.Warnings {
+		out += "\n" + v
+	}
+
+	return out
+}
+
+// ExportBundle exports the provided commands and groups, commands within the provided groups are included as well.
+// If no commands or groups are provided everything is exported.
+func ExportBundle(ctx context.Context, guildID int64, ccIDs []int64, groupIDs []int64) (*Bundle, error) {
+	all := len(ccIDs) == 0 && len(groupIDs) == 0
+
+	groups, err := models.CustomCommandGroups(qm.Where("guild_id = ?", guildID), qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	commands, err := models.CustomCommands(qm.Where("guild_id = ?", guildID), qm.OrderBy("local_id asc")).AllG(ctx)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	bundle := &Bundle{
+		Version:    BundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Groups:     []*BundleGroup{},
+		Commands:   []*BundleCommand{},
+	}
+
+	includedGroups := make(map[int64]bool)
+	for _, cmd := range commands {
+		if !all && !common.ContainsInt64Slice(ccIDs, cmd.LocalID) && !(cmd.GroupID.Valid && common.ContainsInt64Slice(groupIDs, cmd.GroupID.Int64)) {
+			continue
+		}
+
+		if cmd.GroupID.Valid {
+			includedGroups[cmd.GroupID.Int64] = true
+		}
+
+		bundle.Commands = append(bundle.Commands, bundleCommandFromModel(cmd))
+	}
+
+	for _, g := range groups {
+		if !all && !includedGroups[g.ID] && !common.ContainsInt64Slice(groupIDs, g.ID) {
+			continue
+		}
+
+		bundle.Groups = append(bundle.Groups, &BundleGroup{
+			ID:                g.ID,
+			Name:              g.Name,
+			IgnoreRoles:       g.IgnoreRoles,
+			IgnoreChannels:    g.IgnoreChannels,
+			WhitelistRoles:    g.WhitelistRoles,
+			WhitelistChannels: g.WhitelistChannels,
+		})
+	}
+
+	return bundle, nil
+}
+
+func bundleCommandFromModel(cmd *models.CustomCommand) *BundleCommand {
+	return &BundleCommand{
+		ID:      cmd.LocalID,
+		GroupID: cmd.GroupID.Int64,
+		Name:    cmd.Name.String,
+
+		TriggerType:              cmd.TriggerType,
+		TextTrigger:              cmd.TextTrigger,
+		TextTriggerCaseSensitive: cmd.TextTriggerCaseSensitive,
+		TriggerOnEdit:            cmd.TriggerOnEdit,
+		ReactionTriggerMode:      cmd.ReactionTriggerMode,
+
+		TimeTriggerInterval:       cmd.TimeTriggerInterval,
+		TimeTriggerExcludingDays:  cmd.TimeTriggerExcludingDays,
+		TimeTriggerExcludingHours: cmd.TimeTriggerExcludingHours,
+		ContextChannel:            cmd.ContextChannel,
+
+		Responses: cmd.Responses,
+
+		Channels:              cmd.Channels,
+		ChannelsWhitelistMode: cmd.ChannelsWhitelistMode,
+		Roles:                 cmd.Roles,
+		RolesWhitelistMode:    cmd.RolesWhitelistMode,
+
+		ShowErrors: cmd.ShowErrors,
+		Disabled:   cmd.Disabled,
+	}
+}
+
+// Validate checks the bundle against the same limits as the control panel
+func (b *Bundle) Validate() error {
+	if b.Version < 1 || b.Version > BundleVersion {
+		return BundleError(fmt.Sprintf("Unsupported bundle version %d, this server supports up to version %d", b.Version, BundleVersion))
+	}
+
+	if len(b.Groups) > MaxGroups {
+		return BundleError(fmt.Sprintf("Too many groups in bundle, max %d", MaxGroups))
+	}
+
+	if len(b.Commands) > MaxCommandsPremium {
+		return BundleError(fmt.Sprintf("Too many commands in bundle, max %d", MaxCommandsPremium))
+	}
+
+	for _, g := range b.Groups {
+		if strings.TrimSpace(g.Name) == "" || utf8.RuneCountInString(g.Name) > 100 {
+			return BundleError(fmt.Sprintf("Group #%d: name has to be between 1 and 100 characters", g.ID))
+		}
+	}
+
+	for _, c := range b.Commands {
+		if err := c.validate(); err != nil {
+			return BundleError(fmt.Sprintf("Command #%d: %s", c.ID, err.Error()))
+		}
+	}
+
+	return nil
+}
+
+func (c *BundleCommand) validate() error {
+	if _, ok := triggerStrings[CommandTriggerType(c.TriggerType)]; !ok {
+		return errors.New("unknown trigger type")
+	}
+
+	if utf8.RuneCountInString(c.TextTrigger) > 1000 {
+		return errors.New("trigger can be max 1000 characters")
+	}
+
+	if utf8.RuneCountInString(c.Name) > 100 {
+		return errors.New("name can be max 100 characters")
+	}
+
+	if len(c.Responses) > MaxUserMessages {
+		return errors.Errorf("too many responses, max %d", MaxUserMessages)
+	}
+
+	foundOkayResponse := false
+	combinedSize := 0
+	for i, v := range c.Responses {
+		if strings.TrimSpace(v) != "" {
+			foundOkayResponse = true
+		}
+		combinedSize += utf8.RuneCountInString(v)
+
+		if err := web.ValidateTemplateField(v, 10000); err != nil {
+			return errors.Errorf("response #%d: %s", i+1, err.Error())
+		}
+	}
+
+	if !foundOkayResponse {
+		return errors.New("no response set")
+	}
+
+	if combinedSize > 10000 {
+		return errors.New("max combined command size can be 10k")
+	}
+
+	if CommandTriggerType(c.TriggerType) == CommandTriggerInterval &&
+		(c.TimeTriggerInterval < MinIntervalTriggerDurationMinutes || c.TimeTriggerInterval > MaxIntervalTriggerDurationMinutes) {
+		return errors.Errorf("interval has to be between %d and %d minutes", MinIntervalTriggerDurationMinutes, MaxIntervalTriggerDurationMinutes)
+	}
+
+	return nil
+}
+
+// toDBModel converts the command to a model for the provided server, dropping channels and roles that don't exist there
+func (c *BundleCommand) toDBModel(gs *dstate.GuildSet, isPremium bool, result *ImportResult) *models.CustomCommand {
+	m := &models.CustomCommand{
+		GuildID: gs.ID,
+
+		TriggerType:              c.TriggerType,
+		TextTrigger:              c.TextTrigger,
+		TextTriggerCaseSensitive: c.TextTriggerCaseSensitive,
+		TriggerOnEdit:            c.TriggerOnEdit,
+		ReactionTriggerMode:      c.ReactionTriggerMode,
+
+		TimeTriggerInterval:       c.TimeTriggerInterval,
+		TimeTriggerExcludingDays:  c.TimeTriggerExcludingDays,
+		TimeTriggerExcludingHours: c.TimeTriggerExcludingHours,
+
+		Responses: c.Responses,
+
+		ChannelsWhitelistMode: c.ChannelsWhitelistMode,
+		RolesWhitelistMode:    c.RolesWhitelistMode,
+
+		ShowErrors: c.ShowErrors,
+		Disabled:   c.Disabled,
+	}
+
+	if m.TimeTriggerExcludingDays == nil {
+		m.TimeTriggerExcludingDays = []int64{}
+	}
+
+	if m.TimeTriggerExcludingHours == nil {
+		m.TimeTriggerExcludingHours = []int64{}
+	}
+
+	if c.Name != "" {
+		m.Name = null.StringFrom(c.Name)
+	}
+
+	if c.TriggerOnEdit && !isPremium {
+		m.TriggerOnEdit = false
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Command #%d: `Trigger on edits` is a premium feature and was disabled", c.ID))
+	}
+
+	var dropped bool
+	m.Channels, dropped = filterGuildChannels(gs, c.Channels)
+	if c.ContextChannel != 0 && gs.GetChannel(c.ContextChannel) != nil {
+		m.ContextChannel = c.ContextChannel
+	} else if c.ContextChannel != 0 {
+		dropped = true
+	}
+
+	var droppedRoles bool
+	m.Roles, droppedRoles = filterGuildRoles(gs, c.Roles)
+	if dropped || droppedRoles {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Command #%d: channels or roles not found on this server were removed", c.ID))
+	}
+
+	return m
+}
+
+func filterGuildChannels(gs *dstate.GuildSet, channels []int64) (filtered []int64, dropped bool) {
+	filtered = make([]int64, 0, len(channels))
+	for _, v := range channels {
+		if gs.GetChannel(v) == nil {
+			dropped = true
+			continue
+		}
+
+		filtered = append(filtered, v)
+	}
+
+	return filtered, dropped
+}
+
+func filterGuildRoles(gs *dstate.GuildSet, roles []int64) (filtered []int64, dropped bool) {
+	filtered = make([]int64, 0, len(roles))
+	for _, v := range roles {
+		if gs.GetRole(v) == nil {
+			dropped = true
+			continue
+		}
+
+		filtered = append(filtered, v)
+	}
+
+	return filtered, dropped
+}
+
+// Max number of suffixes tried when renaming a conflicting command
+const maxRenameAttempts = 1000
+
+// namesConflict returns true if both commands have the same name, commands without a name never conflict
+func namesConflict(a, b *models.CustomCommand) bool {
+	return a.Name.String != "" && strings.EqualFold(a.Name.String, b.Name.String)
+}
+
+// triggersConflict returns true if both commands would be triggered by the same text, commands without a text trigger never conflict
+func triggersConflict(a, b *models.CustomCommand) bool {
+	if a.TriggerType != b.TriggerType || a.TextTrigger == "" || b.TextTrigger == "" {
+		return false
+	}
+
+	switch CommandTriggerType(a.TriggerType) {
+	case CommandTriggerInterval, CommandTriggerReaction, CommandTriggerNone:
+		return false
+	}
+
+	if a.TextTriggerCaseSensitive && b.TextTriggerCaseSensitive {
+		return a.TextTrigger == b.TextTrigger
+	}
+
+	return strings.EqualFold(a.TextTrigger, b.TextTrigger)
+}
+
+// commandsConflict returns true if both commands have the same name or would be triggered by the same text
+func commandsConflict(a, b *models.CustomCommand) bool {
+	return namesConflict(a, b) || triggersConflict(a, b)
+}
+
+func findConflictingCommand(cmds []*models.CustomCommand, cmd *models.CustomCommand) *models.CustomCommand {
+	for _, v := range cmds {
+		if commandsConflict(v, cmd) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// renameCommand suffixes the name and/or trigger of the command, whichever conflicts, until it no longer conflicts with any of the commands
+func renameCommand(cmds []*models.CustomCommand, cmd *models.CustomCommand) error {
+	name, trigger := cmd.Name.String, cmd.TextTrigger
+	for i := 2; i < maxRenameAttempts+2; i++ {
+		conflict := findConflictingCommand(cmds, cmd)
+		if conflict == nil {
+			return nil
+		}
+
+		if namesConflict(conflict, cmd) {
+			cmd.Name = null.StringFrom(name + " (" + strconv.Itoa(i) + ")")
+		}
+
+		if triggersConflict(conflict, cmd) {
+			cmd.TextTrigger = trigger + strconv.Itoa(i)
+		}
+	}
+
+	if findConflictingCommand(cmds, cmd) == nil {
+		return nil
+	}
+
+	return errors.Errorf("failed finding a free name and trigger after %d attempts", maxRenameAttempts)
+}
+
+// renameGroup suffixes the name until it's not taken by any of the groups
+func renameGroup(groups map[string]*models.CustomCommandGroup, name string) string {
+	newName := name
+	for i := 2; groups[strings.ToLower(newName)] != nil; i++ {
+		newName = name + " (" + strconv.Itoa(i) + ")"
+	}
+
+	return newName
+}
+
+// ImportBundle imports the bundle on the server, resolving conflicts with existing commands and groups using the mode
+func ImportBundle(ctx context.Context, gs *dstate.GuildSet, bundle *Bundle, mode ImportMode, isPremium bool, authorID int64, authorName string) (*ImportResult, error) {
+	err := bundle.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	existingGroups, err := models.CustomCommandGroups(qm.Where("guild_id = ?", gs.ID)).AllG(ctx)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	existingCmds, err := models.CustomCommands(qm.Where("guild_id = ?", gs.ID), qm.OrderBy("local_id asc")).AllG(ctx)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	result := &ImportResult{}
+
+	groupsByName := make(map[string]*models.CustomCommandGroup)
+	for _, g := range existingGroups {
+		groupsByName[strings.ToLower(g.Name)] = g
+	}
+
+	// maps the group ids in the bundle to the group on this server
+	groupMapping := make(map[int64]*models.CustomCommandGroup)
+	var newGroups, updatedGroups []*models.CustomCommandGroup
+	for _, bg := range bundle.Groups {
+		name := strings.TrimSpace(bg.Name)
+		group := &models.CustomCommandGroup{
+			GuildID: gs.ID,
+			Name:    name,
+		}
+
+		if existing := groupsByName[strings.ToLower(name)]; existing != nil {
+			switch mode {
+			case ImportModeSkip:
+				groupMapping[bg.ID] = existing
+				continue
+			case ImportModeOverwrite:
+				group = existing
+				updatedGroups = append(updatedGroups, group)
+			case ImportModeRename:
+				group.Name = renameGroup(groupsByName, name)
+				newGroups = append(newGroups, group)
+			}
+		} else {
+			newGroups = append(newGroups, group)
+		}
+
+		var dropped, d bool
+		group.IgnoreRoles, d = filterGuildRoles(gs, bg.IgnoreRoles)
+		dropped = dropped || d
+		group.WhitelistRoles, d = filterGuildRoles(gs, bg.WhitelistRoles)
+		dropped = dropped || d
+		group.IgnoreChannels, d = filterGuildChannels(gs, bg.IgnoreChannels)
+		dropped = dropped || d
+		group.WhitelistChannels, d = filterGuildChannels(gs, bg.WhitelistChannels)
+		dropped = dropped || d
+		if dropped {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Group %s: channels or roles not found on this server were removed", group.Name))
+		}
+
+		groupsByName[strings.ToLower(group.Name)] = group
+		groupMapping[bg.ID] = group
+	}
+
+	if len(existingGroups)+len(newGroups) > MaxGroups {
+		return nil, BundleError(fmt.Sprintf("Importing this bundle would go above the limit of %d custom command groups", MaxGroups))
+	}
+
+	cmds := make([]*models.CustomCommand, len(bundle.Commands))
+	cmdGroups := make(map[*models.CustomCommand]*models.CustomCommandGroup)
+	for i, bc := range bundle.Commands {
+		cmds[i] = bc.toDBModel(gs, isPremium, result)
+		if group, ok := groupMapping[bc.GroupID]; ok && bc.GroupID != 0 {
+			cmdGroups[cmds[i]] = group
+		}
+	}
+
+	newCmds, updatedCmds, err := resolveCommandConflicts(existingCmds, bundle.Commands, cmds, mode, result)
+	if err != nil {
+		return nil, err
+	}
+
+	maxCommands := MaxCommands
+	if isPremium {
+		maxCommands = MaxCommandsPremium
+	}
+
+	if len(existingCmds)+len(newCmds) > maxCommands {
+		return nil, BundleError(fmt.Sprintf("Importing this bundle would go above the limit of %d custom commands (or %d for premium servers)", MaxCommands, MaxCommandsPremium))
+	}
+
+	if countLowIntervalCommands(existingCmds, updatedCmds)+countLowIntervalCommands(append(newCmds, updatedCmds...), nil) > 5 {
+		return nil, BundleError("Importing this bundle would go above the limit of 5 triggers on less than 10 minute intervals")
+	}
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	for _, g := range newGroups {
+		err = g.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.WithStackIf(err)
+		}
+	}
+
+	for _, g := range updatedGroups {
+		_, err = g.Update(ctx, tx, boil.Infer())
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.WithStackIf(err)
+		}
+	}
+
+	for cmd, group := range cmdGroups {
+		cmd.GroupID = null.Int64From(group.ID)
+	}
+
+	for _, cmd := range newCmds {
+		cmd.LocalID, err = common.GenLocalIncrID(gs.ID, "custom_command")
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.WrapIf(err, "error generating local id")
+		}
+
+		err = cmd.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.WithStackIf(err)
+		}
+
+		result.Created = append(result.Created, cmd.LocalID)
+	}
+
+	for _, cmd := range updatedCmds {
+		_, err = cmd.Update(ctx, tx, boil.Blacklist("last_run", "next_run", "local_id", "guild_id", "last_error", "last_error_time", "run_count"))
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.WithStackIf(err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	result.GroupsCreated = len(newGroups)
+	result.GroupsOverwritten = len(updatedGroups)
+
+	for _, cmd := range append(newCmds, updatedCmds...) {
+		if _, err := SaveRevision(ctx, cmd, authorID, authorName); err != nil {
+			logger.WithError(err).WithField("guild", gs.ID).Error("failed saving custom command revision")
+		}
+
+		if err := updateNextRunTime(ctx, cmd); err != nil {
+			logger.WithError(err).WithField("guild", gs.ID).Error("failed updating next custom command run time")
+		}
+	}
+
+	featureflags.MarkGuildDirty(gs.ID)
+	pubsub.EvictCacheSet(cachedCommandsMessage, gs.ID)
+
+	return result, nil
+}
+
+// resolveCommandConflicts decides which of the bundled commands (cmds, converted from bundled) are created and which overwrite existing commands,
+// commands only conflict if they have the same name or text trigger, so commands without either are always created
+func resolveCommandConflicts(existingCmds []*models.CustomCommand, bundled []*BundleCommand, cmds []*models.CustomCommand, mode ImportMode, result *ImportResult) (newCmds, updatedCmds []*models.CustomCommand, err error) {
+	known := append([]*models.CustomCommand{}, existingCmds...)
+	for i, cmd := range cmds {
+		bc := bundled[i]
+
+		if existing := findConflictingCommand(known, cmd); existing != nil {
+			switch mode {
+			case ImportModeSkip:
+				result.Skipped = append(result.Skipped, fmt.Sprintf("#%d (conflicts with #%d)", bc.ID, existing.LocalID))
+				continue
+			case ImportModeOverwrite:
+				if existing.LocalID == 0 || common.ContainsInt64Slice(result.Overwritten, existing.LocalID) {
+					// conflicts with another command in the same bundle
+					result.Skipped = append(result.Skipped, fmt.Sprintf("#%d (duplicate in bundle)", bc.ID))
+					continue
+				}
+
+				cmd.LocalID = existing.LocalID
+				result.Overwritten = append(result.Overwritten, existing.LocalID)
+				updatedCmds = append(updatedCmds, cmd)
+				known = append(known, cmd)
+				continue
+			case ImportModeRename:
+				if err := renameCommand(known, cmd); err != nil {
+					return nil, nil, BundleError(fmt.Sprintf("Command #%d: %s", bc.ID, err.Error()))
+				}
+			}
+		}
+
+		newCmds = append(newCmds, cmd)
+		known = append(known, cmd)
+	}
+
+	return newCmds, updatedCmds, nil
+}
+
+// countLowIntervalCommands counts the interval commands running at least every 10 minutes, excluding the overwritten ones
+func countLowIntervalCommands(cmds []*models.CustomCommand, overwritten []*models.CustomCommand) int {
+	n := 0
+	for _, v := range cmds {
+		if v.TriggerType != int(CommandTriggerInterval) || v.TimeTriggerInterval > 10 {
+			continue
+		}
+
+		isOverwritten := false
+		for _, o := range overwritten {
+			if o.LocalID == v.LocalID {
+				isOverwritten = true
+				break
+			}
+		}
+
+		if !isOverwritten {
+			n++
+		}
+	}
+
+	return n
+}
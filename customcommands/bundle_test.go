@@ -0,0 +1,107 @@
+package customcommands
+
+import (
+	"testing"
+
+	"github.com/cirelion/flint/customcommands/models"
+	"github.com/volatiletech/null"
+)
+
+func TestCommandsConflict(t *testing.T) {
+	cases := []struct {
+		a, b     *models.CustomCommand
+		expected bool
+	}{
+		{&models.CustomCommand{TriggerType: int(CommandTriggerCommand), TextTrigger: "hello"}, &models.CustomCommand{TriggerType: int(CommandTriggerCommand), TextTrigger: "HELLO"}, true},
+		{&models.CustomCommand{TriggerType: int(CommandTriggerCommand), TextTrigger: "hello", TextTriggerCaseSensitive: true}, &models.CustomCommand{TriggerType: int(CommandTriggerCommand), TextTrigger: "HELLO", TextTriggerCaseSensitive: true}, false},
+		{&models.CustomCommand{TriggerType: int(CommandTriggerCommand), TextTrigger: "hello"}, &models.CustomCommand{TriggerType: int(CommandTriggerContains), TextTrigger: "hello"}, false},
+		{&models.CustomCommand{TriggerType: int(CommandTriggerInterval)}, &models.CustomCommand{TriggerType: int(CommandTriggerInterval)}, false},
+		{&models.CustomCommand{TriggerType: int(CommandTriggerInterval), Name: null.StringFrom("Daily")}, &models.CustomCommand{TriggerType: int(CommandTriggerReaction), Name: null.StringFrom("daily")}, true},
+		{&models.CustomCommand{TriggerType: int(CommandTriggerCommand)}, &models.CustomCommand{TriggerType: int(CommandTriggerCommand)}, false},
+		{&models.CustomCommand{TriggerType: int(CommandTriggerCommand), TextTrigger: "hello"}, &models.CustomCommand{TriggerType: int(CommandTriggerCommand)}, false},
+	}
+
+	for i, c := range cases {
+		if got := commandsConflict(c.a, c.b); got != c.expected {
+			t.Errorf("case %d: expected %t, got %t", i, c.expected, got)
+		}
+	}
+}
+
+func TestRenameCommand(t *testing.T) {
+	existing := []*models.CustomCommand{
+		{TriggerType: int(CommandTriggerCommand), TextTrigger: "hello", Name: null.StringFrom("greeting")},
+		{TriggerType: int(CommandTriggerCommand), TextTrigger: "hello2"},
+	}
+
+	cmd := &models.CustomCommand{TriggerType: int(CommandTriggerCommand), TextTrigger: "hello", Name: null.StringFrom("greeting")}
+	if err := renameCommand(existing, cmd); err != nil {
+		t.Fatal("unexpected error renaming: ", err)
+	}
+
+	if cmd.TextTrigger != "hello3" || cmd.Name.String != "greeting (2)" {
+		t.Errorf("unexpected rename result: %q, %q", cmd.TextTrigger, cmd.Name.String)
+	}
+
+	// only the name conflicts, so the trigger should be left as is
+	cmd = &models.CustomCommand{TriggerType: int(CommandTriggerCommand), TextTrigger: "bye", Name: null.StringFrom("Greeting")}
+	if err := renameCommand(existing, cmd); err != nil {
+		t.Fatal("unexpected error renaming: ", err)
+	}
+
+	if cmd.TextTrigger != "bye" || cmd.Name.String != "Greeting (2)" {
+		t.Errorf("unexpected rename result: %q, %q", cmd.TextTrigger, cmd.Name.String)
+	}
+}
+
+func TestResolveCommandConflictsEmpty(t *testing.T) {
+	for _, mode := range []ImportMode{ImportModeRename, ImportModeOverwrite} {
+		existing := []*models.CustomCommand{{LocalID: 1, TriggerType: int(CommandTriggerCommand)}}
+		bundled := []*BundleCommand{
+			{ID: 1, TriggerType: int(CommandTriggerCommand), Responses: []string{"a"}},
+			{ID: 2, TriggerType: int(CommandTriggerCommand), Responses: []string{"b"}},
+		}
+		cmds := []*models.CustomCommand{
+			{TriggerType: int(CommandTriggerCommand), Responses: []string{"a"}},
+			{TriggerType: int(CommandTriggerCommand), Responses: []string{"b"}},
+		}
+
+		result := &ImportResult{}
+		newCmds, updatedCmds, err := resolveCommandConflicts(existing, bundled, cmds, mode, result)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", mode, err)
+		}
+
+		if len(newCmds) != 2 || len(updatedCmds) != 0 || len(result.Skipped) != 0 {
+			t.Errorf("%s: expected 2 new commands, got %d new, %d updated and %d skipped", mode, len(newCmds), len(updatedCmds), len(result.Skipped))
+		}
+
+		for _, v := range newCmds {
+			if v.Name.String != "" || v.TextTrigger != "" {
+				t.Errorf("%s: expected command not to be renamed, got %q, %q", mode, v.Name.String, v.TextTrigger)
+			}
+		}
+	}
+}
+
+func TestBundleValidate(t *testing.T) {
+	valid := &BundleCommand{ID: 1, TriggerType: int(CommandTriggerCommand), TextTrigger: "hello", Responses: []string{"hi"}}
+	if err := (&Bundle{Version: BundleVersion, Commands: []*BundleCommand{valid}}).Validate(); err != nil {
+		t.Error("expected valid bundle, got: ", err)
+	}
+
+	invalid := []*Bundle{
+		{Version: BundleVersion + 1},
+		{Version: BundleVersion, Commands: []*BundleCommand{{ID: 1, TriggerType: int(CommandTriggerCommand), Responses: []string{" "}}}},
+		{Version: BundleVersion, Commands: []*BundleCommand{{ID: 1, TriggerType: int(CommandTriggerCommand), Responses: []string{"{{if}}"}}}},
+		{Version: BundleVersion, Commands: []*BundleCommand{{ID: 1, TriggerType: int(CommandTriggerInterval), TimeTriggerInterval: 1, Responses: []string{"hi"}}}},
+		{Version: BundleVersion, Groups: []*BundleGroup{{ID: 1}}},
+	}
+
+	for i, b := range invalid {
+		err := b.Validate()
+		if _, ok := err.(BundleError); !ok {
+			t.Errorf("case %d: expected bundle error, got: %v", i, err)
+		}
+	}
+}
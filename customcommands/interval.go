@@ -108,6 +108,21 @@ func DelNextRunEvent(guildID int64, cmdID int64) error {
 	return err
 }
 
+// updateNextRunTime creates, updates or removes the next run time and scheduled event of the command after it was saved
+func updateNextRunTime(ctx context.Context, cc *models.CustomCommand) error {
+	if cc.TriggerType != int(CommandTriggerInterval) {
+		return DelNextRunEvent(cc.GuildID, cc.LocalID)
+	}
+
+	// need the last run time
+	fullModel, err := models.CustomCommands(qm.Where("guild_id = ? AND local_id = ?", cc.GuildID, cc.LocalID)).OneG(ctx)
+	if err != nil {
+		return errors.WrapIf(err, "failed retrieving full model")
+	}
+
+	return UpdateCommandNextRunTime(fullModel, true, true)
+}
+
 // TODO: Run this all in a transaction?
 func UpdateCommandNextRunTime(cc *models.CustomCommand, updateLastRun bool, clearOld bool) error {
 	if clearOld {
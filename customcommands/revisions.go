@@ -0,0 +1,168 @@
+package customcommands
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/customcommands/models"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// MaxRevisionsPerCommand is the number of revisions kept for every custom command, older ones are pruned on save
+const MaxRevisionsPerCommand = 50
+
+// SaveRevision stores a snapshot of the custom command as a new revision
+func SaveRevision(ctx context.Context, cc *models.CustomCommand, authorID int64, authorName string) (*models.CustomCommandRevision, error) {
+	data, err := json.Marshal(cc)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	revision := 1
+	last, err := models.CustomCommandRevisions(
+		models.CustomCommandRevisionWhere.GuildID.EQ(cc.GuildID),
+		models.CustomCommandRevisionWhere.LocalID.EQ(cc.LocalID),
+		qm.OrderBy("revision desc")).OneG(ctx)
+	if err == nil {
+		revision = last.Revision + 1
+	} else if errors.Cause(err) != sql.ErrNoRows {
+		return nil, errors.WithStackIf(err)
+	}
+
+	model := &models.CustomCommandRevision{
+		GuildID:    cc.GuildID,
+		LocalID:    cc.LocalID,
+		Revision:   revision,
+		AuthorID:   authorID,
+		AuthorName: authorName,
+		Data:       data,
+	}
+
+	err = model.InsertG(ctx, boil.Infer())
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	if revision > MaxRevisionsPerCommand {
+		_, err = models.CustomCommandRevisions(
+			models.CustomCommandRevisionWhere.GuildID.EQ(cc.GuildID),
+			models.CustomCommandRevisionWhere.LocalID.EQ(cc.LocalID),
+			models.CustomCommandRevisionWhere.Revision.LTE(revision-MaxRevisionsPerCommand)).DeleteAll(ctx, common.PQ)
+		if err != nil {
+			logger.WithError(err).WithField("guild", cc.GuildID).Error("failed pruning old custom command revisions")
+		}
+	}
+
+	return model, nil
+}
+
+// RevisionCommand returns the custom command as it was stored in the revision
+func RevisionCommand(rev *models.CustomCommandRevision) (*models.CustomCommand, error) {
+	var cc models.CustomCommand
+	err := json.Unmarshal(rev.Data, &cc)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	cc.GuildID = rev.GuildID
+	cc.LocalID = rev.LocalID
+	return &cc, nil
+}
+
+// revisionText returns a plain text representation of the user editable parts of the command, used for diffing revisions
+func revisionText(cc *models.CustomCommand) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Name: %s\n", cc.Name.String)
+	fmt.Fprintf(&b, "Trigger type: %s\n", CommandTriggerType(cc.TriggerType))
+	switch CommandTriggerType(cc.TriggerType) {
+	case CommandTriggerInterval:
+		fmt.Fprintf(&b, "Interval: %d minutes\n", cc.TimeTriggerInterval)
+		fmt.Fprintf(&b, "Channel: %d\n", cc.ContextChannel)
+		fmt.Fprintf(&b, "Excluded hours: %v\n", cc.TimeTriggerExcludingHours)
+		fmt.Fprintf(&b, "Excluded days: %v\n", cc.TimeTriggerExcludingDays)
+	case CommandTriggerReaction:
+		fmt.Fprintf(&b, "Reaction mode: %d\n", cc.ReactionTriggerMode)
+	case CommandTriggerNone:
+	default:
+		fmt.Fprintf(&b, "Trigger: %s\n", cc.TextTrigger)
+		fmt.Fprintf(&b, "Case sensitive: %t\n", cc.TextTriggerCaseSensitive)
+		fmt.Fprintf(&b, "Trigger on edits: %t\n", cc.TriggerOnEdit)
+	}
+
+	fmt.Fprintf(&b, "Group: %d\n", cc.GroupID.Int64)
+	fmt.Fprintf(&b, "Disabled: %t\n", cc.Disabled)
+	fmt.Fprintf(&b, "Show errors: %t\n", cc.ShowErrors)
+	fmt.Fprintf(&b, "Roles (whitelist: %t): %v\n", cc.RolesWhitelistMode, cc.Roles)
+	fmt.Fprintf(&b, "Channels (whitelist: %t): %v\n", cc.ChannelsWhitelistMode, cc.Channels)
+
+	for i, v := range cc.Responses {
+		fmt.Fprintf(&b, "\nResponse %d:\n%s\n", i+1, v)
+	}
+
+	return b.String()
+}
+
+// DiffLine is a single line in a diff between two revisions, Op is one of "+", "-" or " "
+type DiffLine struct {
+	Op   string
+	Text string
+}
+
+// diffLines returns a line based diff turning a into b, using the longest common subsequence
+func diffLines(a, b []string) []DiffLine {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	result := make([]DiffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, DiffLine{Op: " ", Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, DiffLine{Op: "-", Text: a[i]})
+			i++
+		default:
+			result = append(result, DiffLine{Op: "+", Text: b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		result = append(result, DiffLine{Op: "-", Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		result = append(result, DiffLine{Op: "+", Text: b[j]})
+	}
+
+	return result
+}
+
+// DiffRevisions returns the diff between the two versions of the command
+func DiffRevisions(from, to *models.CustomCommand) []DiffLine {
+	return diffLines(strings.Split(revisionText(from), "\n"), strings.Split(revisionText(to), "\n"))
+}
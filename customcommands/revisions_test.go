@@ -0,0 +1,32 @@
+package customcommands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected string
+	}{
+		{"a\nb\nc", "a\nb\nc", " a| b| c"},
+		{"a\nb\nc", "a\nc", " a|-b| c"},
+		{"a\nc", "a\nb\nc", " a|+b| c"},
+		{"a\nb", "a\nx", " a|-b|+x"},
+		{"", "a", "-|+a"},
+	}
+
+	for _, c := range cases {
+		lines := diffLines(strings.Split(c.a, "\n"), strings.Split(c.b, "\n"))
+
+		formatted := make([]string, 0, len(lines))
+		for _, v := range lines {
+			formatted = append(formatted, v.Op+v.Text)
+		}
+
+		if got := strings.Join(formatted, "|"); got != c.expected {
+			t.Errorf("diff %q -> %q: expected %q, got %q", c.a, c.b, c.expected, got)
+		}
+	}
+}
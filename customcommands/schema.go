@@ -84,4 +84,20 @@ CREATE INDEX IF NOT EXISTS templates_user_database_combined_idx ON templates_use
 CREATE INDEX IF NOT EXISTS templates_user_database_expires_idx ON templates_user_database (expires_at);
 `, `
 ALTER TABLE custom_commands ADD COLUMN IF NOT EXISTS name TEXT;
+`, `
+CREATE TABLE IF NOT EXISTS custom_command_revisions (
+	id BIGSERIAL PRIMARY KEY,
+
+	guild_id BIGINT NOT NULL,
+	local_id BIGINT NOT NULL,
+	revision INT NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	author_id BIGINT NOT NULL,
+	author_name TEXT NOT NULL,
+
+	data JSONB NOT NULL,
+
+	UNIQUE(guild_id, local_id, revision)
+);
 `}
@@ -0,0 +1,158 @@
+package customcommands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"emperror.dev/errors"
+	"github.com/cirelion/flint/bot"
+	"github.com/cirelion/flint/common/internalapi"
+	"github.com/cirelion/flint/common/templates"
+	"github.com/cirelion/flint/lib/dcmd"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/template"
+	"goji.io"
+	"goji.io/pat"
+)
+
+var _ internalapi.InternalAPIPlugin = (*Plugin)(nil)
+
+// TestRunRequest is sent from the control panel to the bot to dry run a custom command response
+type TestRunRequest struct {
+	CCID      int64  `json:"cc_id"`
+	Trigger   string `json:"trigger"`
+	Response  string `json:"response"`
+	ChannelID int64  `json:"channel_id"`
+	UserID    int64  `json:"user_id"`
+	Args      string `json:"args"`
+}
+
+// TestRunResult is the outcome of a dry run, actions with side effects are recorded instead of performed
+type TestRunResult struct {
+	Output  string                    `json:"output"`
+	Actions []*templates.DryRunAction `json:"actions"`
+
+	Error        string `json:"error,omitempty"`
+	ErrorLine    int64  `json:"error_line,omitempty"`
+	ErrorContext string `json:"error_context,omitempty"`
+}
+
+func (p *Plugin) InitInternalAPIRoutes(mux *goji.Mux) {
+	if !bot.Enabled {
+		return
+	}
+
+	mux.Handle(pat.Post("/:guild/customcommands/testrun"), http.HandlerFunc(botRestHandleTestRun))
+}
+
+func botRestHandleTestRun(w http.ResponseWriter, r *http.Request) {
+	guildID, _ := strconv.ParseInt(pat.Param(r, "guild"), 10, 64)
+
+	var req TestRunRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if internalapi.ServerError(w, r, err) {
+		return
+	}
+
+	result, err := TestRun(guildID, &req)
+	if internalapi.ServerError(w, r, err) {
+		return
+	}
+
+	internalapi.ServeJson(w, r, result)
+}
+
+// TestRun executes the response in a sandboxed context, as if the user ran the command with the provided args
+func TestRun(guildID int64, req *TestRunRequest) (result *TestRunResult, err error) {
+	gs := bot.State.GetGuild(guildID)
+	if gs == nil {
+		return nil, errors.New("unknown server")
+	}
+
+	cs := gs.GetChannel(req.ChannelID)
+	if cs == nil {
+		return nil, errors.New("unknown channel")
+	}
+
+	ms, err := bot.GetMember(guildID, req.UserID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "GetMember")
+	}
+
+	content := strings.TrimSpace(req.Trigger + " " + req.Args)
+	msg := &discordgo.Message{
+		ChannelID: cs.ID,
+		GuildID:   guildID,
+		Content:   content,
+		Author:    &ms.User,
+		Member:    ms.DgoMember(),
+	}
+
+	tmplCtx := templates.NewContext(gs, cs, ms)
+	tmplCtx.DryRun = true
+	tmplCtx.Msg = msg
+	tmplCtx.Name = "CC #" + strconv.Itoa(int(req.CCID))
+
+	args := dcmd.SplitArgs(content)
+	argsStr := make([]string, len(args))
+	for k, v := range args {
+		argsStr[k] = v.Str
+	}
+
+	cmdArgs := []string{}
+	if len(argsStr) > 1 {
+		cmdArgs = argsStr[1:]
+	}
+
+	tmplCtx.Data["Args"] = argsStr
+	tmplCtx.Data["StrippedMsg"] = req.Args
+	tmplCtx.Data["Cmd"] = req.Trigger
+	tmplCtx.Data["CmdArgs"] = cmdArgs
+	tmplCtx.Data["IsMessageEdit"] = false
+	tmplCtx.Data["Message"] = msg
+	tmplCtx.Data["CCID"] = req.CCID
+	tmplCtx.Data["CCRunCount"] = 1
+	tmplCtx.Data["CCTrigger"] = req.Trigger
+
+	result = &TestRunResult{}
+	defer func() {
+		if r := recover(); r != nil {
+			result.Error = fmt.Sprint(r)
+			result.Actions = tmplCtx.DryRunActions
+		}
+	}()
+
+	out, execErr := tmplCtx.Execute(req.Response)
+	result.Output = strings.TrimSpace(out)
+	result.Actions = tmplCtx.DryRunActions
+	if utf8.RuneCountInString(result.Output) > 2000 {
+		result.Error = "Response was longer than 2k characters and would not be sent"
+	}
+
+	if execErr != nil {
+		result.Error = execErr.Error()
+		if eerr, ok := errors.Cause(execErr).(template.ExecError); ok {
+			if data := parseExecError(eerr); data != nil {
+				result.Error = data.Msg
+				result.ErrorLine = data.Line
+
+				lines := strings.Split(req.Response, "\n")
+				if int(data.Line) <= len(lines) {
+					result.ErrorContext = getSurroundingLines(lines, int(data.Line-1))
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func botRestPostTestRun(guildID int64, req *TestRunRequest) (*TestRunResult, error) {
+	var result TestRunResult
+	err := internalapi.PostWithGuild(guildID, strconv.FormatInt(guildID, 10)+"/customcommands/testrun", req, &result)
+	return &result, err
+}
@@ -47,6 +47,9 @@ func init() {
 		ctx.ContextFuncs["dbCount"] = tmplDBCount(ctx)
 		ctx.ContextFuncs["dbRank"] = tmplDBRank(ctx)
 	})
+
+	templates.RegisterSideEffectFuncs("execCC", "scheduleUniqueCC", "cancelScheduledUniqueCC",
+		"dbSet", "dbSetExpire", "dbIncr", "dbDel", "dbDelById", "dbDelByID", "dbDelMultiple")
 }
 
 func tmplCArg(typ string, name string, opts ...interface{}) (*dcmd.ArgDef, error) {
@@ -2,9 +2,12 @@ package customcommands
 
 import (
 	"context"
+	"database/sql"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"html/template"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -35,6 +38,9 @@ var PageHTMLEditCmd string
 //go:embed assets/customcommands.html
 var PageHTMLMain string
 
+//go:embed assets/customcommands-history.html
+var PageHTMLHistory string
+
 // GroupForm is the form bindings used when creating or updating groups
 type GroupForm struct {
 	ID                int64
@@ -50,6 +56,9 @@ var (
 	panelLogKeyNewCommand     = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_new_command", FormatString: "Created a new custom command: %d"})
 	panelLogKeyUpdatedCommand = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_updated_command", FormatString: "Updated custom command: %d"})
 	panelLogKeyRemovedCommand = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_removed_command", FormatString: "Removed custom command: %d"})
+	panelLogKeyNewRevision    = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_new_revision", FormatString: "Updated custom command: %d (revision #%d)"})
+	panelLogKeyRolledBack     = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_rolled_back_command", FormatString: "Rolled back custom command %d to revision #%d"})
+	panelLogKeyImportedBundle = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_imported_bundle", FormatString: "Imported a custom command bundle: %d created, %d overwritten"})
 
 	panelLogKeyNewGroup     = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_new_group", FormatString: "Created a new custom command group: %s"})
 	panelLogKeyUpdatedGroup = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_updated_group", FormatString: "Updated custom command group: %s"})
@@ -60,6 +69,7 @@ var (
 func (p *Plugin) InitWeb() {
 	web.AddHTMLTemplate("customcommands/assets/customcommands.html", PageHTMLMain)
 	web.AddHTMLTemplate("customcommands/assets/customcommands-editcmd.html", PageHTMLEditCmd)
+	web.AddHTMLTemplate("customcommands/assets/customcommands-history.html", PageHTMLHistory)
 	web.AddSidebarItem(web.SidebarCategoryCore, &web.SidebarItem{
 		Name: "Custom commands",
 		URL:  "customcommands",
@@ -69,6 +79,7 @@ func (p *Plugin) InitWeb() {
 	getHandler := web.ControllerHandler(handleCommands, "cp_custom_commands")
 	getCmdHandler := web.ControllerHandler(handleGetCommand, "cp_custom_commands_edit_cmd")
 	getGroupHandler := web.ControllerHandler(handleGetCommandsGroup, "cp_custom_commands")
+	getHistoryHandler := web.ControllerHandler(handleGetCommandHistory, "cp_custom_commands_cmd_history")
 
 	subMux := goji.SubMux()
 	web.CPMux.Handle(pat.New("/customcommands"), subMux)
@@ -93,6 +104,7 @@ func (p *Plugin) InitWeb() {
 	subMux.Handle(pat.Get("/"), getHandler)
 
 	subMux.Handle(pat.Get("/commands/:cmd/"), getCmdHandler)
+	subMux.Handle(pat.Get("/commands/:cmd/history"), getHistoryHandler)
 
 	subMux.Handle(pat.Get("/groups/:group/"), web.ControllerHandler(handleGetCommandsGroup, "cp_custom_commands"))
 	subMux.Handle(pat.Get("/groups/:group"), web.ControllerHandler(handleGetCommandsGroup, "cp_custom_commands"))
@@ -103,6 +115,11 @@ func (p *Plugin) InitWeb() {
 	subMux.Handle(pat.Post("/commands/:cmd/delete"), web.ControllerPostHandler(handleDeleteCommand, getHandler, nil))
 	subMux.Handle(pat.Post("/commands/:cmd/run_now"), web.ControllerPostHandler(handleRunCommandNow, getCmdHandler, nil))
 	subMux.Handle(pat.Post("/commands/:cmd/update_and_run"), web.ControllerPostHandler(handleUpdateAndRunNow, getCmdHandler, CustomCommand{}))
+	subMux.Handle(pat.Post("/commands/:cmd/test_run"), web.APIHandler(handleTestRunCommand))
+	subMux.Handle(pat.Post("/commands/:cmd/revisions/:revision/rollback"), web.ControllerPostHandler(handleRollbackCommand, getHistoryHandler, nil))
+
+	subMux.Handle(pat.Get("/export"), http.HandlerFunc(handleExportBundle))
+	subMux.Handle(pat.Post("/import"), web.ControllerPostHandler(handleImportBundle, getHandler, nil))
 
 	subMux.Handle(pat.Post("/creategroup"), web.ControllerPostHandler(handleNewGroup, getHandler, GroupForm{}))
 	subMux.Handle(pat.Post("/groups/:group/update"), web.ControllerPostHandler(handleUpdateGroup, getGroupHandler, GroupForm{}))
@@ -266,6 +283,10 @@ func handleNewCommand(w http.ResponseWriter, r *http.Request) (web.TemplateData,
 
 	featureflags.MarkGuildDirty(activeGuild.ID)
 
+	if _, err = saveRevisionFromContext(ctx, dbModel); err != nil {
+		web.CtxLogger(ctx).WithError(err).WithField("guild", activeGuild.ID).Error("failed saving custom command revision")
+	}
+
 	http.Redirect(w, r, fmt.Sprintf("/manage/%d/customcommands/commands/%d/", activeGuild.ID, localID), http.StatusSeeOther)
 
 	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyNewCommand, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: dbModel.LocalID}))
@@ -330,27 +351,196 @@ func handleUpdateCommand(w http.ResponseWriter, r *http.Request) (web.TemplateDa
 		return templateData, nil
 	}
 
-	// create, update or remove the next run time and scheduled event
-	if dbModel.TriggerType == int(CommandTriggerInterval) {
-		// need the last run time
-		fullModel, err := models.CustomCommands(qm.Where("guild_id = ? AND local_id = ?", activeGuild.ID, dbModel.LocalID)).OneG(ctx)
+	err = updateNextRunTime(ctx, dbModel)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).WithField("guild", dbModel.GuildID).Error("failed updating next custom command run time")
+	}
+
+	rev, revErr := saveRevisionFromContext(ctx, dbModel)
+	if revErr != nil {
+		web.CtxLogger(ctx).WithError(revErr).WithField("guild", dbModel.GuildID).Error("failed saving custom command revision")
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyUpdatedCommand, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: dbModel.LocalID}))
+	} else {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyNewRevision,
+			&cplogs.Param{Type: cplogs.ParamTypeInt, Value: dbModel.LocalID}, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: int64(rev.Revision)}))
+	}
+
+	pubsub.EvictCacheSet(cachedCommandsMessage, activeGuild.ID)
+	return templateData, err
+}
+
+func saveRevisionFromContext(ctx context.Context, cc *models.CustomCommand) (*models.CustomCommandRevision, error) {
+	user := web.ContextUser(ctx)
+	return SaveRevision(ctx, cc, user.ID, user.Username)
+}
+
+func handleGetCommandHistory(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	ccID, err := strconv.ParseInt(pat.Param(r, "cmd"), 10, 64)
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	cc, err := models.CustomCommands(
+		models.CustomCommandWhere.GuildID.EQ(activeGuild.ID),
+		models.CustomCommandWhere.LocalID.EQ(ccID)).OneG(ctx)
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	revisions, err := models.CustomCommandRevisions(
+		models.CustomCommandRevisionWhere.GuildID.EQ(activeGuild.ID),
+		models.CustomCommandRevisionWhere.LocalID.EQ(ccID),
+		qm.OrderBy("revision desc")).AllG(ctx)
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	templateData["CC"] = cc
+	templateData["Revisions"] = revisions
+
+	if len(revisions) < 1 {
+		return templateData, nil
+	}
+
+	// default to comparing the latest revision against the one before it
+	fromRev, toRev := revisions[0], revisions[0]
+	if len(revisions) > 1 {
+		fromRev = revisions[1]
+	}
+
+	if v, err := strconv.Atoi(r.FormValue("from")); err == nil {
+		fromRev = findRevision(revisions, v, fromRev)
+	}
+	if v, err := strconv.Atoi(r.FormValue("to")); err == nil {
+		toRev = findRevision(revisions, v, toRev)
+	}
+
+	from, err := RevisionCommand(fromRev)
+	if err != nil {
+		return templateData, err
+	}
+
+	to, err := RevisionCommand(toRev)
+	if err != nil {
+		return templateData, err
+	}
+
+	templateData["DiffFrom"] = fromRev.Revision
+	templateData["DiffTo"] = toRev.Revision
+	templateData["Diff"] = DiffRevisions(from, to)
+
+	return templateData, nil
+}
+
+func findRevision(revisions models.CustomCommandRevisionSlice, revision int, fallback *models.CustomCommandRevision) *models.CustomCommandRevision {
+	for _, v := range revisions {
+		if v.Revision == revision {
+			return v
+		}
+	}
+
+	return fallback
+}
+
+func handleRollbackCommand(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	ccID, err := strconv.ParseInt(pat.Param(r, "cmd"), 10, 64)
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	revision, err := strconv.Atoi(pat.Param(r, "revision"))
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	current, err := models.CustomCommands(
+		models.CustomCommandWhere.GuildID.EQ(activeGuild.ID),
+		models.CustomCommandWhere.LocalID.EQ(ccID)).OneG(ctx)
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	rev, err := models.CustomCommandRevisions(
+		models.CustomCommandRevisionWhere.GuildID.EQ(activeGuild.ID),
+		models.CustomCommandRevisionWhere.LocalID.EQ(ccID),
+		models.CustomCommandRevisionWhere.Revision.EQ(revision)).OneG(ctx)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return templateData.AddAlerts(web.ErrorAlert("Unknown revision")), nil
+		}
+
+		return templateData, errors.WithStackIf(err)
+	}
+
+	dbModel, err := RevisionCommand(rev)
+	if err != nil {
+		return templateData, err
+	}
+
+	// the limits may have changed since the revision was saved
+	if err := validateRestoredCommand(dbModel); err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("This revision can't be restored: " + err.Error())), nil
+	}
+
+	// the group may have been deleted since
+	if dbModel.GroupID.Valid {
+		c, err := models.CustomCommandGroups(qm.Where("guild_id = ? AND id = ?", activeGuild.ID, dbModel.GroupID.Int64)).CountG(ctx)
 		if err != nil {
-			web.CtxLogger(ctx).WithError(err).Error("failed retrieving full model")
-		} else {
-			err = UpdateCommandNextRunTime(fullModel, true, true)
+			return templateData, err
 		}
-	} else {
-		err = DelNextRunEvent(activeGuild.ID, dbModel.LocalID)
+
+		if c < 1 {
+			dbModel.GroupID = null.Int64{}
+		}
+	}
+
+	if current.Disabled && !dbModel.Disabled {
+		c, err := models.CustomCommands(qm.Where("guild_id = ? and disabled = false", activeGuild.ID)).CountG(ctx)
+		if err != nil {
+			return templateData, err
+		}
+		if int(c) >= MaxCommandsForContext(ctx) {
+			return templateData, web.NewPublicError(fmt.Sprintf("Max %d enabled custom commands allowed (or %d for premium servers)", MaxCommands, MaxCommandsPremium))
+		}
+	}
+
+	if !premium.ContextPremium(ctx) && dbModel.TriggerOnEdit {
+		return templateData.AddAlerts(web.ErrorAlert("`Trigger on edits` is a premium feature, this revision can't be restored without premium")), nil
+	}
+
+	if dbModel.TriggerType == int(CommandTriggerInterval) && dbModel.TimeTriggerInterval <= 10 {
+		ok, err := checkIntervalLimits(ctx, activeGuild.ID, dbModel.LocalID, templateData)
+		if err != nil || !ok {
+			return templateData, err
+		}
+	}
+
+	_, err = dbModel.UpdateG(ctx, boil.Blacklist("last_run", "next_run", "local_id", "guild_id", "last_error", "last_error_time", "run_count"))
+	if err != nil {
+		return templateData, err
 	}
 
+	err = updateNextRunTime(ctx, dbModel)
 	if err != nil {
 		web.CtxLogger(ctx).WithError(err).WithField("guild", dbModel.GuildID).Error("failed updating next custom command run time")
 	}
 
-	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyUpdatedCommand, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: dbModel.LocalID}))
+	if _, err = saveRevisionFromContext(ctx, dbModel); err != nil {
+		web.CtxLogger(ctx).WithError(err).WithField("guild", dbModel.GuildID).Error("failed saving custom command revision")
+	}
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyRolledBack,
+		&cplogs.Param{Type: cplogs.ParamTypeInt, Value: dbModel.LocalID}, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: int64(rev.Revision)}))
 
+	featureflags.MarkGuildDirty(activeGuild.ID)
 	pubsub.EvictCacheSet(cachedCommandsMessage, activeGuild.ID)
-	return templateData, err
+	return templateData.AddAlerts(web.SucessAlert(fmt.Sprintf("Rolled back to revision #%d", rev.Revision))), nil
 }
 
 func handleDeleteCommand(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
@@ -379,6 +569,13 @@ func handleDeleteCommand(w http.ResponseWriter, r *http.Request) (web.TemplateDa
 
 	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyRemovedCommand, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: cmd.LocalID}))
 
+	_, err = models.CustomCommandRevisions(
+		models.CustomCommandRevisionWhere.GuildID.EQ(activeGuild.ID),
+		models.CustomCommandRevisionWhere.LocalID.EQ(cmd.LocalID)).DeleteAll(ctx, common.PQ)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).WithField("guild", activeGuild.ID).Error("failed removing custom command revisions")
+	}
+
 	err = DelNextRunEvent(cmd.GuildID, cmd.LocalID)
 	featureflags.MarkGuildDirty(activeGuild.ID)
 	pubsub.EvictCacheSet(cachedCommandsMessage, activeGuild.ID)
@@ -449,6 +646,188 @@ func handleUpdateAndRunNow(w http.ResponseWriter, r *http.Request) (web.Template
 	return handleRunCommandNow(w, r)
 }
 
+func handleTestRunCommand(w http.ResponseWriter, r *http.Request) interface{} {
+	ctx := r.Context()
+	activeGuild, _ := web.GetBaseCPContextData(ctx)
+	member := web.ContextMember(ctx)
+	if member == nil {
+		return web.NewPublicError("Couldn't find you on the server")
+	}
+
+	cmdID, err := strconv.ParseInt(pat.Param(r, "cmd"), 10, 64)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := models.CustomCommands(qm.Where("guild_id = ? AND local_id = ?", activeGuild.ID, cmdID)).OneG(ctx)
+	if err != nil {
+		return err
+	}
+
+	response := r.FormValue("response")
+	if utf8.RuneCountInString(response) > 10000 {
+		return web.NewPublicError("Response is too long, max 10000 characters")
+	}
+
+	channelID, _ := strconv.ParseInt(r.FormValue("channel"), 10, 64)
+	if channelID == 0 {
+		return web.NewPublicError("No channel selected")
+	}
+
+	ok, err := checkSetCooldown(activeGuild.ID, member.User.ID)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return web.NewPublicError("You're on cooldown, wait before trying again")
+	}
+
+	result, err := botRestPostTestRun(activeGuild.ID, &TestRunRequest{
+		CCID:      cmd.LocalID,
+		Trigger:   cmd.TextTrigger,
+		Response:  response,
+		ChannelID: channelID,
+		UserID:    member.User.ID,
+		Args:      r.FormValue("args"),
+	})
+	if err != nil {
+		return web.NewPublicError("Failed running the command: " + err.Error())
+	}
+
+	return result
+}
+
+func handleExportBundle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	activeGuild := web.ContextGuild(ctx)
+
+	r.ParseForm()
+	ccIDs := parseIDList(r.Form["cc"])
+	groupIDs := parseIDList(r.Form["group"])
+
+	bundle, err := ExportBundle(ctx, activeGuild.ID, ccIDs, groupIDs)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed exporting custom commands")
+		http.Error(w, "Failed exporting custom commands", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="custom-commands-%d.json"`, activeGuild.ID))
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(bundle)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed writing custom commands export")
+	}
+}
+
+func parseIDList(in []string) []int64 {
+	result := make([]int64, 0, len(in))
+	for _, v := range in {
+		for _, s := range strings.Split(v, ",") {
+			parsed, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			if err == nil {
+				result = append(result, parsed)
+			}
+		}
+	}
+
+	return result
+}
+
+func handleImportBundle(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	mode, ok := ParseImportMode(r.FormValue("mode"))
+	if !ok {
+		return templateData.AddAlerts(web.ErrorAlert("Unknown import mode")), nil
+	}
+
+	f, _, err := r.FormFile("bundle")
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("No bundle file provided")), nil
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, MaxBundleSize+1))
+	if err != nil {
+		return templateData, err
+	}
+
+	if len(data) > MaxBundleSize {
+		return templateData.AddAlerts(web.ErrorAlert("Bundle is too big, max 1MB")), nil
+	}
+
+	var bundle Bundle
+	err = json.Unmarshal(data, &bundle)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Failed parsing bundle: ", err.Error())), nil
+	}
+
+	user := web.ContextUser(ctx)
+	result, err := ImportBundle(ctx, activeGuild, &bundle, mode, premium.ContextPremium(ctx), user.ID, user.Username)
+	if err != nil {
+		if bErr, ok := errors.Cause(err).(BundleError); ok {
+			return templateData.AddAlerts(web.ErrorAlert(bErr.Error())), nil
+		}
+
+		return templateData, err
+	}
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, panelLogKeyImportedBundle,
+		&cplogs.Param{Type: cplogs.ParamTypeInt, Value: int64(len(result.Created))}, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: int64(len(result.Overwritten))}))
+
+	templateData.AddAlerts(web.SucessAlert(result.String()))
+	return templateData, nil
+}
+
+// validateRestoredCommand runs the same checks on a restored command as CustomCommand.Validate and the form validation does when saving
+func validateRestoredCommand(cc *models.CustomCommand) error {
+	if utf8.RuneCountInString(cc.Name.String) > 100 {
+		return errors.New("name can be max 100 characters")
+	}
+
+	if utf8.RuneCountInString(cc.TextTrigger) > 1000 {
+		return errors.New("trigger can be max 1000 characters")
+	}
+
+	if len(cc.Responses) > MaxUserMessages {
+		return errors.Errorf("too many responses, max %d", MaxUserMessages)
+	}
+
+	foundOkayResponse := false
+	combinedSize := 0
+	for i, v := range cc.Responses {
+		if strings.TrimSpace(v) != "" {
+			foundOkayResponse = true
+		}
+		combinedSize += utf8.RuneCountInString(v)
+
+		if err := web.ValidateTemplateField(v, 10000); err != nil {
+			return errors.Errorf("response #%d: %s", i+1, err.Error())
+		}
+	}
+
+	if !foundOkayResponse {
+		return errors.New("no response set")
+	}
+
+	if combinedSize > 10000 {
+		return errors.New("max combined command size can be 10k")
+	}
+
+	if CommandTriggerType(cc.TriggerType) == CommandTriggerInterval &&
+		(cc.TimeTriggerInterval < MinIntervalTriggerDurationMinutes || cc.TimeTriggerInterval > MaxIntervalTriggerDurationMinutes) {
+		return errors.Errorf("interval has to be between %d and %d minutes", MinIntervalTriggerDurationMinutes, MaxIntervalTriggerDurationMinutes)
+	}
+
+	return nil
+}
+
 // allow for max 5 triggers with intervals of less than 10 minutes
 func checkIntervalLimits(ctx context.Context, guildID int64, cmdID int64, templateData web.TemplateData) (ok bool, err error) {
 	num, err := models.CustomCommands(qm.Where("guild_id = ? AND local_id != ? AND trigger_type = 5 AND time_trigger_interval <= 10", guildID, cmdID)).CountG(ctx)
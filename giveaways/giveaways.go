@@ -9,6 +9,7 @@ import (
 	"github.com/cirelion/flint/lib/dcmd"
 	"github.com/cirelion/flint/lib/discordgo"
 	"github.com/cirelion/flint/lib/dstate"
+	"github.com/cirelion/flint/timezonecompanion"
 	"math/rand"
 	"time"
 )
@@ -27,7 +28,7 @@ var (
 		IsResponseEphemeral:       true,
 		Arguments: []*dcmd.ArgDef{
 			{Name: "Prize", Help: "The prize of the giveaway", Type: dcmd.String},
-			{Name: "Duration", Help: "The duration of the giveaway", Type: &commands.DurationArg{}},
+			{Name: "Duration", Help: "The duration of the giveaway, or when it ends (e.g. `next friday 19:00`)", Type: &timezonecompanion.TimeArg{}},
 		},
 		ArgSwitches: []*dcmd.ArgDef{
 			{Name: "MaxWinners", Help: "Maximum amount of winners", Type: dcmd.Int, Default: 1},
@@ -12,7 +12,11 @@ import (
 var Packs = make(map[string]*CardPack)
 
 func AddPack(pack *CardPack) {
-	// Count picks
+	countPicks(pack)
+	Packs[pack.Name] = pack
+}
+
+func countPicks(pack *CardPack) {
 	for _, v := range pack.Prompts {
 		numPicks := strings.Count(v.Prompt, "%s")
 		if numPicks == 0 {
@@ -22,8 +26,6 @@ func AddPack(pack *CardPack) {
 			v.NumPick = numPicks
 		}
 	}
-
-	Packs[pack.Name] = pack
 }
 
 type CardPack struct {
@@ -67,13 +67,17 @@ var CreateGameCommand = &dcmd.SimpleCmd{
 	},
 	CmdSwitches: []*dcmd.ArgDef{
 		{Name: "v", Help: "Vote mode, no cardczar"},
+		{Name: "e", Help: "Ephemeral hands, cards are only shown in the channel instead of in dm's"},
 	},
 	RunFunc: func(data *dcmd.Data) (interface{}, error) {
-		voteMode := data.Switch("v").Bool()
+		options := cardsagainstdiscord.GameOptions{
+			VoteMode:       data.Switch("v").Bool(),
+			EphemeralHands: data.Switch("e").Bool(),
+		}
 		pStr := data.Args[0].Str()
 		packs := strings.Fields(pStr)
 
-		_, err := cahManager.CreateGame(data.GuildData.GS.ID, data.GuildData.CS.ID, data.Author.ID, data.Author.Username, voteMode, packs...)
+		_, err := cahManager.CreateGame(data.GuildData.GS.ID, data.GuildData.CS.ID, data.Author.ID, data.Author.Username, options, packs...)
 		if err == nil {
 			log.Println("Created a new game in ", data.GuildData.CS.ID)
 			return "", nil
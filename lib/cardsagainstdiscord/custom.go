@@ -0,0 +1,118 @@
+package cardsagainstdiscord
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	MaxCustomPackPrompts   = 500
+	MaxCustomPackResponses = 2000
+	MaxCustomCardLength    = 200
+	MaxCustomPromptPicks   = 3
+)
+
+var (
+	customPackNameRegex = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+	customBlankRegex    = regexp.MustCompile(`_+`)
+)
+
+// CustomPackProvider provides the custom packs made by a server, they can be used in addition to the built in packs
+type CustomPackProvider interface {
+	CustomPacksForGuild(guildID int64) ([]*CardPack, error)
+}
+
+// CustomPackData is the json format of custom packs, blanks in prompts are marked with one or more underscores
+// and a response of %blank is a blank card players write their own response on
+type CustomPackData struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Prompts     []string `json:"prompts"`
+	Responses   []string `json:"responses"`
+}
+
+// ValidateCustomPackName returns an error if the name can't be used for a custom pack
+func ValidateCustomPackName(name string) error {
+	if !customPackNameRegex.MatchString(name) {
+		return errors.New("Pack names can only contain lowercase letters, numbers, - and _, and can be max 32 characters long")
+	}
+
+	if _, ok := Packs[name]; ok {
+		return fmt.Errorf("`%s` is the name of a built in pack", name)
+	}
+
+	return nil
+}
+
+// NewCustomPack validates the data and converts it to a pack that can be used in games
+func NewCustomPack(data *CustomPackData) (*CardPack, error) {
+	if err := ValidateCustomPackName(data.Name); err != nil {
+		return nil, err
+	}
+
+	if len(data.Prompts) > MaxCustomPackPrompts {
+		return nil, fmt.Errorf("Max %d prompts per pack", MaxCustomPackPrompts)
+	}
+
+	if len(data.Responses) > MaxCustomPackResponses {
+		return nil, fmt.Errorf("Max %d responses per pack", MaxCustomPackResponses)
+	}
+
+	if len(data.Responses) < 1 {
+		return nil, errors.New("A pack needs at least 1 response")
+	}
+
+	pack := &CardPack{
+		Name:        data.Name,
+		Description: data.Description,
+		Prompts:     make([]*PromptCard, 0, len(data.Prompts)),
+		Responses:   make([]ResponseCard, 0, len(data.Responses)),
+	}
+
+	for i, v := range data.Prompts {
+		v = strings.TrimSpace(v)
+		if err := validateCustomCard(v); err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("Prompt #%d", i+1))
+		}
+
+		if strings.Contains(v, "%s") {
+			return nil, fmt.Errorf("Prompt #%d: mark blanks with _ instead of %%s", i+1)
+		}
+
+		prompt := strings.Replace(v, "%", "%%", -1)
+		prompt = customBlankRegex.ReplaceAllString(prompt, "%s")
+		if strings.Count(prompt, "%s") > MaxCustomPromptPicks {
+			return nil, fmt.Errorf("Prompt #%d: max %d blanks per prompt", i+1, MaxCustomPromptPicks)
+		}
+
+		pack.Prompts = append(pack.Prompts, &PromptCard{Prompt: FilterEveryoneMentions(prompt)})
+	}
+
+	for i, v := range data.Responses {
+		v = strings.TrimSpace(v)
+		if err := validateCustomCard(v); err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("Response #%d", i+1))
+		}
+
+		pack.Responses = append(pack.Responses, ResponseCard(FilterEveryoneMentions(v)))
+	}
+
+	countPicks(pack)
+	return pack, nil
+}
+
+func validateCustomCard(card string) error {
+	if card == "" {
+		return errors.New("empty card")
+	}
+
+	if utf8.RuneCountInString(card) > MaxCustomCardLength {
+		return fmt.Errorf("cards can be max %d characters long", MaxCustomCardLength)
+	}
+
+	return nil
+}
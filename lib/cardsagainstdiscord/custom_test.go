@@ -0,0 +1,49 @@
+package cardsagainstdiscord
+
+import (
+	"testing"
+)
+
+func TestNewCustomPack(t *testing.T) {
+	pack, err := NewCustomPack(&CustomPackData{
+		Name:      "mydeck",
+		Prompts:   []string{"_ + _ = 100% ___", "Why am I sticky?"},
+		Responses: []string{" Cats ", "%blank"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pack.Prompts[0].Prompt != "%s + %s = 100%% %s" || pack.Prompts[0].NumPick != 3 {
+		t.Errorf("unexpected first prompt: %q (%d picks)", pack.Prompts[0].Prompt, pack.Prompts[0].NumPick)
+	}
+
+	if pack.Prompts[1].Prompt != "Why am I sticky? %s" || pack.Prompts[1].NumPick != 1 {
+		t.Errorf("unexpected second prompt: %q (%d picks)", pack.Prompts[1].Prompt, pack.Prompts[1].NumPick)
+	}
+
+	if got := pack.Prompts[0].WithCards([]string{"a", "b", "c"}); got != "**a** + **b** = 100% **c**" {
+		t.Errorf("unexpected filled prompt: %q", got)
+	}
+
+	if pack.Responses[0] != "Cats" {
+		t.Errorf("response not trimmed: %q", pack.Responses[0])
+	}
+}
+
+func TestNewCustomPackInvalid(t *testing.T) {
+	cases := []*CustomPackData{
+		{Name: "Bad Name", Responses: []string{"a"}},
+		{Name: "main", Responses: []string{"a"}},
+		{Name: "deck"},
+		{Name: "deck", Prompts: []string{"%s is not a blank"}, Responses: []string{"a"}},
+		{Name: "deck", Prompts: []string{"_ _ _ _"}, Responses: []string{"a"}},
+		{Name: "deck", Responses: []string{"  "}},
+	}
+
+	for i, v := range cases {
+		if _, err := NewCustomPack(v); err == nil {
+			t.Errorf("case %d: expected an error", i)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"runtime/debug"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -67,12 +68,15 @@ var (
 	JoinEmoji      = "➕"
 	LeaveEmoji     = "➖"
 	PlayPauseEmoji = "⏯"
+	HandEmoji      = "🃏"
 
 	CahGameJoined    = "cah_game_joined"
 	CahGameLeft      = "cah_game_left"
 	CahGamePlayPause = "cah_game_play"
+	CahGameShowHand  = "cah_game_hand"
 
 	CahCardSelectMenu = "cah_card_select"
+	CahHandSelectMenu = "cah_hand_select"
 	CahBlankCardModal = "cah_blank_card"
 	CahTextInput      = "cah_text_input"
 )
@@ -101,6 +105,12 @@ type Game struct {
 	availablePrompts   []*PromptCard
 	availableResponses []ResponseCard
 
+	// Custom packs used in this game, they're not in the global pack list so they're kept here
+	CustomPacks []*CardPack
+
+	// Hands are only shown in ephemeral messages in the game channel instead of in dm's
+	EphemeralHands bool
+
 	Players []*Player
 
 	State        GameState
@@ -130,20 +140,29 @@ func GetCommonCahButtons() []discordgo.MessageComponent {
 		discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
 				discordgo.Button{
+					Label:    "Join",
 					Emoji:    discordgo.ComponentEmoji{Name: JoinEmoji},
 					Style:    discordgo.SuccessButton,
 					CustomID: CahGameJoined,
 				},
 				discordgo.Button{
+					Label:    "Leave",
 					Emoji:    discordgo.ComponentEmoji{Name: LeaveEmoji},
 					Style:    discordgo.DangerButton,
 					CustomID: CahGameLeft,
 				},
 				discordgo.Button{
+					Label:    "Start/Pause",
 					Emoji:    discordgo.ComponentEmoji{Name: PlayPauseEmoji},
 					Style:    discordgo.PrimaryButton,
 					CustomID: CahGamePlayPause,
 				},
+				discordgo.Button{
+					Label:    "Hand",
+					Emoji:    discordgo.ComponentEmoji{Name: HandEmoji},
+					Style:    discordgo.SecondaryButton,
+					CustomID: CahGameShowHand,
+				},
 			},
 		},
 	}
@@ -154,7 +173,7 @@ func (g *Game) Created() error {
 
 	embed := &discordgo.MessageEmbed{
 		Title:       "Game created!",
-		Description: fmt.Sprintf("React with %s to join and %s to leave, the game master can start/stop the game with %s", JoinEmoji, LeaveEmoji, PlayPauseEmoji),
+		Description: fmt.Sprintf("Press %s to join and %s to leave, the game master can start/stop the game with %s and everyone can see their cards with %s", JoinEmoji, LeaveEmoji, PlayPauseEmoji, HandEmoji),
 	}
 
 	msg, err := g.Session.ChannelMessageSendComplex(g.MasterChannel, &discordgo.MessageSend{
@@ -178,16 +197,28 @@ func (g *Game) Created() error {
 	return nil
 }
 
+func (g *Game) findPack(name string) *CardPack {
+	for _, v := range g.CustomPacks {
+		if v.Name == name {
+			return v
+		}
+	}
+
+	return Packs[name]
+}
+
 func (g *Game) loadPackResponses() {
 	for _, v := range g.Packs {
-		pack := Packs[v]
-		g.availableResponses = append(g.availableResponses, pack.Responses...)
+		if pack := g.findPack(v); pack != nil {
+			g.availableResponses = append(g.availableResponses, pack.Responses...)
+		}
 	}
 }
 func (g *Game) loadPackPrompts() {
 	for _, v := range g.Packs {
-		pack := Packs[v]
-		g.availablePrompts = append(g.availablePrompts, pack.Prompts...)
+		if pack := g.findPack(v); pack != nil {
+			g.availablePrompts = append(g.availablePrompts, pack.Prompts...)
+		}
 	}
 }
 
@@ -459,7 +490,9 @@ func (g *Game) Tick() {
 				allPlayersDone = false
 				if !v.sent15sWarning && time.Since(g.StateEntered) > (PickResponseDuration-(time.Second*15)) {
 					v.sent15sWarning = true
-					go g.Session.ChannelMessageSendEmbed(v.Channel, &discordgo.MessageEmbed{Description: "You have 15 seconds left"})
+					if !g.EphemeralHands {
+						go g.Session.ChannelMessageSendEmbed(v.Channel, &discordgo.MessageEmbed{Description: "You have 15 seconds left"})
+					}
 				}
 			} else {
 				oneResponsePicked = true
@@ -632,7 +665,7 @@ func (g *Game) presentStartRound() {
 
 	for _, player := range g.Players {
 		go func(p *Player) {
-			if !p.PlayingThisRound() {
+			if !p.PlayingThisRound() || g.EphemeralHands {
 				return
 			}
 
@@ -640,7 +673,10 @@ func (g *Game) presentStartRound() {
 		}(player)
 	}
 
-	instructions := fmt.Sprintf("Players: Check your dm for your cards and make your selections there, then return here, you have %d seconds", int(PickResponseDuration.Seconds()))
+	instructions := fmt.Sprintf("Players: Check your dm for your cards and make your selections there, or press %s to pick them here, you have %d seconds", HandEmoji, int(PickResponseDuration.Seconds()))
+	if g.EphemeralHands {
+		instructions = fmt.Sprintf("Players: Press %s to see your cards and make your selections, you have %d seconds", HandEmoji, int(PickResponseDuration.Seconds()))
+	}
 	if g.VoteMode {
 		instructions += "\nAfter that you will all vote on the response"
 	} else {
@@ -691,7 +727,9 @@ func (g *Game) donePickingResponses() {
 		}
 
 		if len(v.SelectedCards) < g.CurrentPropmpt.NumPick {
-			go g.Session.ChannelMessageSend(v.Channel, fmt.Sprintf("You didn't respond in time... winner is being picked in <#%d>", g.MasterChannel))
+			if !g.EphemeralHands {
+				go g.Session.ChannelMessageSend(v.Channel, fmt.Sprintf("You didn't respond in time... winner is being picked in <#%d>", g.MasterChannel))
+			}
 			v.SelectedCards = nil
 			continue
 		}
@@ -820,26 +858,8 @@ func (g *Game) presentPickedResponseCards(edit bool) {
 				},
 			},
 		},
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.Button{
-					Emoji:    discordgo.ComponentEmoji{Name: JoinEmoji},
-					Style:    discordgo.SuccessButton,
-					CustomID: CahGameJoined,
-				},
-				discordgo.Button{
-					Emoji:    discordgo.ComponentEmoji{Name: LeaveEmoji},
-					Style:    discordgo.DangerButton,
-					CustomID: CahGameLeft,
-				},
-				discordgo.Button{
-					Emoji:    discordgo.ComponentEmoji{Name: PlayPauseEmoji},
-					Style:    discordgo.PrimaryButton,
-					CustomID: CahGamePlayPause,
-				},
-			},
-		},
 	}
+	voteOptionsComponent = append(voteOptionsComponent, GetCommonCahButtons()...)
 
 	if edit {
 		g.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
@@ -899,34 +919,61 @@ func (g *Game) removeOldInteractions(cID, mID int64) {
 	})
 }
 
+func interactionUser(ic *discordgo.InteractionCreate) *discordgo.User {
+	if ic.Member != nil {
+		return ic.Member.User
+	}
+
+	return ic.User
+}
+
+func (g *Game) ackInteraction(ic *discordgo.InteractionCreate) {
+	err := g.Session.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+	if err != nil {
+		logrus.WithError(err).Error("Failed Creating CAH Response")
+	}
+}
+
 func (g *Game) HandleInteractionAdd(ic *discordgo.InteractionCreate) {
+	if ic.Type != discordgo.InteractionMessageComponent {
+		return
+	}
+
+	customID := ic.MessageComponentData().CustomID
+	if !strings.HasPrefix(customID, "cah_") {
+		// not ours
+		return
+	}
+
 	g.Lock()
 	defer g.Unlock()
 
-	if g.State != GameStatePickingResponses {
-		// Pong the interaction
-		err := g.Session.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseDeferredMessageUpdate,
-		})
-		if err != nil {
-			logrus.WithError(err).Error("Failed Creating CAH Response")
-			return
-		}
+	user := interactionUser(ic)
+	player := g.findPlayer(user.ID)
+
+	// Hands are shown in ephemeral messages which are responded to separately
+	switch {
+	case customID == CahGameShowHand:
+		g.showHand(player, ic)
+		return
+	case strings.HasPrefix(customID, CahHandSelectMenu):
+		g.handSelection(player, customID, ic)
+		return
+	}
+
+	if g.State != GameStatePickingResponses || customID != CahCardSelectMenu {
+		// Pong the interaction, card selections are responded to when they're handled
+		g.ackInteraction(ic)
 	}
 
 	if g.State == GameStateEnded {
 		return
 	}
 
-	var user *discordgo.User
-	if ic.Member != nil {
-		user = ic.Member.User
-	} else {
-		user = ic.User
-	}
-	player := g.findPlayer(user.ID)
 	if ic.Message.ID == g.LastMenuMessage {
-		switch ic.MessageComponentData().CustomID {
+		switch customID {
 		case CahGameJoined:
 			if player != nil && player.InGame {
 				return
@@ -981,15 +1028,20 @@ func (g *Game) HandleInteractionAdd(ic *discordgo.InteractionCreate) {
 
 	switch g.State {
 	case GameStatePickingResponses:
-		if ic.Message.ID != player.LastReactionMenu || ic.MessageComponentData().CustomID != CahCardSelectMenu {
+		if customID != CahCardSelectMenu {
+			return
+		}
+
+		if ic.Message.ID != player.LastReactionMenu {
+			g.ackInteraction(ic)
 			return
 		}
 		response := ic.MessageComponentData().Values[0]
 
 		g.LastAction = time.Now()
-		g.playerPickedResponseReaction(player, response, ic)
+		g.playerPickedResponseReaction(player, response, ic, false)
 	case GameStatePickingWinner:
-		if ic.Message.ID != g.LastMenuMessage || (player.ID != g.CurrentCardCzar && !g.VoteMode) || ic.MessageComponentData().CustomID != CahCardSelectMenu {
+		if ic.Message.ID != g.LastMenuMessage || (player.ID != g.CurrentCardCzar && !g.VoteMode) || customID != CahCardSelectMenu {
 			return
 		}
 
@@ -1045,21 +1097,14 @@ func (g *Game) HandleMessageCreate(ic *discordgo.InteractionCreate) {
 	g.Lock()
 	defer g.Unlock()
 
-	if ic.User == nil {
-		// Only check dm messages
+	user := interactionUser(ic)
+	if user == nil {
 		return
 	}
 
 	cardResponse := ic.ModalSubmitData().Components[0].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
 
-	var player *Player
-	for _, v := range g.Players {
-		if v.ID == ic.User.ID {
-			player = v
-			break
-		}
-	}
-
+	player := g.findPlayer(user.ID)
 	if player == nil || !player.FilingBlankCard {
 		return
 	}
@@ -1076,11 +1121,17 @@ func (g *Game) HandleMessageCreate(ic *discordgo.InteractionCreate) {
 				msg += fmt.Sprintf("go to <#%d> and wait for the other players to finish their selections, the winner will be picked there", g.MasterChannel)
 			}
 
+			data := &discordgo.InteractionResponseData{
+				Embeds: []*discordgo.MessageEmbed{{Description: msg}},
+			}
+			if ic.GuildID != 0 {
+				// filled in from an ephemeral hand
+				data.Flags = uint64(discordgo.MessageFlagsEphemeral)
+			}
+
 			err := g.Session.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{
-					Embeds: []*discordgo.MessageEmbed{{Description: msg}},
-				},
+				Data: data,
 			})
 			if err != nil {
 				return
@@ -1216,11 +1267,7 @@ func (g *Game) presentWinners(winningPicks []*PickedResonse) {
 	}
 }
 
-func (g *Game) playerPickedResponseReaction(player *Player, response string, ic *discordgo.InteractionCreate) {
-	if len(player.SelectedCards) >= g.CurrentPropmpt.NumPick {
-		return
-	}
-
+func (g *Game) playerPickedResponseReaction(player *Player, response string, ic *discordgo.InteractionCreate, ephemeral bool) {
 	emojiIndex := -1
 	for i, v := range CardSelectionEmojis {
 		if v == response {
@@ -1229,33 +1276,13 @@ func (g *Game) playerPickedResponseReaction(player *Player, response string, ic
 		}
 	}
 
-	if emojiIndex < 0 {
-		// Unknown reaction
+	// Either picked too many cards, an unknown card, or a card that was already selected
+	if len(player.SelectedCards) >= g.CurrentPropmpt.NumPick || emojiIndex < 0 || emojiIndex >= len(player.Cards) || player.hasSelected(emojiIndex) {
+		g.ackInteraction(ic)
 		return
 	}
 
-	if emojiIndex >= len(player.Cards) {
-		// Somehow picked a reaction that they cant (probably added the reaction themselv to mess with the bot)
-		return
-	}
-
-	for _, selection := range player.SelectedCards {
-		if selection == emojiIndex {
-			// Already selected this card
-			return
-		}
-	}
-
 	card := player.Cards[emojiIndex]
-	if card != BlankCard {
-		// Pong the interaction
-		err := g.Session.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseDeferredMessageUpdate,
-		})
-		if err != nil {
-			return
-		}
-	}
 
 	respMsg := ""
 	var showTextModal bool
@@ -1302,9 +1329,104 @@ func (g *Game) playerPickedResponseReaction(player *Player, response string, ic
 		})
 		return
 	}
+
+	if ephemeral {
+		// Update the hand in place
+		embed := player.handEmbed(g.CurrentPropmpt)
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Selection", Value: respMsg})
+		err := g.Session.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{embed},
+				Components: player.handComponents(g.CurrentPropmpt, g.handMenuID()),
+			},
+		})
+		if err != nil {
+			logrus.WithError(err).Error("Failed Creating CAH Response")
+		}
+		return
+	}
+
+	g.ackInteraction(ic)
 	go g.Session.ChannelMessageSendEmbed(player.Channel, &discordgo.MessageEmbed{Description: respMsg})
 }
 
+// handMenuID returns the custom id of the ephemeral hand select menus of the current round,
+// so that selections from hands shown in earlier rounds can be told apart
+func (g *Game) handMenuID() string {
+	return CahHandSelectMenu + ":" + strconv.FormatInt(g.StateEntered.Unix(), 10)
+}
+
+func (g *Game) canPickCards(player *Player) bool {
+	if g.State != GameStatePickingResponses || player == nil || !player.PlayingThisRound() {
+		return false
+	}
+
+	return g.VoteMode || player.ID != g.CurrentCardCzar
+}
+
+func (g *Game) respondEphemeral(ic *discordgo.InteractionCreate, embed *discordgo.MessageEmbed, components []discordgo.MessageComponent) {
+	err := g.Session.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+			Flags:      uint64(discordgo.MessageFlagsEphemeral),
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Error("Failed Creating CAH Response")
+	}
+}
+
+// showHand shows the player their cards in an ephemeral message, with a menu to pick from if they can pick cards this round
+func (g *Game) showHand(player *Player, ic *discordgo.InteractionCreate) {
+	if player == nil || !player.InGame {
+		g.respondEphemeral(ic, &discordgo.MessageEmbed{Description: fmt.Sprintf("You're not in this game, press %s to join it", JoinEmoji)}, nil)
+		return
+	}
+
+	if g.canPickCards(player) && !player.MadeSelections(g.CurrentPropmpt) {
+		g.respondEphemeral(ic, player.handEmbed(g.CurrentPropmpt), player.handComponents(g.CurrentPropmpt, g.handMenuID()))
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Your cards",
+		Description: player.cardList(),
+	}
+
+	switch {
+	case g.State == GameStatePickingResponses && !g.VoteMode && player.ID == g.CurrentCardCzar:
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: "You're the card czar this round"}
+	case g.State == GameStatePickingResponses && player.PlayingThisRound():
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: "You've made your selections for this round"}
+	case g.State == GameStatePickingResponses:
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: "You'll be dealt in next round"}
+	}
+
+	g.respondEphemeral(ic, embed, nil)
+}
+
+func (g *Game) handSelection(player *Player, customID string, ic *discordgo.InteractionCreate) {
+	if !g.canPickCards(player) || customID != g.handMenuID() {
+		err := g.Session.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{{Description: fmt.Sprintf("This hand is from an earlier round, press %s again to see your current cards", HandEmoji)}},
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+		if err != nil {
+			logrus.WithError(err).Error("Failed Creating CAH Response")
+		}
+		return
+	}
+
+	g.LastAction = time.Now()
+	g.playerPickedResponseReaction(player, ic.MessageComponentData().Values[0], ic, true)
+}
+
 func (g *Game) loadFromSerializedState() {
 	g.Lock()
 	// update references
@@ -1364,44 +1486,92 @@ func (p *Player) MadeSelections(currentPrompt *PromptCard) bool {
 	return true
 }
 
-func (p *Player) PresentBoard(session *discordgo.Session, currentPrompt *PromptCard, currentCardCzar int64) {
-	if currentCardCzar == p.ID {
-		return
+func (p *Player) hasSelected(index int) bool {
+	for _, v := range p.SelectedCards {
+		if v == index {
+			return true
+		}
 	}
 
-	embed := &discordgo.MessageEmbed{
+	return false
+}
+
+func (p *Player) cardList() string {
+	list := ""
+	for i, v := range p.Cards {
+		list += fmt.Sprintf("%s: %s\n", CardSelectionEmojis[i], EscaperReplacer.Replace(string(v)))
+	}
+
+	if list == "" {
+		return "You have no cards"
+	}
+
+	return list
+}
+
+func (p *Player) handEmbed(currentPrompt *PromptCard) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
 		Title:       fmt.Sprintf("Pick %d card(s)!", currentPrompt.NumPick),
 		Description: currentPrompt.PlaceHolder(),
 	}
+}
 
-	options := []discordgo.SelectMenuOption{}
+// handComponents returns a select menu with the cards the player has not selected yet,
+// or no components if the player is done picking
+func (p *Player) handComponents(currentPrompt *PromptCard, customID string) []discordgo.MessageComponent {
+	if len(p.SelectedCards) >= currentPrompt.NumPick {
+		return []discordgo.MessageComponent{}
+	}
 
+	options := []discordgo.SelectMenuOption{}
 	for i, v := range p.Cards {
-		cardValue := string(v)
-		selectMenuOption := discordgo.SelectMenuOption{
-			Value: CardSelectionEmojis[i],
+		if p.hasSelected(i) {
+			continue
 		}
-		if len(cardValue) > 100 {
-			selectMenuOption.Label = cardValue[:100]
-			if len(cardValue) > 200 {
-				selectMenuOption.Description = cardValue[100:200]
-			} else {
-				selectMenuOption.Description = cardValue[100:]
-			}
+
+		options = append(options, cardSelectOption(CardSelectionEmojis[i], v))
+	}
+
+	if len(options) < 1 {
+		return []discordgo.MessageComponent{}
+	}
+
+	return []discordgo.MessageComponent{discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{discordgo.SelectMenu{
+			Options:  options,
+			CustomID: customID,
+		}},
+	}}
+}
+
+// cardSelectOption puts as much of the card as possible in the option, overflowing into the description
+func cardSelectOption(value string, card ResponseCard) discordgo.SelectMenuOption {
+	option := discordgo.SelectMenuOption{
+		Value: value,
+		Label: string(card),
+	}
+
+	runes := []rune(string(card))
+	if len(runes) > 100 {
+		option.Label = string(runes[:100])
+		if len(runes) > 200 {
+			option.Description = string(runes[100:200])
 		} else {
-			selectMenuOption.Label = cardValue
+			option.Description = string(runes[100:])
 		}
-		options = append(options, selectMenuOption)
+	}
+
+	return option
+}
+
+func (p *Player) PresentBoard(session *discordgo.Session, currentPrompt *PromptCard, currentCardCzar int64) {
+	if currentCardCzar == p.ID {
+		return
 	}
 
 	resp, err := session.ChannelMessageSendComplex(p.Channel, &discordgo.MessageSend{
-		Embeds: []*discordgo.MessageEmbed{embed},
-		Components: []discordgo.MessageComponent{discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{discordgo.SelectMenu{
-				Options:  options,
-				CustomID: CahCardSelectMenu,
-			}},
-		}},
+		Embeds:     []*discordgo.MessageEmbed{p.handEmbed(currentPrompt)},
+		Components: p.handComponents(currentPrompt, CahCardSelectMenu),
 	})
 	if err != nil {
 		return
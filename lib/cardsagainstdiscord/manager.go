@@ -12,6 +12,18 @@ type GameManager struct {
 	SessionProvider SessionProvider
 	ActiveGames     map[int64]*Game
 	NumActiveGames  int
+
+	// If set, custom packs from this provider can be used in addition to the built in ones
+	CustomPackProvider CustomPackProvider
+}
+
+// GameOptions are the settings a game is created with
+type GameOptions struct {
+	// Players vote on the winner instead of having a card czar
+	VoteMode bool
+
+	// Hands are only shown in ephemeral messages in the game channel instead of in dm's
+	EphemeralHands bool
 }
 
 func NewGameManager(sessionProvider SessionProvider) *GameManager {
@@ -21,7 +33,35 @@ func NewGameManager(sessionProvider SessionProvider) *GameManager {
 	}
 }
 
-func (gm *GameManager) CreateGame(guildID int64, channelID int64, userID int64, username string, voteMode bool, packs ...string) (*Game, error) {
+// AvailablePacks returns the built in packs and the custom packs of the guild, custom packs take precedence
+func (gm *GameManager) AvailablePacks(guildID int64) (map[string]*CardPack, error) {
+	result := make(map[string]*CardPack, len(Packs))
+	for k, v := range Packs {
+		result[k] = v
+	}
+
+	if gm.CustomPackProvider == nil {
+		return result, nil
+	}
+
+	custom, err := gm.CustomPackProvider.CustomPacksForGuild(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range custom {
+		result[v.Name] = v
+	}
+
+	return result, nil
+}
+
+func (gm *GameManager) CreateGame(guildID int64, channelID int64, userID int64, username string, options GameOptions, packs ...string) (*Game, error) {
+	available, err := gm.AvailablePacks(guildID)
+	if err != nil {
+		return nil, err
+	}
+
 	allPacks := false
 	allResponseOnly := true
 	for _, v := range packs {
@@ -31,10 +71,10 @@ func (gm *GameManager) CreateGame(guildID int64, channelID int64, userID int64,
 			break
 		}
 
-		p, ok := Packs[v]
+		p, ok := available[v]
 		if !ok {
-			validPacks := make([]string, 0, len(Packs))
-			for k, _ := range Packs {
+			validPacks := make([]string, 0, len(available))
+			for k, _ := range available {
 				validPacks = append(validPacks, k)
 			}
 			return nil, &ErrUnknownPack{
@@ -55,12 +95,20 @@ func (gm *GameManager) CreateGame(guildID int64, channelID int64, userID int64,
 	}
 
 	if allPacks {
-		packs = make([]string, 0, len(Packs))
-		for k, _ := range Packs {
+		packs = make([]string, 0, len(available))
+		for k, _ := range available {
 			packs = append(packs, k)
 		}
 	}
 
+	// custom packs are stored in the game so they survive shard migrations
+	var customPacks []*CardPack
+	for _, v := range packs {
+		if p := available[v]; p != Packs[v] {
+			customPacks = append(customPacks, p)
+		}
+	}
+
 	gm.Lock()
 	defer gm.Unlock()
 
@@ -73,18 +121,20 @@ func (gm *GameManager) CreateGame(guildID int64, channelID int64, userID int64,
 	}
 
 	game := &Game{
-		MasterChannel: channelID,
-		Manager:       gm,
-		GuildID:       guildID,
-		Packs:         packs,
-		GameMaster:    userID,
-		VoteMode:      voteMode,
-		PlayerLimit:   10,
-		WinLimit:      10,
-		Session:       gm.SessionProvider.SessionForGuild(guildID),
-	}
-
-	err := game.Created()
+		MasterChannel:  channelID,
+		Manager:        gm,
+		GuildID:        guildID,
+		Packs:          packs,
+		CustomPacks:    customPacks,
+		GameMaster:     userID,
+		VoteMode:       options.VoteMode,
+		EphemeralHands: options.EphemeralHands,
+		PlayerLimit:    10,
+		WinLimit:       10,
+		Session:        gm.SessionProvider.SessionForGuild(guildID),
+	}
+
+	err = game.Created()
 	if err == nil {
 		game.AddPlayer(userID, username)
 
@@ -245,7 +295,7 @@ func (gm *GameManager) TryAdminRemoveGame(admin int64) error {
 }
 
 func (gm *GameManager) HandleInteractionCreate(ic *discordgo.InteractionCreate) {
-	if ic.Type != discordgo.InteractionMessageComponent {
+	if ic.Type != discordgo.InteractionMessageComponent && ic.Type != discordgo.InteractionModalSubmit {
 		return
 	}
 
@@ -258,6 +308,23 @@ func (gm *GameManager) HandleInteractionCreate(ic *discordgo.InteractionCreate)
 }
 
 func (gm *GameManager) HandleCahInteraction(ic *discordgo.InteractionCreate) {
+	if ic.Type == discordgo.InteractionModalSubmit {
+		// blank cards can be filled in from both dm's and ephemeral hands in the game channel
+		if ic.ModalSubmitData().CustomID != CahBlankCardModal {
+			return
+		}
+
+		user := interactionUser(ic)
+		if user == nil {
+			return
+		}
+
+		if game := gm.FindGameFromChannelOrUser(user.ID); game != nil {
+			game.HandleMessageCreate(ic)
+		}
+		return
+	}
+
 	cid := ic.ChannelID
 	gm.RLock()
 
@@ -266,13 +333,7 @@ func (gm *GameManager) HandleCahInteraction(ic *discordgo.InteractionCreate) {
 		game.HandleInteractionAdd(ic)
 	} else if ic.User != nil {
 		gm.RUnlock()
-		if ic.Type == discordgo.InteractionModalSubmit && ic.ModalSubmitData().CustomID == CahBlankCardModal {
-			if game, ok := gm.ActiveGames[ic.User.ID]; ok {
-				game.HandleMessageCreate(ic)
-			}
-			return
-		}
-		if game, ok := gm.ActiveGames[ic.User.ID]; ok {
+		if game := gm.FindGameFromChannelOrUser(ic.User.ID); game != nil {
 			game.HandleInteractionAdd(ic)
 		}
 	} else {
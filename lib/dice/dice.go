@@ -3,6 +3,7 @@ package dice
 import (
 	"errors"
 	"fmt"
+	"math/rand"
 	"regexp"
 	"strings"
 )
@@ -24,6 +25,9 @@ type RollResult interface {
 type roller interface {
 	Pattern() *regexp.Regexp
 	Roll([]string) (RollResult, error)
+
+	// RollRand rolls using rng, or the global source if it's nil
+	RollRand([]string, *rand.Rand) (RollResult, error)
 }
 
 type basicRollResult struct {
@@ -44,11 +48,32 @@ func addRollHandler(handler roller) {
 */
 
 func Roll(desc string) (RollResult, string, error) {
+	return roll(desc, nil)
+}
+
+// RollSeeded rolls like Roll, but with the dice determined by the seed,
+// so rolling the same description with the same seed always gives the same result
+func RollSeeded(desc string, seed int64) (RollResult, string, error) {
+	return roll(desc, rand.New(rand.NewSource(seed)))
+}
+
+// IsRoll returns true if desc is recognized as a roll by one of the roll handlers, without rolling it
+func IsRoll(desc string) bool {
+	for _, rollHandler := range rollHandlers {
+		if rollHandler.Pattern().MatchString(desc) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func roll(desc string, rng *rand.Rand) (RollResult, string, error) {
 	for _, rollHandler := range rollHandlers {
 		rollHandler.Pattern().Longest()
 
 		if r := rollHandler.Pattern().FindStringSubmatch(desc); r != nil {
-			result, err := rollHandler.Roll(r)
+			result, err := rollHandler.RollRand(r, rng)
 			if err != nil {
 				return nil, "", err
 			}
@@ -61,3 +86,12 @@ func Roll(desc string) (RollResult, string, error) {
 
 	return nil, "", errors.New("Bad roll format: " + desc)
 }
+
+// intn returns a random number in [0,n) from rng, or from the global source if rng is nil
+func intn(rng *rand.Rand, n int) int {
+	if rng == nil {
+		return rand.Intn(n)
+	}
+
+	return rng.Intn(n)
+}
@@ -126,7 +126,11 @@ var diePattern = regexp.MustCompile(`([0-9]+)(r|b|blk|p|g|y|w)`)
 
 func (EoteRoller) Pattern() *regexp.Regexp { return eotePattern }
 
-func (EoteRoller) Roll(matches []string) (RollResult, error) {
+func (r EoteRoller) Roll(matches []string) (RollResult, error) {
+	return r.RollRand(matches, nil)
+}
+
+func (EoteRoller) RollRand(matches []string, rng *rand.Rand) (RollResult, error) {
 	diePattern.Longest()
 
 	res := EoteResult{basicRollResult: basicRollResult{matches[0]}}
@@ -151,7 +155,7 @@ func (EoteRoller) Roll(matches []string) (RollResult, error) {
 		}
 
 		for i := int64(0); i < num; i++ {
-			die := choices[rand.Intn(len(choices))]
+			die := choices[intn(rng, len(choices))]
 			res.Add(die)
 			res.Rolls = append(res.Rolls, die)
 		}
@@ -0,0 +1,160 @@
+package dice
+
+import (
+	"testing"
+)
+
+func TestRollSeeded(t *testing.T) {
+	for _, desc := range []string{"4d6kh3+2", "10d10v7", "3g 2p", "2d20adv"} {
+		first, _, err := RollSeeded(desc, 1234)
+		if err != nil {
+			t.Fatalf("%s: %v", desc, err)
+		}
+
+		for i := 0; i < 5; i++ {
+			again, _, err := RollSeeded(desc, 1234)
+			if err != nil {
+				t.Fatalf("%s: %v", desc, err)
+			}
+
+			if again.String() != first.String() {
+				t.Fatalf("%s: same seed gave different results: %s and %s", desc, first, again)
+			}
+		}
+	}
+}
+
+func TestAdvantage(t *testing.T) {
+	for seed := int64(0); seed < 100; seed++ {
+		res, _, err := RollSeeded("1d20adv+1", seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		std := res.(StdResult)
+		if std.Other == nil || std.Advantage != "adv" {
+			t.Fatalf("advantage roll has no other roll: %s", std)
+		}
+
+		if std.Total < std.Other.Total {
+			t.Fatalf("advantage used the lower roll: %s", std)
+		}
+
+		res, _, err = RollSeeded("1d20dis", seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		std = res.(StdResult)
+		if std.Total > std.Other.Total {
+			t.Fatalf("disadvantage used the higher roll: %s", std)
+		}
+	}
+}
+
+func TestAdvantageNotDrop(t *testing.T) {
+	// "dis" must not be read as dropping dice
+	res, _, err := RollSeeded("10d6d2", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	std := res.(StdResult)
+	if len(std.Rolls) != 8 || len(std.Dropped) != 2 || std.Other != nil {
+		t.Fatalf("unexpected result for 10d6d2: %s", std)
+	}
+}
+
+func TestExploding(t *testing.T) {
+	exploded := false
+	for seed := int64(0); seed < 100; seed++ {
+		res, _, err := RollSeeded("3d2!", seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		std := res.(StdResult)
+		if len(std.Rolls) < 3 {
+			t.Fatalf("rolled less dice than asked for: %s", std)
+		}
+
+		// every die that rolled the max should have caused another roll
+		maxRolls := 0
+		for _, v := range std.Rolls {
+			if v == 2 {
+				maxRolls++
+			}
+		}
+
+		if len(std.Rolls) != 3+maxRolls {
+			t.Fatalf("wrong number of exploded dice: %s", std)
+		}
+
+		if maxRolls > 0 {
+			exploded = true
+		}
+	}
+
+	if !exploded {
+		t.Fatal("dice never exploded")
+	}
+
+	if _, _, err := Roll("2d1!"); err == nil {
+		t.Fatal("expected an error for exploding one sided dice")
+	}
+}
+
+func TestKeepMoreThanRolled(t *testing.T) {
+	if _, _, err := Roll("2d6kh3"); err == nil {
+		t.Fatal("expected an error when keeping more dice than rolled")
+	}
+}
+
+func TestExpandVariables(t *testing.T) {
+	vars := map[string]int{"str": 3, "dex": -1}
+
+	cases := map[string]string{
+		"1d20+{str}":      "1d20+3",
+		"1d20+{dex}":      "1d20-1",
+		"1d20-{dex}":      "1d20+1",
+		"1d20 + {STR}":    "1d20+3",
+		"{str}d6":         "3d6",
+		"1d20 no vars":    "1d20 no vars",
+		"1d20adv+{str} x": "1d20adv+3 x",
+	}
+
+	for in, expected := range cases {
+		out, err := ExpandVariables(in, vars)
+		if err != nil {
+			t.Errorf("%s: %v", in, err)
+			continue
+		}
+
+		if out != expected {
+			t.Errorf("%s: got %q, expected %q", in, out, expected)
+		}
+	}
+
+	if _, err := ExpandVariables("1d20+{wis}", vars); err == nil {
+		t.Error("expected an error for an unknown variable")
+	}
+}
+
+func TestIsRoll(t *testing.T) {
+	cases := map[string]bool{
+		"1d20":     true,
+		"3d6!":     true,
+		"1d20adv":  true,
+		"2d6v3":    true,
+		"2b 1p":    true,
+		"fireball": false,
+		"attack":   false,
+		"d20":      false,
+	}
+
+	for in, expected := range cases {
+		if got := IsRoll(in); got != expected {
+			t.Errorf("%s: got %t, expected %t", in, got, expected)
+		}
+	}
+}
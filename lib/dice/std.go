@@ -11,7 +11,8 @@ import (
 
 type StdRoller struct{}
 
-var stdPattern = regexp.MustCompile(`([0-9]+)d([0-9]+)((k|d|kh|dl|kl|dh)([0-9]+))?([+-][0-9]+)?($|\s)`)
+// matches: 1 dice, 2 sides, 3 explode, 4 advantage, 6 keep/drop, 7 keep/drop num, 8 bonus
+var stdPattern = regexp.MustCompile(`([0-9]+)d([0-9]+)(!)?(adv|dis)?((k|d|kh|dl|kl|dh)([0-9]+))?([+-][0-9]+)?($|\s)`)
 
 func (StdRoller) Pattern() *regexp.Regexp { return stdPattern }
 
@@ -20,17 +21,30 @@ type StdResult struct {
 	Rolls   []int
 	Dropped []int
 	Total   int
+
+	// Set to adv or dis for rolls with advantage or disadvantage, the roll that was not used is in Other
+	Advantage string
+	Other     *StdResult
 }
 
 func (r StdResult) String() string {
-	return fmt.Sprintf("%d %v (%v)", r.Total, r.Rolls, r.Dropped)
+	s := fmt.Sprintf("%d %v (%v)", r.Total, r.Rolls, r.Dropped)
+	if r.Other != nil {
+		s += fmt.Sprintf(" %s, other roll: %d %v (%v)", r.Advantage, r.Other.Total, r.Other.Rolls, r.Other.Dropped)
+	}
+
+	return s
 }
 
 func (r StdResult) Int() int {
 	return r.Total
 }
 
-func (StdRoller) Roll(matches []string) (RollResult, error) {
+func (r StdRoller) Roll(matches []string) (RollResult, error) {
+	return r.RollRand(matches, nil)
+}
+
+func (StdRoller) RollRand(matches []string, rng *rand.Rand) (RollResult, error) {
 	dice, err := strconv.ParseInt(matches[1], 10, 0)
 	if err != nil {
 		return nil, err
@@ -47,40 +61,88 @@ func (StdRoller) Roll(matches []string) (RollResult, error) {
 		return nil, errors.New("Must have at least one side")
 	}
 
+	explode := matches[3] != ""
+	if explode && sides < 2 {
+		return nil, errors.New("Exploding dice must have at least two sides")
+	}
+
 	keep := ""
 	num := 0
-	if matches[4] != "" {
-		number, err := strconv.ParseInt(matches[5], 10, 0)
+	if matches[6] != "" {
+		number, err := strconv.ParseInt(matches[7], 10, 0)
 		if err != nil {
 			return nil, err
 		}
 		num = int(number)
-		keep = matches[4]
+		keep = matches[6]
 	}
 
-	result := StdResult{
-		basicRollResult: basicRollResult{matches[0]},
-		Rolls:           make([]int, dice),
-		Dropped:         nil,
-		Total:           0,
-	}
-
-	if matches[6] != "" {
-		bonus, err := strconv.ParseInt(matches[6], 10, 0)
+	bonus := 0
+	if matches[8] != "" {
+		parsed, err := strconv.ParseInt(matches[8], 10, 0)
 		if err != nil {
 			return nil, err
 		}
-		result.Total += int(bonus)
+		bonus = int(parsed)
 	}
 
-	for i := 0; i < len(result.Rolls); i++ {
-		roll := rand.Intn(int(sides)) + 1
-		result.Rolls[i] = roll
+	result, err := rollStdSet(rng, int(dice), int(sides), explode, keep, num)
+	if err != nil {
+		return nil, err
+	}
+
+	result.basicRollResult = basicRollResult{matches[0]}
+	result.Total += bonus
+
+	if matches[4] == "" {
+		return *result, nil
+	}
+
+	// Roll it all again and use the best (or worst) of the two
+	other, err := rollStdSet(rng, int(dice), int(sides), explode, keep, num)
+	if err != nil {
+		return nil, err
+	}
+	other.Total += bonus
+
+	if (matches[4] == "adv" && other.Total > result.Total) || (matches[4] == "dis" && other.Total < result.Total) {
+		result.Rolls, other.Rolls = other.Rolls, result.Rolls
+		result.Dropped, other.Dropped = other.Dropped, result.Dropped
+		result.Total, other.Total = other.Total, result.Total
+	}
+
+	result.Advantage = matches[4]
+	result.Other = other
+	return *result, nil
+}
+
+// rollStdSet rolls the dice, exploding dice that roll the max by rolling another die, then keeps or drops dice
+func rollStdSet(rng *rand.Rand, dice, sides int, explode bool, keep string, num int) (*StdResult, error) {
+	result := &StdResult{
+		Rolls: make([]int, 0, dice),
+	}
+
+	for i := 0; i < dice; i++ {
+		roll := intn(rng, sides) + 1
+		result.Rolls = append(result.Rolls, roll)
+
+		if explode && roll == sides {
+			if int64(len(result.Rolls)) >= MaxLoop {
+				return nil, ErrTooManyLoops
+			}
+
+			// roll another one
+			i--
+		}
 	}
 
 	sort.Ints(result.Rolls)
 	size := len(result.Rolls)
 
+	if num > size {
+		return nil, errors.New("Can't keep or drop more dice than were rolled")
+	}
+
 	switch keep {
 	case "k":
 		fallthrough
@@ -0,0 +1,53 @@
+package dice
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var varPattern = regexp.MustCompile(`(\s*[+-]\s*)?\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
+
+// ExpandVariables replaces references to variables in the roll description, like the {str} in 1d20+{str}, with their values.
+// Variable names are case insensitive, vars should have lowercase keys.
+func ExpandVariables(desc string, vars map[string]int) (string, error) {
+	var err error
+	expanded := varPattern.ReplaceAllStringFunc(desc, func(match string) string {
+		parts := varPattern.FindStringSubmatch(match)
+		v, ok := vars[strings.ToLower(parts[2])]
+		if !ok {
+			if err == nil {
+				err = fmt.Errorf("Unknown variable {%s}", parts[2])
+			}
+			return match
+		}
+
+		sign := strings.TrimSpace(parts[1])
+		if sign == "" {
+			return strconv.Itoa(v)
+		}
+
+		if sign == "-" {
+			v = -v
+		}
+
+		if v < 0 {
+			return strconv.Itoa(v)
+		}
+
+		return "+" + strconv.Itoa(v)
+	})
+
+	return expanded, err
+}
+
+// VariableNames returns the lowercase names of the variables referenced in the roll description
+func VariableNames(desc string) []string {
+	var names []string
+	for _, v := range varPattern.FindAllStringSubmatch(desc, -1) {
+		names = append(names, strings.ToLower(v[2]))
+	}
+
+	return names
+}
@@ -30,7 +30,11 @@ func (r VsResult) Int() int {
 	return r.Successes
 }
 
-func (VsRoller) Roll(matches []string) (RollResult, error) {
+func (r VsRoller) Roll(matches []string) (RollResult, error) {
+	return r.RollRand(matches, nil)
+}
+
+func (VsRoller) RollRand(matches []string, rng *rand.Rand) (RollResult, error) {
 	dice, err := strconv.ParseInt(matches[1], 10, 0)
 	if err != nil {
 		return nil, err
@@ -68,12 +72,12 @@ func (VsRoller) Roll(matches []string) (RollResult, error) {
 	}
 
 	for i := int64(0); i < dice; i++ {
-		roll := rand.Intn(int(sides)) + 1
+		roll := intn(rng, int(sides)) + 1
 
 		if roll == int(sides) && explode {
 			total := roll
 			for roll == int(sides) {
-				roll = rand.Intn(int(sides)) + 1
+				roll = intn(rng, int(sides)) + 1
 				total += roll
 			}
 			roll = total
@@ -1320,6 +1320,8 @@ const (
 	ErrCodeUnknownEmoji       = 10014
 	ErrCodeUnknownWebhook     = 10015
 
+	ErrCodeUnknownGuildScheduledEvent = 10070
+
 	ErrCodeBotsCannotUseEndpoint  = 20001
 	ErrCodeOnlyBotsCanUseEndpoint = 20002
 
@@ -1594,6 +1596,94 @@ type AutoModerationAction struct {
 	Metadata *AutoModerationActionMetadata `json:"metadata,omitempty"`
 }
 
+// GuildScheduledEvent is a representation of a scheduled event in a guild.
+// https://discord.com/developers/docs/resources/guild-scheduled-event#guild-scheduled-event-object
+type GuildScheduledEvent struct {
+	ID                 int64                              `json:"id,string"`
+	GuildID            int64                              `json:"guild_id,string"`
+	ChannelID          int64                              `json:"channel_id,string"`
+	CreatorID          int64                              `json:"creator_id,string"`
+	Name               string                             `json:"name"`
+	Description        string                             `json:"description"`
+	ScheduledStartTime time.Time                          `json:"scheduled_start_time"`
+	ScheduledEndTime   *time.Time                         `json:"scheduled_end_time"`
+	PrivacyLevel       GuildScheduledEventPrivacyLevel    `json:"privacy_level"`
+	Status             GuildScheduledEventStatus          `json:"status"`
+	EntityType         GuildScheduledEventEntityType      `json:"entity_type"`
+	EntityID           int64                              `json:"entity_id,string"`
+	EntityMetadata     *GuildScheduledEventEntityMetadata `json:"entity_metadata"`
+	Creator            *User                              `json:"creator"`
+	UserCount          int                                `json:"user_count"`
+	Image              string                             `json:"image"`
+}
+
+// GuildScheduledEventParams are the parameters allowed for creating or updating a scheduled event
+// https://discord.com/developers/docs/resources/guild-scheduled-event#create-guild-scheduled-event
+type GuildScheduledEventParams struct {
+	// set to a pointer to 0 to clear the channel when changing to an external event
+	ChannelID          *NullableID                        `json:"channel_id,omitempty"`
+	EntityMetadata     *GuildScheduledEventEntityMetadata `json:"entity_metadata,omitempty"`
+	Name               string                             `json:"name,omitempty"`
+	PrivacyLevel       GuildScheduledEventPrivacyLevel    `json:"privacy_level,omitempty"`
+	ScheduledStartTime *time.Time                         `json:"scheduled_start_time,omitempty"`
+	ScheduledEndTime   *time.Time                         `json:"scheduled_end_time,omitempty"`
+	Description        string                             `json:"description,omitempty"`
+	EntityType         GuildScheduledEventEntityType      `json:"entity_type,omitempty"`
+	Status             GuildScheduledEventStatus          `json:"status,omitempty"`
+}
+
+// GuildScheduledEventEntityMetadata holds additional metadata for guild scheduled event.
+type GuildScheduledEventEntityMetadata struct {
+	// location of the event (1-100 characters)
+	// required for events with 'entity_type': EXTERNAL
+	Location string `json:"location"`
+}
+
+// GuildScheduledEventPrivacyLevel is the privacy level of a scheduled event.
+type GuildScheduledEventPrivacyLevel int
+
+const (
+	// GuildScheduledEventPrivacyLevelGuildOnly makes the scheduled
+	// event is only accessible to guild members
+	GuildScheduledEventPrivacyLevelGuildOnly GuildScheduledEventPrivacyLevel = 2
+)
+
+// GuildScheduledEventStatus is the status of a scheduled event
+// Valid Guild Scheduled Event Status Transitions :
+// SCHEDULED --> ACTIVE --> COMPLETED
+// SCHEDULED --> CANCELED
+type GuildScheduledEventStatus int
+
+const (
+	// GuildScheduledEventStatusScheduled represents the current event is in scheduled state
+	GuildScheduledEventStatusScheduled GuildScheduledEventStatus = 1
+	// GuildScheduledEventStatusActive represents the current event is in active state
+	GuildScheduledEventStatusActive GuildScheduledEventStatus = 2
+	// GuildScheduledEventStatusCompleted represents the current event is in completed state
+	GuildScheduledEventStatusCompleted GuildScheduledEventStatus = 3
+	// GuildScheduledEventStatusCanceled represents the current event is in canceled state
+	GuildScheduledEventStatusCanceled GuildScheduledEventStatus = 4
+)
+
+// GuildScheduledEventEntityType is the type of entity associated with a guild scheduled event.
+type GuildScheduledEventEntityType int
+
+const (
+	// GuildScheduledEventEntityTypeStageInstance represents a stage channel
+	GuildScheduledEventEntityTypeStageInstance GuildScheduledEventEntityType = 1
+	// GuildScheduledEventEntityTypeVoice represents a voice channel
+	GuildScheduledEventEntityTypeVoice GuildScheduledEventEntityType = 2
+	// GuildScheduledEventEntityTypeExternal represents an external event
+	GuildScheduledEventEntityTypeExternal GuildScheduledEventEntityType = 3
+)
+
+// GuildScheduledEventUser is a user subscribed to a scheduled event.
+type GuildScheduledEventUser struct {
+	GuildScheduledEventID int64   `json:"guild_scheduled_event_id,string"`
+	User                  *User   `json:"user"`
+	Member                *Member `json:"member"`
+}
+
 // ActivityType is the type of Activity (see ActivityType* consts) in the Activity struct
 // https://discord.com/developers/docs/topics/gateway#activity-object-activity-types
 type ActivityType int
@@ -0,0 +1,58 @@
+package de
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cirelion/flint/lib/when/rules"
+)
+
+func CasualDate(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\P{L}|^)" +
+			"((?:bis\\s+)?(?:jetzt|sofort|heute\\s+(?:morgen|früh|nacht)|heute|übermorgen|uebermorgen|morgen|vorgestern|gestern))" +
+			"(?:\\P{L}|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			lower := strings.ToLower(strings.TrimSpace(m.String()))
+
+			switch {
+			case strings.Contains(lower, "heute morgen"), strings.Contains(lower, "heute früh"):
+				if (c.Hour == nil && c.Minute == nil) || s == rules.Override {
+					if o.Morning != 0 {
+						c.Hour = &o.Morning
+					} else {
+						c.Hour = pointer.ToInt(8)
+					}
+					c.Minute = pointer.ToInt(0)
+				}
+			case strings.Contains(lower, "heute nacht"):
+				if (c.Hour == nil && c.Minute == nil) || s == rules.Override {
+					c.Hour = pointer.ToInt(23)
+					c.Minute = pointer.ToInt(0)
+				}
+			case strings.Contains(lower, "heute"):
+				// c.Hour = pointer.ToInt(18)
+			case strings.Contains(lower, "übermorgen"), strings.Contains(lower, "uebermorgen"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration += time.Hour * 48
+				}
+			case strings.Contains(lower, "morgen"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration += time.Hour * 24
+				}
+			case strings.Contains(lower, "vorgestern"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration -= time.Hour * 48
+				}
+			case strings.Contains(lower, "gestern"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration -= time.Hour * 24
+				}
+			}
+
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package de_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/de"
+)
+
+func TestCasualDate(t *testing.T) {
+	fixt := []Fixture{
+		{"Das muss jetzt erledigt werden", 9, "jetzt", 0},
+		{"Das muss heute erledigt werden", 9, "heute", 0},
+		{"Das muss morgen erledigt werden", 9, "morgen", time.Hour * 24},
+		{"Das muss bis übermorgen erledigt werden", 9, "bis übermorgen", time.Hour * 48},
+		{"Das hätte gestern erledigt werden müssen", 11, "gestern", -(time.Hour * 24)},
+		{"vorgestern", 0, "vorgestern", -(time.Hour * 48)},
+		{"heute Nacht", 0, "heute Nacht", 23 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(de.CasualDate(rules.Skip))
+
+	ApplyFixtures(t, "de.CasualDate", w, fixt)
+}
+
+func TestCasualTime(t *testing.T) {
+	fixt := []Fixture{
+		{"Das muss bis mittags fertig sein", 9, "bis mittags", 12 * time.Hour},
+		{"am Nachmittag", 0, "am Nachmittag", 15 * time.Hour},
+		{"gegen Abend", 0, "gegen Abend", 18 * time.Hour},
+		{"abends", 0, "abends", 18 * time.Hour},
+		{"früh", 0, "früh", 8 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(de.CasualTime(rules.Skip))
+
+	ApplyFixtures(t, "de.CasualTime", w, fixt)
+}
+
+func TestCasualDateCasualTime(t *testing.T) {
+	fixt := []Fixture{
+		{"Das muss morgen nachmittags passieren", 9, "morgen nachmittags", (15 + 24) * time.Hour},
+		{"Das muss morgen früh passieren", 9, "morgen früh", (8 + 24) * time.Hour},
+		{"Das war gestern abends", 8, "gestern abends", (18 - 24) * time.Hour},
+		{"Fenster putzen heute Abend", 15, "heute Abend", 18 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(
+		de.CasualDate(rules.Skip),
+		de.CasualTime(rules.Override),
+	)
+
+	ApplyFixtures(t, "de.CasualDate|de.CasualTime", w, fixt)
+}
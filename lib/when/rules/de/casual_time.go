@@ -0,0 +1,59 @@
+package de
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cirelion/flint/lib/when/rules"
+)
+
+func CasualTime(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile(`(?i)(?:\P{L}|^)((?:am|gegen|bis|zum|heute)?\s*(früh|morgens|vormittags?|nachmittags?|mittags?|abends?|nachts))(?:\P{L}|$)`),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			lower := strings.ToLower(strings.TrimSpace(m.String()))
+
+			if (c.Hour != nil || c.Minute != nil) && s == rules.Override {
+				return false, nil
+			}
+
+			switch {
+			case strings.Contains(lower, "nachmittag"):
+				if o.Afternoon != 0 {
+					c.Hour = &o.Afternoon
+				} else {
+					c.Hour = pointer.ToInt(15)
+				}
+				c.Minute = pointer.ToInt(0)
+			case strings.Contains(lower, "abend"):
+				if o.Evening != 0 {
+					c.Hour = &o.Evening
+				} else {
+					c.Hour = pointer.ToInt(18)
+				}
+				c.Minute = pointer.ToInt(0)
+			case strings.Contains(lower, "nachts"):
+				c.Hour = pointer.ToInt(23)
+				c.Minute = pointer.ToInt(0)
+			case strings.Contains(lower, "früh"), strings.Contains(lower, "morgens"), strings.Contains(lower, "vormittag"):
+				if o.Morning != 0 {
+					c.Hour = &o.Morning
+				} else {
+					c.Hour = pointer.ToInt(8)
+				}
+				c.Minute = pointer.ToInt(0)
+			case strings.Contains(lower, "mittag"):
+				if o.Noon != 0 {
+					c.Hour = &o.Noon
+				} else {
+					c.Hour = pointer.ToInt(12)
+				}
+				c.Minute = pointer.ToInt(0)
+			}
+
+			return true, nil
+		},
+	}
+}
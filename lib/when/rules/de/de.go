@@ -0,0 +1,47 @@
+package de
+
+import "github.com/cirelion/flint/lib/when/rules"
+
+var All = []rules.Rule{
+	Weekday(rules.Override),
+	CasualDate(rules.Override),
+	CasualTime(rules.Override),
+	Hour(rules.Override),
+	HourMinute(rules.Override),
+	Deadline(rules.Override),
+}
+
+var WEEKDAY_OFFSET = map[string]int{
+	"sonntag":    0,
+	"montag":     1,
+	"dienstag":   2,
+	"mittwoch":   3,
+	"donnerstag": 4,
+	"freitag":    5,
+	"samstag":    6,
+	"sonnabend":  6,
+}
+
+var WEEKDAY_OFFSET_PATTERN = "(?:sonntag|montag|dienstag|mittwoch|donnerstag|freitag|samstag|sonnabend)"
+
+var INTEGER_WORDS = map[string]int{
+	"eins":   1,
+	"ein":    1,
+	"eine":   1,
+	"einer":  1,
+	"einem":  1,
+	"einen":  1,
+	"zwei":   2,
+	"drei":   3,
+	"vier":   4,
+	"fünf":   5,
+	"sechs":  6,
+	"sieben": 7,
+	"acht":   8,
+	"neun":   9,
+	"zehn":   10,
+	"elf":    11,
+	"zwölf":  12,
+}
+
+var INTEGER_WORDS_PATTERN = `(?:eins|einer|einem|einen|eine|ein|zwei|drei|vier|fünf|sechs|sieben|acht|neun|zehn|elf|zwölf)`
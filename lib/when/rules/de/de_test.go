@@ -0,0 +1,58 @@
+package de_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules/de"
+	"github.com/stretchr/testify/require"
+)
+
+var null = time.Date(2016, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+type Fixture struct {
+	Text   string
+	Index  int
+	Phrase string
+	Diff   time.Duration
+}
+
+func ApplyFixtures(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d - %s", name, i, f.Text)
+		require.NotNil(t, res, "[%s] res #%d - %s", name, i, f.Text)
+		require.Equal(t, f.Index, res.Index, "[%s] index #%d - %s", name, i, f.Text)
+		require.Equal(t, f.Phrase, res.Text, "[%s] text #%d - %s", name, i, f.Text)
+		require.Equal(t, f.Diff, res.Time.Sub(null), "[%s] diff #%d - %s", name, i, f.Text)
+	}
+}
+
+func ApplyFixturesNil(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d", name, i)
+		require.Nil(t, res, "[%s] res #%d", name, i)
+	}
+}
+
+func TestAll(t *testing.T) {
+	w := when.New(nil)
+	w.Add(de.All...)
+
+	// complex cases
+	fixt := []Fixture{
+		{"morgen um 11:10 abends", 0, "morgen um 11:10 abends", (47 * time.Hour) + (10 * time.Minute)},
+		{"nächsten Montag abends", 0, "nächsten Montag abends", ((5 * 24) + 18) * time.Hour},
+		{"am Freitag nachmittags", 0, "am Freitag nachmittags", ((2 * 24) + 15) * time.Hour},
+		{"nächsten Dienstag um 14:00", 0, "nächsten Dienstag um 14:00", ((6 * 24) + 14) * time.Hour},
+		{"nächsten Dienstag um 14 Uhr", 0, "nächsten Dienstag um 14 Uhr", ((6 * 24) + 14) * time.Hour},
+		{"letzten Dienstag um 11 Uhr", 0, "letzten Dienstag um 11 Uhr", -13 * time.Hour},
+		{"Brief schreiben am Samstag abends", 16, "am Samstag abends", ((3 * 24) + 18) * time.Hour},
+		{"heute morgen", 0, "heute morgen", 8 * time.Hour},
+		{"übermorgen um 9 Uhr", 0, "übermorgen um 9 Uhr", (48 + 9) * time.Hour},
+	}
+
+	ApplyFixtures(t, "de.All...", w, fixt)
+}
@@ -0,0 +1,84 @@
+package de
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/pkg/errors"
+)
+
+func Deadline(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\P{L}|^)" +
+			"(in|innerhalb(?:\\s+von)?|binnen)\\s*" +
+			"((?:einer?\\s+)?halbe[nr]?|ein\\s*paar|paar|wenigen|" + INTEGER_WORDS_PATTERN + "|[0-9]+)?\\s*" +
+			"(sekunden?|minuten?|stunden?|tag(?:e|en)?|wochen?|monat(?:e|en)?|jahr(?:e|en)?)" +
+			"(?:\\P{L}|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if c.Duration != 0 && s != rules.Override {
+				return false, nil
+			}
+
+			numStr := strings.ToLower(strings.TrimSpace(m.Captures[1]))
+
+			var num int
+			var err error
+
+			if n, ok := INTEGER_WORDS[numStr]; ok {
+				num = n
+			} else if numStr == "" {
+				num = 1
+			} else if strings.Contains(numStr, "paar") || strings.Contains(numStr, "wenig") {
+				num = 3
+			} else if strings.Contains(numStr, "halb") {
+				// pass
+			} else {
+				num, err = strconv.Atoi(numStr)
+				if err != nil {
+					return false, errors.Wrapf(err, "convert '%s' to int", numStr)
+				}
+			}
+
+			exponent := strings.ToLower(strings.TrimSpace(m.Captures[2]))
+
+			if !strings.Contains(numStr, "halb") {
+				switch {
+				case strings.Contains(exponent, "sekunde"):
+					c.Duration = time.Duration(num) * time.Second
+				case strings.Contains(exponent, "minute"):
+					c.Duration = time.Duration(num) * time.Minute
+				case strings.Contains(exponent, "stunde"):
+					c.Duration = time.Duration(num) * time.Hour
+				case strings.Contains(exponent, "tag"):
+					c.Duration = time.Duration(num) * 24 * time.Hour
+				case strings.Contains(exponent, "woche"):
+					c.Duration = time.Duration(num) * 7 * 24 * time.Hour
+				case strings.Contains(exponent, "monat"):
+					c.Month = pointer.ToInt((int(ref.Month())+num-1)%12 + 1)
+				case strings.Contains(exponent, "jahr"):
+					c.Year = pointer.ToInt(ref.Year() + num)
+				}
+			} else {
+				switch {
+				case strings.Contains(exponent, "stunde"):
+					c.Duration = 30 * time.Minute
+				case strings.Contains(exponent, "tag"):
+					c.Duration = 12 * time.Hour
+				case strings.Contains(exponent, "woche"):
+					c.Duration = 7 * 12 * time.Hour
+				case strings.Contains(exponent, "monat"):
+					// 2 weeks
+					c.Duration = 14 * 24 * time.Hour
+				case strings.Contains(exponent, "jahr"):
+					c.Month = pointer.ToInt((int(ref.Month())+5)%12 + 1)
+				}
+			}
+
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package de_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/de"
+)
+
+func TestDeadline(t *testing.T) {
+	fixt := []Fixture{
+		{"das muss in einer halben Stunde fertig sein", 9, "in einer halben Stunde", time.Hour / 2},
+		{"das muss innerhalb einer Stunde fertig sein", 9, "innerhalb einer Stunde", time.Hour},
+		{"in 5 Minuten", 0, "in 5 Minuten", time.Minute * 5},
+		{"In 5 Minuten gehe ich nach Hause.", 0, "In 5 Minuten", time.Minute * 5},
+		{"Wir müssen das in 10 Tagen schaffen.", 16, "in 10 Tagen", 10 * 24 * time.Hour},
+		{"Wir müssen das in fünf Tagen schaffen.", 16, "in fünf Tagen", 5 * 24 * time.Hour},
+		{"in 5 Sekunden", 0, "in 5 Sekunden", 5 * time.Second},
+		{"binnen zwei Wochen", 0, "binnen zwei Wochen", 14 * 24 * time.Hour},
+		{"in einem Monat", 0, "in einem Monat", 31 * 24 * time.Hour},
+		{"in ein paar Monaten", 0, "in ein paar Monaten", 91 * 24 * time.Hour},
+		{"in einem Jahr", 0, "in einem Jahr", 366 * 24 * time.Hour},
+		{"in einer Woche", 0, "in einer Woche", 7 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(de.Deadline(rules.Skip))
+
+	ApplyFixtures(t, "de.Deadline", w, fixt)
+}
@@ -0,0 +1,73 @@
+package de
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/pkg/errors"
+)
+
+/*
+	"17 Uhr"
+	"5 Uhr abends"
+	"um 9h"
+	"um drei Uhr nachmittags"
+*/
+
+func Hour(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:[^\\p{L}\\d]|^)" +
+			"(" + INTEGER_WORDS_PATTERN + "|\\d{1,2})" +
+			"(\\s*(?:uhr|h))" +
+			"(?:\\s*(morgens|früh|vormittags|nachmittags|abends|nachts))?" +
+			"(?:\\P{L}|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if c.Hour != nil && s != rules.Override {
+				return false, nil
+			}
+
+			var hour int
+			var err error
+
+			if n, ok := INTEGER_WORDS[strings.ToLower(m.Captures[0])]; ok {
+				hour = n
+			} else {
+				hour, err = strconv.Atoi(m.Captures[0])
+				if err != nil {
+					return false, errors.Wrap(err, "hour rule")
+				}
+			}
+
+			if hour > 23 {
+				return false, nil
+			}
+
+			switch strings.ToLower(m.Captures[2]) {
+			case "":
+			case "morgens", "früh", "vormittags":
+				if hour > 12 {
+					return false, nil
+				}
+				if hour == 12 {
+					hour = 0
+				}
+			case "nachmittags", "abends", "nachts":
+				if hour > 12 {
+					return false, nil
+				}
+				if hour < 12 {
+					hour += 12
+				}
+			}
+
+			zero := 0
+			c.Hour = &hour
+			c.Minute = &zero
+
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,70 @@
+package de
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/pkg/errors"
+)
+
+/*
+	"17:30"
+	"17.30 Uhr"
+	"5:30 abends"
+*/
+
+// 1. - int
+// 2. - int
+// 3. - uhr?
+// 4. - ext?
+
+func HourMinute(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\D|^)" +
+			"((?:[0-1]{0,1}[0-9])|(?:2[0-3]))" +
+			"(?:\\:|\\.)" +
+			"((?:[0-5][0-9]))" +
+			"(\\s*uhr)?" +
+			"(?:\\s*(morgens|früh|vormittags|nachmittags|abends|nachts))?" +
+			"(?:[^\\p{L}\\d.]|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if (c.Hour != nil || c.Minute != nil) && s != rules.Override {
+				return false, nil
+			}
+
+			hour, err := strconv.Atoi(m.Captures[0])
+			if err != nil {
+				return false, errors.Wrap(err, "hour minute rule")
+			}
+
+			minutes, err := strconv.Atoi(m.Captures[1])
+			if err != nil {
+				return false, errors.Wrap(err, "hour minute rule")
+			}
+
+			c.Minute = &minutes
+
+			if m.Captures[3] != "" {
+				if hour > 12 {
+					return false, nil
+				}
+				switch strings.ToLower(m.Captures[3]) {
+				case "morgens", "früh", "vormittags": // am
+					c.Hour = &hour
+				case "nachmittags", "abends", "nachts": // pm
+					if hour < 12 {
+						hour += 12
+					}
+					c.Hour = &hour
+				}
+			} else {
+				c.Hour = &hour
+			}
+
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package de_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/de"
+)
+
+func TestHourMinute(t *testing.T) {
+	fixtok := []Fixture{
+		{"17:30", 0, "17:30", (17 * time.Hour) + (30 * time.Minute)},
+		{"um 17.30 Uhr", 3, "17.30 Uhr", (17 * time.Hour) + (30 * time.Minute)},
+		{"um 5:59 abends", 3, "5:59 abends", (17 * time.Hour) + (59 * time.Minute)},
+		{"bis 11:10 nachts", 4, "11:10 nachts", (23 * time.Hour) + (10 * time.Minute)},
+		{"um 8:15 morgens", 3, "8:15 morgens", (8 * time.Hour) + (15 * time.Minute)},
+	}
+
+	fixtnil := []Fixture{
+		{"28:30", 0, "", 0},
+		{"12:61", 0, "", 0},
+		{"24:10", 0, "", 0},
+		{"am 17.03.2016", 0, "", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(
+		de.Hour(rules.Override),
+		de.HourMinute(rules.Override),
+	)
+
+	ApplyFixtures(t, "de.Hour|de.HourMinute", w, fixtok)
+	ApplyFixturesNil(t, "de.Hour|de.HourMinute nil", w, fixtnil)
+}
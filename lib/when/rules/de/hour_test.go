@@ -0,0 +1,26 @@
+package de_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/de"
+)
+
+func TestHour(t *testing.T) {
+	fixt := []Fixture{
+		{"17 Uhr", 0, "17 Uhr", 17 * time.Hour},
+		{"um 5 Uhr abends", 3, "5 Uhr abends", 17 * time.Hour},
+		{"um 9h", 3, "9h", 9 * time.Hour},
+		{"um drei Uhr nachmittags", 3, "drei Uhr nachmittags", 15 * time.Hour},
+		{"um zwölf Uhr morgens", 3, "zwölf Uhr morgens", 0},
+		{"um elf Uhr", 3, "elf Uhr", 11 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(de.Hour(rules.Override))
+
+	ApplyFixtures(t, "de.Hour", w, fixt)
+}
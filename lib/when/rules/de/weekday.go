@@ -0,0 +1,70 @@
+package de
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/lib/when/rules"
+)
+
+func Weekday(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\P{L}|^)" +
+			"(?:(am|an|bis|diese[nmr]?|letzte[nmr]?|vergangene[nmr]?|nächste[nmr]?|naechste[nmr]?|kommende[nmr]?)\\s*)?" +
+			"(" + WEEKDAY_OFFSET_PATTERN[3:] + // skip '(?:'
+			"(?:\\s*((?:diese|letzte|vergangene|nächste|naechste|kommende)\\s*woche))?" +
+			"(?:\\P{L}|$)"),
+
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			day := strings.ToLower(strings.TrimSpace(m.Captures[1]))
+			norm := m.Captures[2]
+			if norm == "" {
+				norm = m.Captures[0]
+			}
+			norm = strings.ToLower(strings.TrimSpace(norm))
+			if norm == "" || norm == "am" || norm == "an" || norm == "bis" {
+				norm = "nächst"
+			}
+
+			dayInt, ok := WEEKDAY_OFFSET[day]
+			if !ok {
+				return false, nil
+			}
+
+			if c.Duration != 0 && s != rules.Override {
+				return false, nil
+			}
+
+			// Switch:
+			switch {
+			case strings.Contains(norm, "letzt") || strings.Contains(norm, "vergangen"):
+				diff := int(ref.Weekday()) - dayInt
+				if diff > 0 {
+					c.Duration = -time.Duration(diff*24) * time.Hour
+				} else if diff < 0 {
+					c.Duration = -time.Duration(7+diff) * 24 * time.Hour
+				} else {
+					c.Duration = -(7 * 24 * time.Hour)
+				}
+			case strings.Contains(norm, "nächst") || strings.Contains(norm, "naechst") || strings.Contains(norm, "kommend"):
+				diff := dayInt - int(ref.Weekday())
+				if diff > 0 {
+					c.Duration = time.Duration(diff*24) * time.Hour
+				} else if diff < 0 {
+					c.Duration = time.Duration(7+diff) * 24 * time.Hour
+				} else {
+					c.Duration = 7 * 24 * time.Hour
+				}
+			case strings.Contains(norm, "dies"):
+				if int(ref.Weekday()) < dayInt {
+					c.Duration = time.Duration((dayInt-int(ref.Weekday()))*24) * time.Hour
+				} else if int(ref.Weekday()) > dayInt {
+					c.Duration = -time.Duration((int(ref.Weekday())-dayInt)*24) * time.Hour
+				}
+			}
+
+			return true, nil
+		},
+	}
+}
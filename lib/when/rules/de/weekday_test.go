@@ -0,0 +1,50 @@
+package de_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/de"
+)
+
+func TestWeekday(t *testing.T) {
+	// current is Wednesday
+	fixt := []Fixture{
+		// past/last
+		{"das war letzten Montag", 8, "letzten Montag", -(2 * 24 * time.Hour)},
+		{"vergangenen Samstag", 0, "vergangenen Samstag", -(4 * 24 * time.Hour)},
+		{"letzten Mittwoch", 0, "letzten Mittwoch", -(7 * 24 * time.Hour)},
+		{"Dienstag letzte Woche", 0, "Dienstag letzte Woche", -(24 * time.Hour)},
+
+		// next
+		{"nächsten Dienstag", 0, "nächsten Dienstag", 6 * 24 * time.Hour},
+		{"schreib mir nächsten Mittwoch", 12, "nächsten Mittwoch", 7 * 24 * time.Hour},
+		{"kommender Samstag", 0, "kommender Samstag", 3 * 24 * time.Hour},
+		{"am Freitag", 0, "am Freitag", 2 * 24 * time.Hour},
+		{"Sonntag", 0, "Sonntag", 4 * 24 * time.Hour},
+
+		// this
+		{"diesen Dienstag", 0, "diesen Dienstag", -(24 * time.Hour)},
+		{"diesen Mittwoch", 0, "diesen Mittwoch", 0},
+		{"diesen Samstag", 0, "diesen Samstag", 3 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(de.Weekday(rules.Override))
+
+	ApplyFixtures(t, "de.Weekday", w, fixt)
+}
+
+func TestWeekdayNil(t *testing.T) {
+	fixt := []Fixture{
+		{"morgen", 0, "", 0},
+		{"Montagsmaler", 0, "", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(de.Weekday(rules.Override))
+
+	ApplyFixturesNil(t, "de.Weekday nil", w, fixt)
+}
@@ -0,0 +1,52 @@
+package es
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cirelion/flint/lib/when/rules"
+)
+
+func CasualDate(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\P{L}|^)" +
+			"((?:hasta\\s+)?(?:ahora(?:\\s+mismo)?|hoy|esta\\s+noche|(?:por|en|de|a|hasta)\\s+la\\s+ma[nñ]ana|pasado\\s+ma[nñ]ana|ma[nñ]ana|anteayer|antier|ayer))" +
+			"(?:\\P{L}|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			lower := strings.ToLower(strings.TrimSpace(m.String()))
+
+			switch {
+			case strings.Contains(lower, "la mañana"), strings.Contains(lower, "la manana"):
+				// "por la mañana" is the morning, not tomorrow, CasualTime handles it
+				return false, nil
+			case strings.Contains(lower, "esta noche"):
+				if (c.Hour == nil && c.Minute == nil) || s == rules.Override {
+					c.Hour = pointer.ToInt(23)
+					c.Minute = pointer.ToInt(0)
+				}
+			case strings.Contains(lower, "hoy"):
+				// c.Hour = pointer.ToInt(18)
+			case strings.Contains(lower, "pasado"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration += time.Hour * 48
+				}
+			case strings.Contains(lower, "mañana"), strings.Contains(lower, "manana"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration += time.Hour * 24
+				}
+			case strings.Contains(lower, "anteayer"), strings.Contains(lower, "antier"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration -= time.Hour * 48
+				}
+			case strings.Contains(lower, "ayer"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration -= time.Hour * 24
+				}
+			}
+
+			return true, nil
+		},
+	}
+}
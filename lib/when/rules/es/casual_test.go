@@ -0,0 +1,73 @@
+package es_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/es"
+)
+
+func TestCasualDate(t *testing.T) {
+	fixt := []Fixture{
+		{"Lo necesito ahora", 12, "ahora", 0},
+		{"Lo necesito hoy", 12, "hoy", 0},
+		{"Lo necesito esta noche", 12, "esta noche", 23 * time.Hour},
+		{"Lo necesito mañana", 12, "mañana", time.Hour * 24},
+		{"Lo necesito pasado mañana", 12, "pasado mañana", time.Hour * 48},
+		{"Lo necesitaba ayer", 14, "ayer", -(time.Hour * 24)},
+		{"Lo necesitaba anteayer", 14, "anteayer", -(time.Hour * 48)},
+		{"Lo necesito hasta mañana", 12, "hasta mañana", time.Hour * 24},
+	}
+
+	w := when.New(nil)
+	w.Add(es.CasualDate(rules.Skip))
+
+	ApplyFixtures(t, "es.CasualDate", w, fixt)
+}
+
+func TestCasualDateNil(t *testing.T) {
+	fixt := []Fixture{
+		{"por la mañana", 0, "", 0},
+		{"mañanas", 0, "", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(es.CasualDate(rules.Skip))
+
+	ApplyFixturesNil(t, "es.CasualDate nil", w, fixt)
+}
+
+func TestCasualTime(t *testing.T) {
+	fixt := []Fixture{
+		{"Lo necesito esta mañana", 12, "esta mañana", 8 * time.Hour},
+		{"Lo necesito al mediodía", 12, "al mediodía", 12 * time.Hour},
+		{"Lo necesito esta tarde", 12, "esta tarde", 15 * time.Hour},
+		{"Lo necesito por la noche", 12, "por la noche", 18 * time.Hour},
+		{"Lo necesito a medianoche", 12, "a medianoche", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(es.CasualTime(rules.Skip))
+
+	ApplyFixtures(t, "es.CasualTime", w, fixt)
+}
+
+func TestCasualDateCasualTime(t *testing.T) {
+	fixt := []Fixture{
+		{"Lo necesito mañana por la tarde", 12, "mañana por la tarde", (15 + 24) * time.Hour},
+		{"Lo necesito mañana por la noche", 12, "mañana por la noche", (18 + 24) * time.Hour},
+		{"Lo necesitaba ayer por la mañana", 14, "ayer por la mañana", -(24 - 8) * time.Hour},
+		{"Lo necesito hoy al mediodía", 12, "hoy al mediodía", 12 * time.Hour},
+		{"Lo necesito por la mañana", 12, "por la mañana", 8 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(
+		es.CasualDate(rules.Skip),
+		es.CasualTime(rules.Override),
+	)
+
+	ApplyFixtures(t, "es.CasualDate|es.CasualTime", w, fixt)
+}
@@ -0,0 +1,86 @@
+package es
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/pkg/errors"
+)
+
+func Deadline(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\P{L}|^)" +
+			"(en|dentro\\s+de)\\s*" +
+			"(media|medio|un\\s+par\\s+de|unos|unas|algunos|algunas|" + INTEGER_WORDS_PATTERN + "|[0-9]+)?\\s*" +
+			"(segundos?|minutos?|horas?|días?|dias?|semanas?|mes(?:es)?|años?|anos?)" +
+			"(?:\\P{L}|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if c.Duration != 0 && s != rules.Override {
+				return false, nil
+			}
+
+			numStr := strings.ToLower(strings.TrimSpace(m.Captures[1]))
+
+			var num int
+			var err error
+
+			if n, ok := INTEGER_WORDS[numStr]; ok {
+				num = n
+			} else if numStr == "" {
+				num = 1
+			} else if strings.Contains(numStr, "par") {
+				num = 2
+			} else if strings.HasPrefix(numStr, "un") || strings.HasPrefix(numStr, "algun") {
+				num = 3
+			} else if strings.HasPrefix(numStr, "medi") {
+				// pass
+			} else {
+				num, err = strconv.Atoi(numStr)
+				if err != nil {
+					return false, errors.Wrapf(err, "convert '%s' to int", numStr)
+				}
+			}
+
+			exponent := strings.ToLower(strings.TrimSpace(m.Captures[2]))
+
+			if !strings.HasPrefix(numStr, "medi") {
+				switch {
+				case strings.Contains(exponent, "segundo"):
+					c.Duration = time.Duration(num) * time.Second
+				case strings.Contains(exponent, "minuto"):
+					c.Duration = time.Duration(num) * time.Minute
+				case strings.Contains(exponent, "hora"):
+					c.Duration = time.Duration(num) * time.Hour
+				case strings.Contains(exponent, "día"), strings.Contains(exponent, "dia"):
+					c.Duration = time.Duration(num) * 24 * time.Hour
+				case strings.Contains(exponent, "semana"):
+					c.Duration = time.Duration(num) * 7 * 24 * time.Hour
+				case strings.Contains(exponent, "mes"):
+					c.Month = pointer.ToInt((int(ref.Month())+num-1)%12 + 1)
+				case strings.Contains(exponent, "año"), strings.Contains(exponent, "ano"):
+					c.Year = pointer.ToInt(ref.Year() + num)
+				}
+			} else {
+				switch {
+				case strings.Contains(exponent, "hora"):
+					c.Duration = 30 * time.Minute
+				case strings.Contains(exponent, "día"), strings.Contains(exponent, "dia"):
+					c.Duration = 12 * time.Hour
+				case strings.Contains(exponent, "semana"):
+					c.Duration = 7 * 12 * time.Hour
+				case strings.Contains(exponent, "mes"):
+					// 2 weeks
+					c.Duration = 14 * 24 * time.Hour
+				case strings.Contains(exponent, "año"), strings.Contains(exponent, "ano"):
+					c.Month = pointer.ToInt((int(ref.Month())+5)%12 + 1)
+				}
+			}
+
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package es_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/es"
+)
+
+func TestDeadline(t *testing.T) {
+	fixt := []Fixture{
+		{"hay que terminarlo en media hora", 19, "en media hora", time.Hour / 2},
+		{"hay que terminarlo dentro de una hora", 19, "dentro de una hora", time.Hour},
+		{"en 5 minutos", 0, "en 5 minutos", time.Minute * 5},
+		{"En 5 minutos me voy a casa.", 0, "En 5 minutos", time.Minute * 5},
+		{"Tenemos que hacerlo en 10 días.", 20, "en 10 días", 10 * 24 * time.Hour},
+		{"Tenemos que hacerlo en cinco días.", 20, "en cinco días", 5 * 24 * time.Hour},
+		{"en 5 segundos", 0, "en 5 segundos", 5 * time.Second},
+		{"dentro de dos semanas", 0, "dentro de dos semanas", 14 * 24 * time.Hour},
+		{"en un mes", 0, "en un mes", 31 * 24 * time.Hour},
+		{"en unos meses", 0, "en unos meses", 91 * 24 * time.Hour},
+		{"en un año", 0, "en un año", 366 * 24 * time.Hour},
+		{"en una semana", 0, "en una semana", 7 * 24 * time.Hour},
+		{"en un par de días", 0, "en un par de días", 2 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(es.Deadline(rules.Skip))
+
+	ApplyFixtures(t, "es.Deadline", w, fixt)
+}
@@ -0,0 +1,45 @@
+package es
+
+import "github.com/cirelion/flint/lib/when/rules"
+
+var All = []rules.Rule{
+	Weekday(rules.Override),
+	CasualDate(rules.Override),
+	CasualTime(rules.Override),
+	Hour(rules.Override),
+	HourMinute(rules.Override),
+	Deadline(rules.Override),
+}
+
+var WEEKDAY_OFFSET = map[string]int{
+	"domingo":   0,
+	"lunes":     1,
+	"martes":    2,
+	"miércoles": 3,
+	"miercoles": 3,
+	"jueves":    4,
+	"viernes":   5,
+	"sábado":    6,
+	"sabado":    6,
+}
+
+var WEEKDAY_OFFSET_PATTERN = "(?:domingo|lunes|martes|miércoles|miercoles|jueves|viernes|sábado|sabado)"
+
+var INTEGER_WORDS = map[string]int{
+	"un":     1,
+	"una":    1,
+	"uno":    1,
+	"dos":    2,
+	"tres":   3,
+	"cuatro": 4,
+	"cinco":  5,
+	"seis":   6,
+	"siete":  7,
+	"ocho":   8,
+	"nueve":  9,
+	"diez":   10,
+	"once":   11,
+	"doce":   12,
+}
+
+var INTEGER_WORDS_PATTERN = `(?:una|uno|un|dos|tres|cuatro|cinco|seis|siete|ocho|nueve|diez|once|doce)`
@@ -0,0 +1,59 @@
+package es_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules/es"
+	"github.com/stretchr/testify/require"
+)
+
+var null = time.Date(2016, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+type Fixture struct {
+	Text   string
+	Index  int
+	Phrase string
+	Diff   time.Duration
+}
+
+func ApplyFixtures(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d - %s", name, i, f.Text)
+		require.NotNil(t, res, "[%s] res #%d - %s", name, i, f.Text)
+		require.Equal(t, f.Index, res.Index, "[%s] index #%d - %s", name, i, f.Text)
+		require.Equal(t, f.Phrase, res.Text, "[%s] text #%d - %s", name, i, f.Text)
+		require.Equal(t, f.Diff, res.Time.Sub(null), "[%s] diff #%d - %s", name, i, f.Text)
+	}
+}
+
+func ApplyFixturesNil(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d", name, i)
+		require.Nil(t, res, "[%s] res #%d", name, i)
+	}
+}
+
+func TestAll(t *testing.T) {
+	w := when.New(nil)
+	w.Add(es.All...)
+
+	// complex cases
+	fixt := []Fixture{
+		{"mañana a las 23:10", 0, "mañana a las 23:10", (47 * time.Hour) + (10 * time.Minute)},
+		{"el próximo lunes por la noche", 0, "el próximo lunes por la noche", ((5 * 24) + 18) * time.Hour},
+		{"el viernes por la tarde", 0, "el viernes por la tarde", ((2 * 24) + 15) * time.Hour},
+		{"el martes que viene a las 14:00", 0, "el martes que viene a las 14:00", ((6 * 24) + 14) * time.Hour},
+		{"el próximo martes a las 2 de la tarde", 0, "el próximo martes a las 2 de la tarde", ((6 * 24) + 14) * time.Hour},
+		{"el martes pasado a las 11", 0, "el martes pasado a las 11", -13 * time.Hour},
+		{"escribir una carta el sábado por la tarde", 19, "el sábado por la tarde", ((3 * 24) + 15) * time.Hour},
+		{"mañana por la mañana", 0, "mañana por la mañana", (24 + 8) * time.Hour},
+		{"pasado mañana a las 9", 0, "pasado mañana a las 9", (48 + 9) * time.Hour},
+		{"en 2 horas", 0, "en 2 horas", 2 * time.Hour},
+	}
+
+	ApplyFixtures(t, "es.All...", w, fixt)
+}
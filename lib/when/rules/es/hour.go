@@ -0,0 +1,98 @@
+package es
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/pkg/errors"
+)
+
+/*
+	"a las 5"
+	"17 horas"
+	"a las 5 de la tarde"
+	"a la una de la madrugada"
+*/
+
+// a las 5 (horas)? (de la tarde)?
+// 1. - a las
+// 2. - int
+// 3. - horas? ext?
+// or 5 horas (de la tarde)? / 5 de la tarde, unless preceded by a deadline word
+// 4. - preceding word of a deadline, the hours are a duration if set
+// 5. - int
+// 6. - horas? ext
+
+func Hour(s rules.Strategy) rules.Rule {
+	num := "(" + INTEGER_WORDS_PATTERN + "|\\d{1,2})"
+	unit := "\\s*(?:horas?|hs|h)"
+	ext := "\\s*de\\s+la\\s+(?:mañana|manana|madrugada|tarde|noche)"
+
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:[^\\p{L}\\d]|^)(?:" +
+			"(a\\s+las?)\\s+" + num + "((?:" + unit + ")?(?:" + ext + ")?)" +
+			"|" +
+			"(?:(en|dentro\\s+de|hace|durante)\\s+)?" + num + "(" + unit + "(?:" + ext + ")?|" + ext + ")" +
+			")(?:[^\\p{L}\\d:.]|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if m.Captures[3] != "" {
+				return false, nil
+			}
+
+			numStr, tail := m.Captures[1], m.Captures[2]
+			if numStr == "" {
+				numStr, tail = m.Captures[4], m.Captures[5]
+			}
+
+			if c.Hour != nil && s != rules.Override {
+				return false, nil
+			}
+
+			var hour int
+			var err error
+
+			if n, ok := INTEGER_WORDS[strings.ToLower(numStr)]; ok {
+				hour = n
+			} else {
+				hour, err = strconv.Atoi(numStr)
+				if err != nil {
+					return false, errors.Wrap(err, "hour rule")
+				}
+			}
+
+			if hour > 23 {
+				return false, nil
+			}
+
+			ext := strings.ToLower(tail)
+			switch {
+			case !strings.Contains(ext, "de la"):
+			case strings.Contains(ext, "la ma"): // mañana, madrugada
+				if hour > 12 {
+					return false, nil
+				}
+				if hour == 12 {
+					hour = 0
+				}
+			default:
+				if hour > 12 {
+					return false, nil
+				}
+				if hour == 12 && strings.Contains(ext, "noche") {
+					hour = 0
+				} else if hour < 12 {
+					hour += 12
+				}
+			}
+
+			zero := 0
+			c.Hour = &hour
+			c.Minute = &zero
+
+			return true, nil
+		},
+	}
+}
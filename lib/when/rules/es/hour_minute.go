@@ -0,0 +1,64 @@
+package es
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/pkg/errors"
+)
+
+/*
+	"17:30"
+	"a las 17:30"
+	"5:30 de la tarde"
+*/
+
+// 1. - a las?
+// 2. - int
+// 3. - int
+// 4. - ext?
+
+func HourMinute(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\D|^)" +
+			"(?:(a\\s+las?)\\s+)?" +
+			"((?:[0-1]{0,1}[0-9])|(?:2[0-3]))" +
+			"(?:\\:|\\.|h)" +
+			"((?:[0-5][0-9]))" +
+			"(?:\\s*(de\\s+la\\s+(?:mañana|manana|madrugada|tarde|noche)))?" +
+			"(?:[^\\p{L}\\d.]|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if (c.Hour != nil || c.Minute != nil) && s != rules.Override {
+				return false, nil
+			}
+
+			hour, err := strconv.Atoi(m.Captures[1])
+			if err != nil {
+				return false, errors.Wrap(err, "hour minute rule")
+			}
+
+			minutes, err := strconv.Atoi(m.Captures[2])
+			if err != nil {
+				return false, errors.Wrap(err, "hour minute rule")
+			}
+
+			c.Minute = &minutes
+
+			ext := strings.ToLower(m.Captures[3])
+			if ext != "" {
+				if hour > 12 {
+					return false, nil
+				}
+				if !strings.Contains(ext, "ma") && hour < 12 { // pm
+					hour += 12
+				}
+			}
+			c.Hour = &hour
+
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package es_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/es"
+)
+
+func TestHourMinute(t *testing.T) {
+	fixt := []Fixture{
+		{"17:30", 0, "17:30", 17*time.Hour + 30*time.Minute},
+		{"a las 17:30", 0, "a las 17:30", 17*time.Hour + 30*time.Minute},
+		{"a las 5:30 de la tarde", 0, "a las 5:30 de la tarde", 17*time.Hour + 30*time.Minute},
+		{"a las 7.15 de la mañana", 0, "a las 7.15 de la mañana", 7*time.Hour + 15*time.Minute},
+		{"sobre las 23:45", 10, "23:45", 23*time.Hour + 45*time.Minute},
+	}
+
+	w := when.New(nil)
+	w.Add(es.HourMinute(rules.Override))
+
+	ApplyFixtures(t, "es.HourMinute", w, fixt)
+}
+
+func TestHourMinuteNil(t *testing.T) {
+	fixt := []Fixture{
+		{"el 17.03.2016", 0, "", 0},
+		{"27:30", 0, "", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(es.HourMinute(rules.Override))
+
+	ApplyFixturesNil(t, "es.HourMinute nil", w, fixt)
+}
@@ -0,0 +1,40 @@
+package es_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/es"
+)
+
+func TestHour(t *testing.T) {
+	fixt := []Fixture{
+		{"a las 17", 0, "a las 17", 17 * time.Hour},
+		{"a las 5 de la tarde", 0, "a las 5 de la tarde", 17 * time.Hour},
+		{"a las 10 de la mañana", 0, "a las 10 de la mañana", 10 * time.Hour},
+		{"a la una de la madrugada", 0, "a la una de la madrugada", time.Hour},
+		{"sobre las 9 horas", 10, "9 horas", 9 * time.Hour},
+		{"tres de la tarde", 0, "tres de la tarde", 15 * time.Hour},
+		{"a las 12 de la noche", 0, "a las 12 de la noche", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(es.Hour(rules.Override))
+
+	ApplyFixtures(t, "es.Hour", w, fixt)
+}
+
+func TestHourNil(t *testing.T) {
+	fixt := []Fixture{
+		{"en 2 horas", 0, "", 0},
+		{"compré 3 manzanas", 0, "", 0},
+		{"a las 17:30", 0, "", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(es.Hour(rules.Override))
+
+	ApplyFixturesNil(t, "es.Hour nil", w, fixt)
+}
@@ -0,0 +1,70 @@
+package es
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/lib/when/rules"
+)
+
+func Weekday(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\P{L}|^)" +
+			"(?:(el|este|pr[oó]ximo|pasado|[uú]ltimo|el\\s+pr[oó]ximo|el\\s+pasado|el\\s+[uú]ltimo)\\s*)?" +
+			"(" + WEEKDAY_OFFSET_PATTERN[3:] + // skip '(?:'
+			"(?:\\s*(que\\s+viene|pr[oó]ximo|pasado|(?:de\\s+)?(?:esta|la)\\s+semana(?:\\s+(?:que\\s+viene|pr[oó]xima|pasada))?))?" +
+			"(?:\\P{L}|$)"),
+
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			day := strings.ToLower(strings.TrimSpace(m.Captures[1]))
+			norm := m.Captures[2]
+			if norm == "" {
+				norm = m.Captures[0]
+			}
+			norm = strings.ToLower(strings.TrimSpace(norm))
+			if norm == "" || norm == "el" {
+				norm = "próximo"
+			}
+
+			dayInt, ok := WEEKDAY_OFFSET[day]
+			if !ok {
+				return false, nil
+			}
+
+			if c.Duration != 0 && s != rules.Override {
+				return false, nil
+			}
+
+			// Switch:
+			switch {
+			case strings.Contains(norm, "pasad") || strings.Contains(norm, "ltimo"):
+				diff := int(ref.Weekday()) - dayInt
+				if diff > 0 {
+					c.Duration = -time.Duration(diff*24) * time.Hour
+				} else if diff < 0 {
+					c.Duration = -time.Duration(7+diff) * 24 * time.Hour
+				} else {
+					c.Duration = -(7 * 24 * time.Hour)
+				}
+			case strings.Contains(norm, "ximo") || strings.Contains(norm, "xima") || strings.Contains(norm, "viene"):
+				diff := dayInt - int(ref.Weekday())
+				if diff > 0 {
+					c.Duration = time.Duration(diff*24) * time.Hour
+				} else if diff < 0 {
+					c.Duration = time.Duration(7+diff) * 24 * time.Hour
+				} else {
+					c.Duration = 7 * 24 * time.Hour
+				}
+			case strings.Contains(norm, "este") || strings.Contains(norm, "esta") || strings.Contains(norm, "semana"):
+				if int(ref.Weekday()) < dayInt {
+					c.Duration = time.Duration((dayInt-int(ref.Weekday()))*24) * time.Hour
+				} else if int(ref.Weekday()) > dayInt {
+					c.Duration = -time.Duration((int(ref.Weekday())-dayInt)*24) * time.Hour
+				}
+			}
+
+			return true, nil
+		},
+	}
+}
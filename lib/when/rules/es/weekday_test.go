@@ -0,0 +1,50 @@
+package es_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/es"
+)
+
+func TestWeekday(t *testing.T) {
+	// current is Wednesday
+	fixt := []Fixture{
+		// past/last
+		{"fue el lunes pasado", 4, "el lunes pasado", -(2 * 24 * time.Hour)},
+		{"el último sábado", 0, "el último sábado", -(4 * 24 * time.Hour)},
+		{"el miércoles pasado", 0, "el miércoles pasado", -(7 * 24 * time.Hour)},
+		{"martes de la semana pasada", 0, "martes de la semana pasada", -(24 * time.Hour)},
+
+		// next
+		{"el próximo martes", 0, "el próximo martes", 6 * 24 * time.Hour},
+		{"escríbeme el miércoles que viene", 11, "el miércoles que viene", 7 * 24 * time.Hour},
+		{"sábado de la semana que viene", 0, "sábado de la semana que viene", 3 * 24 * time.Hour},
+		{"el viernes", 0, "el viernes", 2 * 24 * time.Hour},
+		{"Domingo", 0, "Domingo", 4 * 24 * time.Hour},
+
+		// this
+		{"este martes", 0, "este martes", -(24 * time.Hour)},
+		{"este miércoles", 0, "este miércoles", 0},
+		{"el sabado de esta semana", 0, "el sabado de esta semana", 3 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(es.Weekday(rules.Override))
+
+	ApplyFixtures(t, "es.Weekday", w, fixt)
+}
+
+func TestWeekdayNil(t *testing.T) {
+	fixt := []Fixture{
+		{"mañana", 0, "", 0},
+		{"lunesito", 0, "", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(es.Weekday(rules.Override))
+
+	ApplyFixturesNil(t, "es.Weekday nil", w, fixt)
+}
@@ -0,0 +1,49 @@
+package fr
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cirelion/flint/lib/when/rules"
+)
+
+func CasualDate(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\P{L}|^)" +
+			"((?:jusqu['’]à\\s+|d['’]ici\\s+)?(?:maintenant|tout\\s+de\\s+suite|aujourd['’]hui|après-demain|apres-demain|demain|avant-hier|hier|cette\\s+nuit))" +
+			"(?:\\P{L}|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			lower := strings.ToLower(strings.TrimSpace(m.String()))
+
+			switch {
+			case strings.Contains(lower, "cette nuit"):
+				if (c.Hour == nil && c.Minute == nil) || s == rules.Override {
+					c.Hour = pointer.ToInt(23)
+					c.Minute = pointer.ToInt(0)
+				}
+			case strings.Contains(lower, "aujourd"):
+				// c.Hour = pointer.ToInt(18)
+			case strings.Contains(lower, "après-demain"), strings.Contains(lower, "apres-demain"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration += time.Hour * 48
+				}
+			case strings.Contains(lower, "demain"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration += time.Hour * 24
+				}
+			case strings.Contains(lower, "avant-hier"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration -= time.Hour * 48
+				}
+			case strings.Contains(lower, "hier"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration -= time.Hour * 24
+				}
+			}
+
+			return true, nil
+		},
+	}
+}
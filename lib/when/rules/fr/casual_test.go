@@ -0,0 +1,61 @@
+package fr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/fr"
+)
+
+func TestCasualDate(t *testing.T) {
+	fixt := []Fixture{
+		{"Il le faut maintenant", 11, "maintenant", 0},
+		{"Il le faut aujourd'hui", 11, "aujourd'hui", 0},
+		{"Il le faut cette nuit", 11, "cette nuit", 23 * time.Hour},
+		{"Il le faut demain", 11, "demain", time.Hour * 24},
+		{"Il le faut après-demain", 11, "après-demain", time.Hour * 48},
+		{"Il le fallait hier", 14, "hier", -(time.Hour * 24)},
+		{"Il le fallait avant-hier", 14, "avant-hier", -(time.Hour * 48)},
+		{"Il le faut d'ici demain", 11, "d'ici demain", time.Hour * 24},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.CasualDate(rules.Skip))
+
+	ApplyFixtures(t, "fr.CasualDate", w, fixt)
+}
+
+func TestCasualTime(t *testing.T) {
+	fixt := []Fixture{
+		{"Il le faut ce matin", 11, "ce matin", 8 * time.Hour},
+		{"Il le faut à midi", 11, "à midi", 12 * time.Hour},
+		{"Il le faut cet après-midi", 11, "cet après-midi", 15 * time.Hour},
+		{"Il le faut ce soir", 11, "ce soir", 18 * time.Hour},
+		{"Il le faut dans la soirée", 11, "dans la soirée", 18 * time.Hour},
+		{"Il le faut avant minuit", 11, "avant minuit", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.CasualTime(rules.Skip))
+
+	ApplyFixtures(t, "fr.CasualTime", w, fixt)
+}
+
+func TestCasualDateCasualTime(t *testing.T) {
+	fixt := []Fixture{
+		{"Il le faut demain après-midi", 11, "demain après-midi", (15 + 24) * time.Hour},
+		{"Il le faut demain soir", 11, "demain soir", (18 + 24) * time.Hour},
+		{"Il le fallait hier matin", 14, "hier matin", -(24 - 8) * time.Hour},
+		{"Il le faut aujourd'hui à midi", 11, "aujourd'hui à midi", 12 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(
+		fr.CasualDate(rules.Skip),
+		fr.CasualTime(rules.Override),
+	)
+
+	ApplyFixtures(t, "fr.CasualDate|fr.CasualTime", w, fixt)
+}
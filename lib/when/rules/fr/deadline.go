@@ -0,0 +1,84 @@
+package fr
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/pkg/errors"
+)
+
+func Deadline(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\P{L}|^)" +
+			"(dans|d['’]ici|en)\\s*" +
+			"((?:une\\s+)?demie?|quelques|" + INTEGER_WORDS_PATTERN + "|[0-9]+)?[\\s-]*" +
+			"(secondes?|minutes?|heures?|jours?|semaines?|mois|ans|an|années?)" +
+			"(?:\\P{L}|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if c.Duration != 0 && s != rules.Override {
+				return false, nil
+			}
+
+			numStr := strings.ToLower(strings.TrimSpace(m.Captures[1]))
+
+			var num int
+			var err error
+
+			if n, ok := INTEGER_WORDS[numStr]; ok {
+				num = n
+			} else if numStr == "" {
+				num = 1
+			} else if strings.Contains(numStr, "quelques") {
+				num = 3
+			} else if strings.Contains(numStr, "demi") {
+				// pass
+			} else {
+				num, err = strconv.Atoi(numStr)
+				if err != nil {
+					return false, errors.Wrapf(err, "convert '%s' to int", numStr)
+				}
+			}
+
+			exponent := strings.ToLower(strings.TrimSpace(m.Captures[2]))
+
+			if !strings.Contains(numStr, "demi") {
+				switch {
+				case strings.Contains(exponent, "seconde"):
+					c.Duration = time.Duration(num) * time.Second
+				case strings.Contains(exponent, "minute"):
+					c.Duration = time.Duration(num) * time.Minute
+				case strings.Contains(exponent, "heure"):
+					c.Duration = time.Duration(num) * time.Hour
+				case strings.Contains(exponent, "jour"):
+					c.Duration = time.Duration(num) * 24 * time.Hour
+				case strings.Contains(exponent, "semaine"):
+					c.Duration = time.Duration(num) * 7 * 24 * time.Hour
+				case strings.Contains(exponent, "mois"):
+					c.Month = pointer.ToInt((int(ref.Month())+num-1)%12 + 1)
+				case strings.Contains(exponent, "an"):
+					c.Year = pointer.ToInt(ref.Year() + num)
+				}
+			} else {
+				switch {
+				case strings.Contains(exponent, "heure"):
+					c.Duration = 30 * time.Minute
+				case strings.Contains(exponent, "jour"):
+					c.Duration = 12 * time.Hour
+				case strings.Contains(exponent, "semaine"):
+					c.Duration = 7 * 12 * time.Hour
+				case strings.Contains(exponent, "mois"):
+					// 2 weeks
+					c.Duration = 14 * 24 * time.Hour
+				case strings.Contains(exponent, "an"):
+					c.Month = pointer.ToInt((int(ref.Month())+5)%12 + 1)
+				}
+			}
+
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package fr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/fr"
+)
+
+func TestDeadline(t *testing.T) {
+	fixt := []Fixture{
+		{"il faut le finir dans une demi-heure", 17, "dans une demi-heure", time.Hour / 2},
+		{"il faut le finir d'ici une heure", 17, "d'ici une heure", time.Hour},
+		{"dans 5 minutes", 0, "dans 5 minutes", time.Minute * 5},
+		{"Dans 5 minutes je rentre.", 0, "Dans 5 minutes", time.Minute * 5},
+		{"On doit le faire en 10 jours.", 17, "en 10 jours", 10 * 24 * time.Hour},
+		{"On doit le faire en cinq jours.", 17, "en cinq jours", 5 * 24 * time.Hour},
+		{"dans 5 secondes", 0, "dans 5 secondes", 5 * time.Second},
+		{"d'ici deux semaines", 0, "d'ici deux semaines", 14 * 24 * time.Hour},
+		{"dans un mois", 0, "dans un mois", 31 * 24 * time.Hour},
+		{"dans quelques mois", 0, "dans quelques mois", 91 * 24 * time.Hour},
+		{"dans un an", 0, "dans un an", 366 * 24 * time.Hour},
+		{"dans une semaine", 0, "dans une semaine", 7 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.Deadline(rules.Skip))
+
+	ApplyFixtures(t, "fr.Deadline", w, fixt)
+}
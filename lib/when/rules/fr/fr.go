@@ -0,0 +1,42 @@
+package fr
+
+import "github.com/cirelion/flint/lib/when/rules"
+
+var All = []rules.Rule{
+	Weekday(rules.Override),
+	CasualDate(rules.Override),
+	CasualTime(rules.Override),
+	Hour(rules.Override),
+	HourMinute(rules.Override),
+	Deadline(rules.Override),
+}
+
+var WEEKDAY_OFFSET = map[string]int{
+	"dimanche": 0,
+	"lundi":    1,
+	"mardi":    2,
+	"mercredi": 3,
+	"jeudi":    4,
+	"vendredi": 5,
+	"samedi":   6,
+}
+
+var WEEKDAY_OFFSET_PATTERN = "(?:dimanche|lundi|mardi|mercredi|jeudi|vendredi|samedi)"
+
+var INTEGER_WORDS = map[string]int{
+	"un":     1,
+	"une":    1,
+	"deux":   2,
+	"trois":  3,
+	"quatre": 4,
+	"cinq":   5,
+	"six":    6,
+	"sept":   7,
+	"huit":   8,
+	"neuf":   9,
+	"dix":    10,
+	"onze":   11,
+	"douze":  12,
+}
+
+var INTEGER_WORDS_PATTERN = `(?:une|un|deux|trois|quatre|cinq|six|sept|huit|neuf|dix|onze|douze)`
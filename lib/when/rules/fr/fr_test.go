@@ -0,0 +1,59 @@
+package fr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules/fr"
+	"github.com/stretchr/testify/require"
+)
+
+var null = time.Date(2016, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+type Fixture struct {
+	Text   string
+	Index  int
+	Phrase string
+	Diff   time.Duration
+}
+
+func ApplyFixtures(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d - %s", name, i, f.Text)
+		require.NotNil(t, res, "[%s] res #%d - %s", name, i, f.Text)
+		require.Equal(t, f.Index, res.Index, "[%s] index #%d - %s", name, i, f.Text)
+		require.Equal(t, f.Phrase, res.Text, "[%s] text #%d - %s", name, i, f.Text)
+		require.Equal(t, f.Diff, res.Time.Sub(null), "[%s] diff #%d - %s", name, i, f.Text)
+	}
+}
+
+func ApplyFixturesNil(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d", name, i)
+		require.Nil(t, res, "[%s] res #%d", name, i)
+	}
+}
+
+func TestAll(t *testing.T) {
+	w := when.New(nil)
+	w.Add(fr.All...)
+
+	// complex cases
+	fixt := []Fixture{
+		{"demain à 23h10", 0, "demain à 23h10", (47 * time.Hour) + (10 * time.Minute)},
+		{"lundi prochain au soir", 0, "lundi prochain au soir", ((5 * 24) + 18) * time.Hour},
+		{"vendredi après-midi", 0, "vendredi après-midi", ((2 * 24) + 15) * time.Hour},
+		{"mardi prochain à 14:00", 0, "mardi prochain à 14:00", ((6 * 24) + 14) * time.Hour},
+		{"mardi prochain à 14 heures", 0, "mardi prochain à 14 heures", ((6 * 24) + 14) * time.Hour},
+		{"mardi dernier à 11h", 0, "mardi dernier à 11h", -13 * time.Hour},
+		{"écrire une lettre samedi soir", 19, "samedi soir", ((3 * 24) + 18) * time.Hour},
+		{"demain matin", 0, "demain matin", (24 + 8) * time.Hour},
+		{"après-demain à 9h", 0, "après-demain à 9h", (48 + 9) * time.Hour},
+		{"dans 2 heures", 0, "dans 2 heures", 2 * time.Hour},
+	}
+
+	ApplyFixtures(t, "fr.All...", w, fixt)
+}
@@ -0,0 +1,84 @@
+package fr
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/pkg/errors"
+)
+
+/*
+	"17h"
+	"17 heures"
+	"5 heures du soir"
+	"à trois heures de l'après-midi"
+*/
+
+// 1. - preceding word of a deadline, the hours are a duration if set
+// 2. - int
+// 3. - heures
+// 4. - ext?
+
+func Hour(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:[^\\p{L}\\d]|^)" +
+			"(?:(dans|d['’]ici|en|pendant|depuis|il\\s+y\\s+a)\\s+)?" +
+			"(" + INTEGER_WORDS_PATTERN + "|\\d{1,2})" +
+			"(\\s*(?:heures?|h))" +
+			"(?:\\s*(du\\s+matin|de\\s+l['’]après-midi|de\\s+l['’]apres-midi|du\\s+soir))?" +
+			"(?:[^\\p{L}\\d]|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if m.Captures[0] != "" {
+				return false, nil
+			}
+
+			if c.Hour != nil && s != rules.Override {
+				return false, nil
+			}
+
+			var hour int
+			var err error
+
+			if n, ok := INTEGER_WORDS[strings.ToLower(m.Captures[1])]; ok {
+				hour = n
+			} else {
+				hour, err = strconv.Atoi(m.Captures[1])
+				if err != nil {
+					return false, errors.Wrap(err, "hour rule")
+				}
+			}
+
+			if hour > 23 {
+				return false, nil
+			}
+
+			ext := strings.ToLower(m.Captures[3])
+			switch {
+			case ext == "":
+			case strings.Contains(ext, "matin"):
+				if hour > 12 {
+					return false, nil
+				}
+				if hour == 12 {
+					hour = 0
+				}
+			default:
+				if hour > 12 {
+					return false, nil
+				}
+				if hour < 12 {
+					hour += 12
+				}
+			}
+
+			zero := 0
+			c.Hour = &hour
+			c.Minute = &zero
+
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package fr
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/pkg/errors"
+)
+
+/*
+	"17:30"
+	"17h30"
+	"5h30 du soir"
+*/
+
+// 1. - int
+// 2. - int
+// 3. - ext?
+
+func HourMinute(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\D|^)" +
+			"((?:[0-1]{0,1}[0-9])|(?:2[0-3]))" +
+			"(?:\\:|h|\\.)" +
+			"((?:[0-5][0-9]))" +
+			"(?:\\s*(du\\s+matin|de\\s+l['’]après-midi|de\\s+l['’]apres-midi|du\\s+soir))?" +
+			"(?:[^\\p{L}\\d.]|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if (c.Hour != nil || c.Minute != nil) && s != rules.Override {
+				return false, nil
+			}
+
+			hour, err := strconv.Atoi(m.Captures[0])
+			if err != nil {
+				return false, errors.Wrap(err, "hour minute rule")
+			}
+
+			minutes, err := strconv.Atoi(m.Captures[1])
+			if err != nil {
+				return false, errors.Wrap(err, "hour minute rule")
+			}
+
+			c.Minute = &minutes
+
+			ext := strings.ToLower(m.Captures[2])
+			if ext != "" {
+				if hour > 12 {
+					return false, nil
+				}
+				if !strings.Contains(ext, "matin") && hour < 12 { // pm
+					hour += 12
+				}
+			}
+			c.Hour = &hour
+
+			return true, nil
+		},
+	}
+}
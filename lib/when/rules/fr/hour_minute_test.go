@@ -0,0 +1,37 @@
+package fr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/fr"
+)
+
+func TestHourMinute(t *testing.T) {
+	fixt := []Fixture{
+		{"17:30", 0, "17:30", 17*time.Hour + 30*time.Minute},
+		{"à 17h30", 3, "17h30", 17*time.Hour + 30*time.Minute},
+		{"à 5h30 du soir", 3, "5h30 du soir", 17*time.Hour + 30*time.Minute},
+		{"à 7.15 du matin", 3, "7.15 du matin", 7*time.Hour + 15*time.Minute},
+		{"vers 23:45", 5, "23:45", 23*time.Hour + 45*time.Minute},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.HourMinute(rules.Override))
+
+	ApplyFixtures(t, "fr.HourMinute", w, fixt)
+}
+
+func TestHourMinuteNil(t *testing.T) {
+	fixt := []Fixture{
+		{"le 17.03.2016", 0, "", 0},
+		{"27:30", 0, "", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.HourMinute(rules.Override))
+
+	ApplyFixturesNil(t, "fr.HourMinute nil", w, fixt)
+}
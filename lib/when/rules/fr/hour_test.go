@@ -0,0 +1,39 @@
+package fr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/fr"
+)
+
+func TestHour(t *testing.T) {
+	fixt := []Fixture{
+		{"17h", 0, "17h", 17 * time.Hour},
+		{"à 5 heures du soir", 3, "5 heures du soir", 17 * time.Hour},
+		{"à 10 heures du matin", 3, "10 heures du matin", 10 * time.Hour},
+		{"à trois heures de l'après-midi", 3, "trois heures de l'après-midi", 15 * time.Hour},
+		{"vers 9 h", 5, "9 h", 9 * time.Hour},
+		{"à une heure", 3, "une heure", time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.Hour(rules.Override))
+
+	ApplyFixtures(t, "fr.Hour", w, fixt)
+}
+
+func TestHourNil(t *testing.T) {
+	fixt := []Fixture{
+		{"dans 2 heures", 0, "", 0},
+		{"il y a 3 heures", 0, "", 0},
+		{"17h30", 0, "", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.Hour(rules.Override))
+
+	ApplyFixturesNil(t, "fr.Hour nil", w, fixt)
+}
@@ -0,0 +1,50 @@
+package fr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/fr"
+)
+
+func TestWeekday(t *testing.T) {
+	// current is Wednesday
+	fixt := []Fixture{
+		// past/last
+		{"c'était lundi dernier", 9, "lundi dernier", -(2 * 24 * time.Hour)},
+		{"samedi passé", 0, "samedi passé", -(4 * 24 * time.Hour)},
+		{"dernier mercredi", 0, "dernier mercredi", -(7 * 24 * time.Hour)},
+		{"mardi de la semaine dernière", 0, "mardi de la semaine dernière", -(24 * time.Hour)},
+
+		// next
+		{"mardi prochain", 0, "mardi prochain", 6 * 24 * time.Hour},
+		{"écris-moi mercredi prochain", 11, "mercredi prochain", 7 * 24 * time.Hour},
+		{"samedi de la semaine prochaine", 0, "samedi de la semaine prochaine", 3 * 24 * time.Hour},
+		{"vendredi", 0, "vendredi", 2 * 24 * time.Hour},
+		{"Dimanche", 0, "Dimanche", 4 * 24 * time.Hour},
+
+		// this
+		{"ce mardi", 0, "ce mardi", -(24 * time.Hour)},
+		{"ce mercredi", 0, "ce mercredi", 0},
+		{"samedi de cette semaine", 0, "samedi de cette semaine", 3 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.Weekday(rules.Override))
+
+	ApplyFixtures(t, "fr.Weekday", w, fixt)
+}
+
+func TestWeekdayNil(t *testing.T) {
+	fixt := []Fixture{
+		{"demain", 0, "", 0},
+		{"lundis", 0, "", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(fr.Weekday(rules.Override))
+
+	ApplyFixturesNil(t, "fr.Weekday nil", w, fixt)
+}
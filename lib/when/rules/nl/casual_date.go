@@ -0,0 +1,76 @@
+package nl
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cirelion/flint/lib/when/rules"
+)
+
+func CasualDate(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\P{L}|^)" +
+			"((?:tot\\s+)?(?:nu|meteen|vandaag|vannacht|overmorgen|morgen(?:ochtend|middag|avond)?|eergisteren|gisteren(?:ochtend|middag|avond)?))" +
+			"(?:\\P{L}|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			lower := strings.ToLower(strings.TrimSpace(m.String()))
+
+			switch {
+			case strings.Contains(lower, "vannacht"):
+				if (c.Hour == nil && c.Minute == nil) || s == rules.Override {
+					c.Hour = pointer.ToInt(23)
+					c.Minute = pointer.ToInt(0)
+				}
+			case strings.Contains(lower, "vandaag"):
+				// c.Hour = pointer.ToInt(18)
+			case strings.Contains(lower, "overmorgen"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration += time.Hour * 48
+				}
+			case strings.Contains(lower, "morgen"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration += time.Hour * 24
+				}
+			case strings.Contains(lower, "eergisteren"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration -= time.Hour * 48
+				}
+			case strings.Contains(lower, "gisteren"):
+				if c.Duration == 0 || s == rules.Override {
+					c.Duration -= time.Hour * 24
+				}
+			}
+
+			// "morgenavond", "gisterenochtend"
+			if (c.Hour == nil && c.Minute == nil) || s == rules.Override {
+				switch {
+				case strings.HasSuffix(lower, "ochtend"):
+					if o.Morning != 0 {
+						c.Hour = &o.Morning
+					} else {
+						c.Hour = pointer.ToInt(8)
+					}
+					c.Minute = pointer.ToInt(0)
+				case strings.HasSuffix(lower, "middag"):
+					if o.Afternoon != 0 {
+						c.Hour = &o.Afternoon
+					} else {
+						c.Hour = pointer.ToInt(15)
+					}
+					c.Minute = pointer.ToInt(0)
+				case strings.HasSuffix(lower, "avond"):
+					if o.Evening != 0 {
+						c.Hour = &o.Evening
+					} else {
+						c.Hour = pointer.ToInt(18)
+					}
+					c.Minute = pointer.ToInt(0)
+				}
+			}
+
+			return true, nil
+		},
+	}
+}
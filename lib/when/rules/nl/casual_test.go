@@ -0,0 +1,73 @@
+package nl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/nl"
+)
+
+func TestCasualDate(t *testing.T) {
+	fixt := []Fixture{
+		{"Ik heb het nu nodig", 11, "nu", 0},
+		{"Ik heb het vandaag nodig", 11, "vandaag", 0},
+		{"Ik heb het vannacht nodig", 11, "vannacht", 23 * time.Hour},
+		{"Ik heb het morgen nodig", 11, "morgen", time.Hour * 24},
+		{"Ik heb het overmorgen nodig", 11, "overmorgen", time.Hour * 48},
+		{"Ik had het gisteren nodig", 11, "gisteren", -(time.Hour * 24)},
+		{"Ik had het eergisteren nodig", 11, "eergisteren", -(time.Hour * 48)},
+		{"Ik heb het tot morgen nodig", 11, "tot morgen", time.Hour * 24},
+		{"Ik heb het morgenavond nodig", 11, "morgenavond", (24 + 18) * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(nl.CasualDate(rules.Skip))
+
+	ApplyFixtures(t, "nl.CasualDate", w, fixt)
+}
+
+func TestCasualDateNil(t *testing.T) {
+	fixt := []Fixture{
+		{"'s morgens", 0, "", 0},
+		{"nummer", 0, "", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(nl.CasualDate(rules.Skip))
+
+	ApplyFixturesNil(t, "nl.CasualDate nil", w, fixt)
+}
+
+func TestCasualTime(t *testing.T) {
+	fixt := []Fixture{
+		{"Ik heb het vanochtend nodig", 11, "vanochtend", 8 * time.Hour},
+		{"Ik heb het 's morgens nodig", 11, "'s morgens", 8 * time.Hour},
+		{"Ik heb het vanmiddag nodig", 11, "vanmiddag", 15 * time.Hour},
+		{"Ik heb het vanavond nodig", 11, "vanavond", 18 * time.Hour},
+		{"Ik heb het in de avond nodig", 11, "in de avond", 18 * time.Hour},
+		{"Ik heb het om middernacht nodig", 11, "om middernacht", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(nl.CasualTime(rules.Skip))
+
+	ApplyFixtures(t, "nl.CasualTime", w, fixt)
+}
+
+func TestCasualDateCasualTime(t *testing.T) {
+	fixt := []Fixture{
+		{"Ik heb het morgen in de middag nodig", 11, "morgen in de middag", (15 + 24) * time.Hour},
+		{"Ik heb het morgen 's avonds nodig", 11, "morgen 's avonds", (18 + 24) * time.Hour},
+		{"Ik had het gisteren 's ochtends nodig", 11, "gisteren 's ochtends", -(24 - 8) * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(
+		nl.CasualDate(rules.Skip),
+		nl.CasualTime(rules.Override),
+	)
+
+	ApplyFixtures(t, "nl.CasualDate|nl.CasualTime", w, fixt)
+}
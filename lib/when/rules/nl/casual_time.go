@@ -0,0 +1,55 @@
+package nl
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cirelion/flint/lib/when/rules"
+)
+
+func CasualTime(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile(`(?i)(?:\P{L}|^)(['’]s\s*(?:ochtends|morgens|middags|avonds|nachts)|(?:(?:in|tegen|voor)\s+de|deze)\s+(?:ochtend|middag|avond)|van(?:ochtend|morgen|middag|avond)|(?:om\s+)?middernacht)(?:\P{L}|$)`),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			lower := strings.ToLower(strings.TrimSpace(m.String()))
+
+			if (c.Hour != nil || c.Minute != nil) && s == rules.Override {
+				return false, nil
+			}
+
+			switch {
+			case strings.Contains(lower, "middernacht"):
+				c.Hour = pointer.ToInt(0)
+				c.Minute = pointer.ToInt(0)
+			case strings.Contains(lower, "middag"):
+				if o.Afternoon != 0 {
+					c.Hour = &o.Afternoon
+				} else {
+					c.Hour = pointer.ToInt(15)
+				}
+				c.Minute = pointer.ToInt(0)
+			case strings.Contains(lower, "avond"):
+				if o.Evening != 0 {
+					c.Hour = &o.Evening
+				} else {
+					c.Hour = pointer.ToInt(18)
+				}
+				c.Minute = pointer.ToInt(0)
+			case strings.Contains(lower, "nacht"):
+				c.Hour = pointer.ToInt(23)
+				c.Minute = pointer.ToInt(0)
+			case strings.Contains(lower, "ochtend"), strings.Contains(lower, "morgen"):
+				if o.Morning != 0 {
+					c.Hour = &o.Morning
+				} else {
+					c.Hour = pointer.ToInt(8)
+				}
+				c.Minute = pointer.ToInt(0)
+			}
+
+			return true, nil
+		},
+	}
+}
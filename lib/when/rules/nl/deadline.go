@@ -0,0 +1,84 @@
+package nl
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/pkg/errors"
+)
+
+func Deadline(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\P{L}|^)" +
+			"(over|binnen|in)\\s*" +
+			"(een\\s+half|half|een\\s+paar|paar|enkele|" + INTEGER_WORDS_PATTERN + "|[0-9]+)?\\s*" +
+			"(seconden?|minuten|minuut|uren|uur|dagen|dag|weken|week|maanden|maand|jaren|jaar)" +
+			"(?:\\P{L}|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if c.Duration != 0 && s != rules.Override {
+				return false, nil
+			}
+
+			numStr := strings.ToLower(strings.TrimSpace(m.Captures[1]))
+
+			var num int
+			var err error
+
+			if n, ok := INTEGER_WORDS[numStr]; ok {
+				num = n
+			} else if numStr == "" {
+				num = 1
+			} else if strings.Contains(numStr, "paar") || numStr == "enkele" {
+				num = 3
+			} else if strings.Contains(numStr, "half") {
+				// pass
+			} else {
+				num, err = strconv.Atoi(numStr)
+				if err != nil {
+					return false, errors.Wrapf(err, "convert '%s' to int", numStr)
+				}
+			}
+
+			exponent := strings.ToLower(strings.TrimSpace(m.Captures[2]))
+
+			if !strings.Contains(numStr, "half") {
+				switch {
+				case strings.Contains(exponent, "seconde"):
+					c.Duration = time.Duration(num) * time.Second
+				case strings.HasPrefix(exponent, "minu"):
+					c.Duration = time.Duration(num) * time.Minute
+				case exponent == "uur", exponent == "uren":
+					c.Duration = time.Duration(num) * time.Hour
+				case strings.Contains(exponent, "dag"):
+					c.Duration = time.Duration(num) * 24 * time.Hour
+				case strings.HasPrefix(exponent, "we"):
+					c.Duration = time.Duration(num) * 7 * 24 * time.Hour
+				case strings.Contains(exponent, "maand"):
+					c.Month = pointer.ToInt((int(ref.Month())+num-1)%12 + 1)
+				case strings.HasPrefix(exponent, "ja"):
+					c.Year = pointer.ToInt(ref.Year() + num)
+				}
+			} else {
+				switch {
+				case exponent == "uur", exponent == "uren":
+					c.Duration = 30 * time.Minute
+				case strings.Contains(exponent, "dag"):
+					c.Duration = 12 * time.Hour
+				case strings.HasPrefix(exponent, "we"):
+					c.Duration = 7 * 12 * time.Hour
+				case strings.Contains(exponent, "maand"):
+					// 2 weeks
+					c.Duration = 14 * 24 * time.Hour
+				case strings.HasPrefix(exponent, "ja"):
+					c.Month = pointer.ToInt((int(ref.Month())+5)%12 + 1)
+				}
+			}
+
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package nl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/nl"
+)
+
+func TestDeadline(t *testing.T) {
+	fixt := []Fixture{
+		{"het moet binnen een half uur klaar zijn", 9, "binnen een half uur", time.Hour / 2},
+		{"het moet binnen een uur klaar zijn", 9, "binnen een uur", time.Hour},
+		{"over 5 minuten", 0, "over 5 minuten", time.Minute * 5},
+		{"Over 5 minuten ga ik naar huis.", 0, "Over 5 minuten", time.Minute * 5},
+		{"We moeten het in 10 dagen doen.", 14, "in 10 dagen", 10 * 24 * time.Hour},
+		{"We moeten het in vijf dagen doen.", 14, "in vijf dagen", 5 * 24 * time.Hour},
+		{"over 5 seconden", 0, "over 5 seconden", 5 * time.Second},
+		{"binnen twee weken", 0, "binnen twee weken", 14 * 24 * time.Hour},
+		{"over een maand", 0, "over een maand", 31 * 24 * time.Hour},
+		{"over een paar maanden", 0, "over een paar maanden", 91 * 24 * time.Hour},
+		{"over een jaar", 0, "over een jaar", 366 * 24 * time.Hour},
+		{"over een week", 0, "over een week", 7 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(nl.Deadline(rules.Skip))
+
+	ApplyFixtures(t, "nl.Deadline", w, fixt)
+}
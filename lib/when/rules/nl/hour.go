@@ -0,0 +1,84 @@
+package nl
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/pkg/errors"
+)
+
+/*
+	"17 uur"
+	"om 9u"
+	"om 5 uur 's middags"
+	"om twee uur 's nachts"
+*/
+
+// 1. - preceding word of a deadline, the hours are a duration if set
+// 2. - int
+// 3. - uur
+// 4. - ext?
+
+func Hour(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:[^\\p{L}\\d]|^)" +
+			"(?:(over|binnen|in|na|sinds)\\s+)?" +
+			"(" + INTEGER_WORDS_PATTERN + "|\\d{1,2})" +
+			"(\\s*(?:uur|u))" +
+			"(?:\\s*(['’]s\\s*(?:ochtends|morgens|middags|avonds|nachts)|in\\s+de\\s+(?:ochtend|middag|avond|nacht)))?" +
+			"(?:[^\\p{L}\\d]|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if m.Captures[0] != "" {
+				return false, nil
+			}
+
+			if c.Hour != nil && s != rules.Override {
+				return false, nil
+			}
+
+			var hour int
+			var err error
+
+			if n, ok := INTEGER_WORDS[strings.ToLower(m.Captures[1])]; ok {
+				hour = n
+			} else {
+				hour, err = strconv.Atoi(m.Captures[1])
+				if err != nil {
+					return false, errors.Wrap(err, "hour rule")
+				}
+			}
+
+			if hour > 23 {
+				return false, nil
+			}
+
+			ext := strings.ToLower(m.Captures[3])
+			switch {
+			case ext == "":
+			case strings.Contains(ext, "middag") || strings.Contains(ext, "avond"):
+				if hour > 12 {
+					return false, nil
+				}
+				if hour < 12 {
+					hour += 12
+				}
+			default:
+				if hour > 12 {
+					return false, nil
+				}
+				if hour == 12 {
+					hour = 0
+				}
+			}
+
+			zero := 0
+			c.Hour = &hour
+			c.Minute = &zero
+
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package nl
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/pkg/errors"
+)
+
+/*
+	"17:30"
+	"17.30 uur"
+	"5:30 's avonds"
+*/
+
+// 1. - int
+// 2. - int
+// 3. - uur?
+// 4. - ext?
+
+func HourMinute(s rules.Strategy) rules.Rule {
+	return &rules.F{
+		RegExp: regexp.MustCompile("(?i)(?:\\D|^)" +
+			"((?:[0-1]{0,1}[0-9])|(?:2[0-3]))" +
+			"(?:\\:|\\.)" +
+			"((?:[0-5][0-9]))" +
+			"(\\s*uur)?" +
+			"(?:\\s*(['’]s\\s*(?:ochtends|morgens|middags|avonds|nachts)|in\\s+de\\s+(?:ochtend|middag|avond|nacht)))?" +
+			"(?:[^\\p{L}\\d.]|$)"),
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if (c.Hour != nil || c.Minute != nil) && s != rules.Override {
+				return false, nil
+			}
+
+			hour, err := strconv.Atoi(m.Captures[0])
+			if err != nil {
+				return false, errors.Wrap(err, "hour minute rule")
+			}
+
+			minutes, err := strconv.Atoi(m.Captures[1])
+			if err != nil {
+				return false, errors.Wrap(err, "hour minute rule")
+			}
+
+			c.Minute = &minutes
+
+			ext := strings.ToLower(m.Captures[3])
+			if ext != "" {
+				if hour > 12 {
+					return false, nil
+				}
+				if strings.Contains(ext, "middag") || strings.Contains(ext, "avond") {
+					if hour < 12 { // pm
+						hour += 12
+					}
+				} else if hour == 12 {
+					hour = 0
+				}
+			}
+			c.Hour = &hour
+
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package nl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/nl"
+)
+
+func TestHourMinute(t *testing.T) {
+	fixt := []Fixture{
+		{"17:30", 0, "17:30", 17*time.Hour + 30*time.Minute},
+		{"om 17.30 uur", 3, "17.30 uur", 17*time.Hour + 30*time.Minute},
+		{"om 5:30 's middags", 3, "5:30 's middags", 17*time.Hour + 30*time.Minute},
+		{"om 7.15 's ochtends", 3, "7.15 's ochtends", 7*time.Hour + 15*time.Minute},
+		{"rond 23:45", 5, "23:45", 23*time.Hour + 45*time.Minute},
+	}
+
+	w := when.New(nil)
+	w.Add(nl.HourMinute(rules.Override))
+
+	ApplyFixtures(t, "nl.HourMinute", w, fixt)
+}
+
+func TestHourMinuteNil(t *testing.T) {
+	fixt := []Fixture{
+		{"op 17.03.2016", 0, "", 0},
+		{"27:30", 0, "", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(nl.HourMinute(rules.Override))
+
+	ApplyFixturesNil(t, "nl.HourMinute nil", w, fixt)
+}
@@ -0,0 +1,39 @@
+package nl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/nl"
+)
+
+func TestHour(t *testing.T) {
+	fixt := []Fixture{
+		{"17 uur", 0, "17 uur", 17 * time.Hour},
+		{"om 5 uur 's middags", 3, "5 uur 's middags", 17 * time.Hour},
+		{"om 10 uur 's ochtends", 3, "10 uur 's ochtends", 10 * time.Hour},
+		{"om twee uur 's nachts", 3, "twee uur 's nachts", 2 * time.Hour},
+		{"om 9u", 3, "9u", 9 * time.Hour},
+		{"om 8 uur in de avond", 3, "8 uur in de avond", 20 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(nl.Hour(rules.Override))
+
+	ApplyFixtures(t, "nl.Hour", w, fixt)
+}
+
+func TestHourNil(t *testing.T) {
+	fixt := []Fixture{
+		{"over 2 uur", 0, "", 0},
+		{"binnen 3 uur", 0, "", 0},
+		{"17 uren", 0, "", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(nl.Hour(rules.Override))
+
+	ApplyFixturesNil(t, "nl.Hour nil", w, fixt)
+}
@@ -0,0 +1,42 @@
+package nl
+
+import "github.com/cirelion/flint/lib/when/rules"
+
+var All = []rules.Rule{
+	Weekday(rules.Override),
+	CasualDate(rules.Override),
+	CasualTime(rules.Override),
+	Hour(rules.Override),
+	HourMinute(rules.Override),
+	Deadline(rules.Override),
+}
+
+var WEEKDAY_OFFSET = map[string]int{
+	"zondag":    0,
+	"maandag":   1,
+	"dinsdag":   2,
+	"woensdag":  3,
+	"donderdag": 4,
+	"vrijdag":   5,
+	"zaterdag":  6,
+}
+
+var WEEKDAY_OFFSET_PATTERN = "(?:zondag|maandag|dinsdag|woensdag|donderdag|vrijdag|zaterdag)"
+
+var INTEGER_WORDS = map[string]int{
+	"een":    1,
+	"één":    1,
+	"twee":   2,
+	"drie":   3,
+	"vier":   4,
+	"vijf":   5,
+	"zes":    6,
+	"zeven":  7,
+	"acht":   8,
+	"negen":  9,
+	"tien":   10,
+	"elf":    11,
+	"twaalf": 12,
+}
+
+var INTEGER_WORDS_PATTERN = `(?:een|één|twee|drie|vier|vijf|zes|zeven|acht|negen|tien|elf|twaalf)`
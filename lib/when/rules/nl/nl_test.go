@@ -0,0 +1,59 @@
+package nl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules/nl"
+	"github.com/stretchr/testify/require"
+)
+
+var null = time.Date(2016, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+type Fixture struct {
+	Text   string
+	Index  int
+	Phrase string
+	Diff   time.Duration
+}
+
+func ApplyFixtures(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d - %s", name, i, f.Text)
+		require.NotNil(t, res, "[%s] res #%d - %s", name, i, f.Text)
+		require.Equal(t, f.Index, res.Index, "[%s] index #%d - %s", name, i, f.Text)
+		require.Equal(t, f.Phrase, res.Text, "[%s] text #%d - %s", name, i, f.Text)
+		require.Equal(t, f.Diff, res.Time.Sub(null), "[%s] diff #%d - %s", name, i, f.Text)
+	}
+}
+
+func ApplyFixturesNil(t *testing.T, name string, w *when.Parser, fixt []Fixture) {
+	for i, f := range fixt {
+		res, err := w.Parse(f.Text, null)
+		require.Nil(t, err, "[%s] err #%d", name, i)
+		require.Nil(t, res, "[%s] res #%d", name, i)
+	}
+}
+
+func TestAll(t *testing.T) {
+	w := when.New(nil)
+	w.Add(nl.All...)
+
+	// complex cases
+	fixt := []Fixture{
+		{"morgen om 23:10", 0, "morgen om 23:10", (47 * time.Hour) + (10 * time.Minute)},
+		{"volgende maandag 's avonds", 0, "volgende maandag 's avonds", ((5 * 24) + 18) * time.Hour},
+		{"vrijdag in de middag", 0, "vrijdag in de middag", ((2 * 24) + 15) * time.Hour},
+		{"volgende dinsdag om 14:00", 0, "volgende dinsdag om 14:00", ((6 * 24) + 14) * time.Hour},
+		{"volgende dinsdag om 2 uur 's middags", 0, "volgende dinsdag om 2 uur 's middags", ((6 * 24) + 14) * time.Hour},
+		{"vorige dinsdag om 11 uur", 0, "vorige dinsdag om 11 uur", -13 * time.Hour},
+		{"een brief schrijven zaterdag 's avonds", 20, "zaterdag 's avonds", ((3 * 24) + 18) * time.Hour},
+		{"morgenochtend", 0, "morgenochtend", (24 + 8) * time.Hour},
+		{"overmorgen om 9 uur", 0, "overmorgen om 9 uur", (48 + 9) * time.Hour},
+		{"over 2 uur", 0, "over 2 uur", 2 * time.Hour},
+	}
+
+	ApplyFixtures(t, "nl.All...", w, fixt)
+}
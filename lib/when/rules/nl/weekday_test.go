@@ -0,0 +1,50 @@
+package nl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/nl"
+)
+
+func TestWeekday(t *testing.T) {
+	// current is Wednesday
+	fixt := []Fixture{
+		// past/last
+		{"dat was vorige maandag", 8, "vorige maandag", -(2 * 24 * time.Hour)},
+		{"afgelopen zaterdag", 0, "afgelopen zaterdag", -(4 * 24 * time.Hour)},
+		{"vorige woensdag", 0, "vorige woensdag", -(7 * 24 * time.Hour)},
+		{"dinsdag van vorige week", 0, "dinsdag van vorige week", -(24 * time.Hour)},
+
+		// next
+		{"volgende dinsdag", 0, "volgende dinsdag", 6 * 24 * time.Hour},
+		{"schrijf me volgende woensdag", 11, "volgende woensdag", 7 * 24 * time.Hour},
+		{"aanstaande zaterdag", 0, "aanstaande zaterdag", 3 * 24 * time.Hour},
+		{"op vrijdag", 0, "op vrijdag", 2 * 24 * time.Hour},
+		{"Zondag", 0, "Zondag", 4 * 24 * time.Hour},
+
+		// this
+		{"deze dinsdag", 0, "deze dinsdag", -(24 * time.Hour)},
+		{"deze woensdag", 0, "deze woensdag", 0},
+		{"zaterdag van deze week", 0, "zaterdag van deze week", 3 * 24 * time.Hour},
+	}
+
+	w := when.New(nil)
+	w.Add(nl.Weekday(rules.Override))
+
+	ApplyFixtures(t, "nl.Weekday", w, fixt)
+}
+
+func TestWeekdayNil(t *testing.T) {
+	fixt := []Fixture{
+		{"morgen", 0, "", 0},
+		{"maandagochtendgevoel", 0, "", 0},
+	}
+
+	w := when.New(nil)
+	w.Add(nl.Weekday(rules.Override))
+
+	ApplyFixturesNil(t, "nl.Weekday nil", w, fixt)
+}
@@ -0,0 +1,258 @@
+package moderation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/bot"
+	"github.com/cirelion/flint/bot/eventsystem"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/multiratelimit"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate"
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	AppealStatusPending = iota
+	AppealStatusApproved
+	AppealStatusDenied
+)
+
+const (
+	AppealButton = "moderation_appeal:"
+
+	DefaultAppealCooldownDays = 30
+)
+
+// BanAppeal is an appeal submitted by a banned user through the public appeal page
+type BanAppeal struct {
+	ID       uint  `gorm:"primary_key"`
+	GuildID  int64 `gorm:"index:idx_moderation_ban_appeals_guild_user"`
+	UserID   int64 `gorm:"index:idx_moderation_ban_appeals_guild_user"`
+	Username string
+
+	BanReason string
+	Content   string
+	Status    int
+
+	ReviewerID   int64
+	ReviewerName string
+	ReviewedAt   *time.Time
+
+	// The message posted in the appeal channel
+	ChannelID int64
+	MessageID int64
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (a *BanAppeal) TableName() string {
+	return "moderation_ban_appeals"
+}
+
+func (a *BanAppeal) StatusString() string {
+	switch a.Status {
+	case AppealStatusApproved:
+		return "Approved"
+	case AppealStatusDenied:
+		return "Denied"
+	}
+
+	return "Pending"
+}
+
+// limits the amount of appeals a guild can receive, on top of the per user cooldown
+var appealRatelimiter = multiratelimit.NewMultiRatelimiter(10.0/3600, 10)
+
+func lastAppeal(guildID, userID int64) (*BanAppeal, error) {
+	var appeal BanAppeal
+	err := common.GORM.Where("guild_id = ? AND user_id = ?", guildID, userID).Order("id desc").First(&appeal).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+
+	return &appeal, err
+}
+
+// appealCooldownLeft returns how long the user has to wait before appealing again after their last appeal
+func appealCooldownLeft(config *Config, last *BanAppeal, now time.Time) time.Duration {
+	if last == nil || last.Status == AppealStatusPending {
+		return 0
+	}
+
+	days := config.AppealCooldownDays
+	if days < 1 {
+		days = DefaultAppealCooldownDays
+	}
+
+	left := last.CreatedAt.Add(time.Duration(days) * time.Hour * 24).Sub(now)
+	if left < 0 {
+		return 0
+	}
+
+	return left
+}
+
+// appealBanReason looks up the reason of the ban, preferring the moderation case over the ban modlog and the reason on discord
+func appealBanReason(guildID, userID int64, discordReason string) string {
+	var modCase ModerationCase
+	err := common.GORM.Where("guild_id = ? AND user_id = ? AND action = ?", guildID, userID, CaseBan).Order("case_number desc").First(&modCase).Error
+	if err == nil {
+		return modCase.Reason
+	}
+
+	var modLogs ModLog
+	err = common.GORM.Where("user_id = ? AND guild_id = ?", userID, guildID).First(&modLogs).Error
+	if err == nil {
+		var bans []Ban
+		err = common.GORM.Model(&modLogs).Association("Bans").Find(&bans).Error
+		if err == nil && len(bans) > 0 {
+			return bans[len(bans)-1].Reason
+		}
+	}
+
+	if discordReason == "" {
+		return "(No reason specified)"
+	}
+
+	return discordReason
+}
+
+func appealCustomID(appeal *BanAppeal, action string) string {
+	return fmt.Sprintf("%s%d:%s", AppealButton, appeal.ID, action)
+}
+
+// postAppeal sends the appeal to the staff channel for review
+func postAppeal(config *Config, appeal *BanAppeal, user *discordgo.User) error {
+	msg, err := common.BotSession.ChannelMessageSendComplex(config.AppealChannel, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{{
+			Author: &discordgo.MessageEmbedAuthor{
+				IconURL: discordgo.EndpointUserAvatar(user.ID, user.Avatar),
+				Name:    fmt.Sprintf("%s (%d)", user.String(), user.ID),
+			},
+			Title:       "Ban appeal",
+			Description: fmt.Sprintf("**Ban reason:** %s\n\n**Appeal:**\n%s", appeal.BanReason, appeal.Content),
+			Color:       MABanned.Color,
+			Timestamp:   time.Now().Format(time.RFC3339),
+		}},
+		Components: []discordgo.MessageComponent{discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.Button{Label: "Approve", Style: discordgo.SuccessButton, CustomID: appealCustomID(appeal, "approve")},
+			discordgo.Button{Label: "Deny", Style: discordgo.DangerButton, CustomID: appealCustomID(appeal, "deny")},
+		}}},
+		AllowedMentions: discordgo.AllowedMentions{},
+	})
+	if err != nil {
+		return err
+	}
+
+	return common.GORM.Model(appeal).Updates(map[string]interface{}{"channel_id": msg.ChannelID, "message_id": msg.ID}).Error
+}
+
+func handleAppealInteraction(evt *eventsystem.EventData) {
+	ic := evt.InteractionCreate()
+	if ic.Type != discordgo.InteractionMessageComponent || ic.GuildID == 0 || ic.Member == nil {
+		return
+	}
+
+	customID := ic.MessageComponentData().CustomID
+	if !strings.HasPrefix(customID, AppealButton) {
+		return
+	}
+
+	args := strings.Split(strings.TrimPrefix(customID, AppealButton), ":")
+	if len(args) != 2 || (args[1] != "approve" && args[1] != "deny") {
+		return
+	}
+
+	appealID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return
+	}
+
+	ok, err := bot.AdminOrPermMS(ic.GuildID, ic.ChannelID, dstate.MemberStateFromMember(ic.Member), discordgo.PermissionBanMembers)
+	if err != nil {
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed checking permissions for ban appeal")
+		return
+	}
+
+	if !ok {
+		respondAppealEphemeral(ic, "You need the Ban Members permission to review appeals.")
+		return
+	}
+
+	status := AppealStatusDenied
+	if args[1] == "approve" {
+		status = AppealStatusApproved
+	}
+
+	// claim the appeal first so that two moderators can't review it at the same time
+	reviewer := ic.Member.User
+	now := time.Now()
+	result := common.GORM.Model(&BanAppeal{}).Where("id = ? AND guild_id = ? AND status = ?", appealID, ic.GuildID, AppealStatusPending).
+		Updates(map[string]interface{}{"status": status, "reviewer_id": reviewer.ID, "reviewer_name": reviewer.String(), "reviewed_at": now})
+	if result.Error != nil {
+		logger.WithError(result.Error).WithField("guild", ic.GuildID).Error("failed updating ban appeal")
+		return
+	}
+
+	if result.RowsAffected < 1 {
+		respondAppealEphemeral(ic, "This appeal has already been reviewed.")
+		return
+	}
+
+	var appeal BanAppeal
+	err = common.GORM.Where("id = ?", appealID).First(&appeal).Error
+	if err != nil {
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed retrieving ban appeal")
+		return
+	}
+
+	target := &discordgo.User{ID: appeal.UserID, Username: appeal.Username}
+	if status == AppealStatusApproved {
+		_, err = UnbanUser(nil, ic.GuildID, reviewer, "Ban appeal approved", target)
+		if err != nil {
+			// put it back up for review
+			common.GORM.Model(&appeal).Updates(map[string]interface{}{"status": AppealStatusPending, "reviewer_id": 0, "reviewer_name": "", "reviewed_at": nil})
+			respondAppealEphemeral(ic, "Failed unbanning: "+common.CutStringShort(err.Error(), 1000))
+			return
+		}
+	}
+
+	gs := bot.State.GetGuild(ic.GuildID)
+	if gs != nil {
+		go bot.SendDM(appeal.UserID, fmt.Sprintf("**%s:** Your ban appeal was %s.", gs.Name, strings.ToLower(appeal.StatusString())))
+	}
+
+	embeds := ic.Message.Embeds
+	if len(embeds) > 0 {
+		embeds[0].Fields = append(embeds[0].Fields, &discordgo.MessageEmbedField{Name: "Result", Value: fmt.Sprintf("%s by %s", appeal.StatusString(), reviewer.String())})
+	}
+
+	err = common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     embeds,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed updating ban appeal message")
+	}
+}
+
+func respondAppealEphemeral(ic *discordgo.InteractionCreate, content string) {
+	err := common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   uint64(discordgo.MessageFlagsEphemeral),
+		},
+	})
+	if err != nil {
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed responding to ban appeal interaction")
+	}
+}
@@ -0,0 +1,33 @@
+package moderation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAppealCooldownLeft(t *testing.T) {
+	now := time.Now()
+	config := &Config{AppealCooldownDays: 7}
+
+	cases := []struct {
+		last     *BanAppeal
+		expected time.Duration
+	}{
+		{nil, 0},
+		{&BanAppeal{Status: AppealStatusPending, CreatedAt: now}, 0},
+		{&BanAppeal{Status: AppealStatusDenied, CreatedAt: now.Add(-time.Hour * 24 * 2)}, time.Hour * 24 * 5},
+		{&BanAppeal{Status: AppealStatusDenied, CreatedAt: now.Add(-time.Hour * 24 * 8)}, 0},
+	}
+
+	for i, c := range cases {
+		if left := appealCooldownLeft(config, c.last, now); left != c.expected {
+			t.Errorf("case %d: expected %s, got %s", i, c.expected, left)
+		}
+	}
+
+	// falls back to the default cooldown if not set
+	last := &BanAppeal{Status: AppealStatusApproved, CreatedAt: now}
+	if left := appealCooldownLeft(&Config{}, last, now); left != time.Hour*24*DefaultAppealCooldownDays {
+		t.Errorf("expected the default cooldown, got %s", left)
+	}
+}
@@ -0,0 +1,220 @@
+package moderation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/lib/discordgo"
+)
+
+// Case types, stored in the action column of moderation_cases
+const (
+	CaseMute           = "mute"
+	CaseUnmute         = "unmute"
+	CaseKick           = "kick"
+	CaseBan            = "ban"
+	CaseUnban          = "unban"
+	CaseWarn           = "warn"
+	CaseTimeout        = "timeout"
+	CaseTimeoutRemoved = "timeout_removed"
+	CaseAutomodBlocked = "automod_blocked"
+	CaseAutomodTimeout = "automod_timeout"
+)
+
+// ModerationCase is a single moderation action, numbered sequentially per guild so it can be referred to
+type ModerationCase struct {
+	ID         uint  `gorm:"primary_key"`
+	GuildID    int64 `gorm:"unique_index:idx_moderation_cases_guild_case;index:idx_moderation_cases_guild_user"`
+	CaseNumber int64 `gorm:"unique_index:idx_moderation_cases_guild_case"`
+
+	UserID     int64 `gorm:"index:idx_moderation_cases_guild_user"`
+	Username   string
+	AuthorID   int64
+	AuthorName string
+
+	Action   string
+	Reason   string
+	Duration time.Duration
+	LogLink  string
+	Proof    string
+
+	// Where the modlog embed of this case was posted, 0 if it wasn't
+	ModlogChannelID int64
+	ModlogMessageID int64
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (c *ModerationCase) TableName() string {
+	return "moderation_cases"
+}
+
+// ModlogAction returns the modlog action the case was created from
+func (c *ModerationCase) ModlogAction() ModlogAction {
+	for _, v := range caseActions {
+		if v.Case == c.Action {
+			return v
+		}
+	}
+
+	return ModlogAction{Prefix: c.Action, Emoji: "❔"}
+}
+
+func (c *ModerationCase) ModlogMessageLink() string {
+	if c.ModlogMessageID == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("https://discord.com/channels/%d/%d/%d", c.GuildID, c.ModlogChannelID, c.ModlogMessageID)
+}
+
+var caseActions = []ModlogAction{MAMute, MAUnmute, MAKick, MABanned, MAUnbanned, MAWarned, MATimeoutAdded, MATimeoutRemoved, MAAutomodBlocked, MAAutomodTimeout}
+
+// caseCounter holds the last case number handed out in a guild
+type caseCounter struct {
+	GuildID  int64 `gorm:"primary_key"`
+	LastCase int64
+}
+
+func (c *caseCounter) TableName() string {
+	return "moderation_case_counters"
+}
+
+func nextCaseNumber(guildID int64) (int64, error) {
+	const q = `INSERT INTO moderation_case_counters (guild_id, last_case) VALUES ($1, 1)
+ON CONFLICT (guild_id) DO UPDATE SET last_case = moderation_case_counters.last_case + 1
+RETURNING last_case`
+
+	var n int64
+	err := common.PQ.QueryRow(q, guildID).Scan(&n)
+	return n, err
+}
+
+// CreateCase records the action as a new case, returns nil if the action is not one that's tracked as a case (e.g giving roles)
+func CreateCase(guildID int64, author *discordgo.User, action ModlogAction, target *discordgo.User, reason, logLink, proof string, duration time.Duration) (*ModerationCase, error) {
+	if action.Case == "" {
+		return nil, nil
+	}
+
+	n, err := nextCaseNumber(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if duration < 0 {
+		duration = 0
+	}
+
+	modCase := &ModerationCase{
+		GuildID:    guildID,
+		CaseNumber: n,
+		UserID:     target.ID,
+		Username:   target.String(),
+		Action:     action.Case,
+		Reason:     reason,
+		Duration:   duration,
+		LogLink:    logLink,
+		Proof:      proof,
+	}
+
+	if author != nil {
+		modCase.AuthorID = author.ID
+		modCase.AuthorName = author.String()
+	}
+
+	err = common.GORM.Create(modCase).Error
+	return modCase, err
+}
+
+// recordCase is CreateCase for the places where a failure should not stop the action from being logged
+func recordCase(guildID int64, author *discordgo.User, action ModlogAction, target *discordgo.User, reason, logLink, proof string, duration time.Duration) *ModerationCase {
+	modCase, err := CreateCase(guildID, author, action, target, reason, logLink, proof, duration)
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed creating moderation case")
+		return nil
+	}
+
+	return modCase
+}
+
+func FindCase(guildID int64, caseNumber int64) (*ModerationCase, error) {
+	var modCase ModerationCase
+	err := common.GORM.Where("guild_id = ? AND case_number = ?", guildID, caseNumber).First(&modCase).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &modCase, nil
+}
+
+// UpdateCaseReason changes the reason of the case, and of its modlog embed if it still exists
+func UpdateCaseReason(modCase *ModerationCase, reason string) error {
+	modCase.Reason = reason
+	err := common.GORM.Model(modCase).Update("reason", reason).Error
+	if err != nil {
+		return err
+	}
+
+	if modCase.ModlogMessageID == 0 {
+		return nil
+	}
+
+	msg, err := common.BotSession.ChannelMessage(modCase.ModlogChannelID, modCase.ModlogMessageID)
+	if err != nil {
+		if common.IsDiscordErr(err, discordgo.ErrCodeUnknownMessage, discordgo.ErrCodeUnknownChannel, discordgo.ErrCodeMissingAccess) {
+			return nil
+		}
+		return err
+	}
+
+	if len(msg.Embeds) < 1 || !updateEmbedReason(reason, msg.Embeds[0]) {
+		return nil
+	}
+
+	_, err = common.BotSession.ChannelMessageEditEmbed(modCase.ModlogChannelID, modCase.ModlogMessageID, msg.Embeds[0])
+	return err
+}
+
+func caseEmbed(modCase *ModerationCase) *discordgo.MessageEmbed {
+	action := modCase.ModlogAction()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, ">>> **User:** <@%d> %s (%d)\n**Action:** %s\n**Reason:** %s", modCase.UserID, modCase.Username, modCase.UserID, action.Emoji+action.Prefix, modCase.Reason)
+	if modCase.Duration > 0 {
+		fmt.Fprintf(&b, "\n**Duration:** %s", common.HumanizeDuration(common.DurationPrecisionMinutes, modCase.Duration))
+	}
+	if modCase.Proof != "" {
+		fmt.Fprintf(&b, "\n**Proof:** %s", modCase.Proof)
+	}
+	if link := modCase.ModlogMessageLink(); link != "" {
+		fmt.Fprintf(&b, "\n[Modlog entry](%s)", link)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("Case #%d", modCase.CaseNumber),
+		Description: b.String(),
+		Color:       action.Color,
+		URL:         modCase.LogLink,
+		Timestamp:   modCase.CreatedAt.Format(time.RFC3339),
+	}
+
+	if modCase.AuthorID != 0 {
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("Action taken by %s (%d)", modCase.AuthorName, modCase.AuthorID)}
+	}
+
+	return embed
+}
+
+// caseLine is the short, single line summary of a case used in case lists
+func caseLine(modCase *ModerationCase) string {
+	action := modCase.ModlogAction()
+	line := fmt.Sprintf("`#%d` %s **%s** <t:%d:d> - %s", modCase.CaseNumber, action.Emoji, action.Prefix, modCase.CreatedAt.Unix(), common.CutStringShort(modCase.Reason, 100))
+	if modCase.Duration > 0 {
+		line += fmt.Sprintf(" (%s)", common.HumanizeDuration(common.DurationPrecisionMinutes, modCase.Duration))
+	}
+
+	return line
+}
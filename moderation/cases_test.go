@@ -0,0 +1,43 @@
+package moderation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/discordgo"
+)
+
+func TestUpdateEmbedReason(t *testing.T) {
+	author := &discordgo.User{ID: 1, Username: "bot", Bot: true}
+	target := &discordgo.User{ID: 2, Username: "target"}
+
+	embed := generateGenericModEmbed(MAMute, author, target, "spam", "", "some proof", time.Hour, 3)
+	if !updateEmbedReason("posting invites", embed) {
+		t.Fatal("expected the reason to be found")
+	}
+
+	expected := ">>> **User:** <@2> (2)\n**Reason:** posting invites\n**Duration:** 1 hour (until <t:"
+	if !strings.HasPrefix(embed.Description, expected) || !strings.HasSuffix(embed.Description, ":f>)\n**Proof:** some proof") {
+		t.Errorf("unexpected description:\n%s\nexpected:\n%s", embed.Description, expected)
+	}
+
+	embed = generateGenericModEmbed(MAKick, author, target, "old", "", "", 0, 3)
+	updateEmbedReason("new", embed)
+	if !strings.HasSuffix(embed.Description, "**Reason:** new") {
+		t.Errorf("unexpected description: %s", embed.Description)
+	}
+
+	if updateEmbedReason("new", &discordgo.MessageEmbed{Description: "no reason here"}) {
+		t.Error("expected no reason to be found")
+	}
+}
+
+func TestCaseModlogAction(t *testing.T) {
+	for _, v := range caseActions {
+		c := &ModerationCase{Action: v.Case}
+		if got := c.ModlogAction(); got != v {
+			t.Errorf("case %q: got action %v, expected %v", v.Case, got, v)
+		}
+	}
+}
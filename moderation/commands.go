@@ -8,12 +8,15 @@ import (
 	"github.com/cirelion/flint/bot/paginatedmessages"
 	"github.com/cirelion/flint/commands"
 	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/i18n"
 	"github.com/cirelion/flint/common/scheduledevents2"
 	"github.com/cirelion/flint/common/templates"
 	"github.com/cirelion/flint/lib/dcmd"
 	"github.com/cirelion/flint/lib/discordgo"
 	"github.com/cirelion/flint/lib/dstate"
+	"github.com/cirelion/flint/timezonecompanion"
 	"github.com/cirelion/flint/web"
+	"github.com/jinzhu/gorm"
 	log "github.com/sirupsen/logrus"
 	"math"
 	"regexp"
@@ -50,6 +53,7 @@ type ModLogEntry struct {
 	Reason   string
 	LogLink  string
 	Duration time.Duration
+	Points   int64
 	GivenAt  time.Time
 }
 
@@ -67,7 +71,7 @@ func MBaseCmd(cmdData *dcmd.Data, targetID int64) (config *Config, targetUser *d
 			above := bot.IsMemberAbove(gs, cmdData.GuildData.MS, targetMember)
 
 			if !above {
-				return config, &targetMember.User, commands.NewUserError("Can't use moderation commands on users ranked the same or higher than you")
+				return config, &targetMember.User, commands.NewUserError(i18n.T(cmdData, "moderation.target_above_you"))
 			}
 
 			return config, &targetMember.User, nil
@@ -86,15 +90,16 @@ func MBaseCmdSecond(cmdData *dcmd.Data, reason string, reasonArgOptional bool, n
 	cmdName := cmdData.Cmd.Trigger.Names[0]
 	oreason = reason
 	if !enabled {
-		return oreason, commands.NewUserErrorf("The **%s** command is disabled on this server. It can be enabled at <%s/moderation>", cmdName, web.ManageServerURL(cmdData.GuildData))
+		return oreason, commands.NewUserError(i18n.T(cmdData, "moderation.command_disabled", cmdName, web.ManageServerURL(cmdData.GuildData)))
 	}
 
 	if strings.TrimSpace(reason) == "" {
 		if !reasonArgOptional {
-			return oreason, commands.NewUserError("A reason has been set to be required for this command by the server admins, see help for more info.")
+			return oreason, commands.NewUserError(i18n.T(cmdData, "moderation.reason_required"))
 		}
 
-		oreason = "(No reason specified)"
+		// the reason ends up in the modlog, so it's in the language of the server rather than the one of the user
+		oreason = i18n.Translate(i18n.GuildLanguage(cmdData.GuildData.GS.ID), "moderation.no_reason")
 	}
 
 	member := cmdData.GuildData.MS
@@ -115,7 +120,7 @@ func MBaseCmdSecond(cmdData *dcmd.Data, reason string, reasonArgOptional bool, n
 		// Fallback to legacy permissions
 		hasPerms, err := bot.AdminOrPermMS(cmdData.GuildData.GS.ID, cmdData.ChannelID, member, neededPerm)
 		if err != nil || !hasPerms {
-			return oreason, commands.NewUserErrorf("The **%s** command requires the **%s** permission in this channel or additional roles set up by admins, you don't have it. (if you do contact bot support)", cmdName, common.StringPerms[neededPerm])
+			return oreason, commands.NewUserError(i18n.T(cmdData, "moderation.missing_permission", cmdName, common.StringPerms[neededPerm]))
 		}
 
 		permsMet = true
@@ -129,7 +134,7 @@ func MBaseCmdSecond(cmdData *dcmd.Data, reason string, reasonArgOptional bool, n
 func checkHierarchy(cmdData *dcmd.Data, targetID int64) error {
 	botMember, err := bot.GetMember(cmdData.GuildData.GS.ID, common.BotUser.ID)
 	if err != nil {
-		return commands.NewUserError("Failed fetching bot member to check hierarchy")
+		return commands.NewUserError(i18n.T(cmdData, "moderation.bot_member_failed"))
 	}
 
 	gs := cmdData.GuildData.GS
@@ -142,7 +147,7 @@ func checkHierarchy(cmdData *dcmd.Data, targetID int64) error {
 
 	if !above {
 		cmdName := cmdData.Cmd.Trigger.Names[0]
-		return commands.NewUserErrorf("Can't use the **%s** command on members that are ranked higher than the bot.", cmdName)
+		return commands.NewUserError(i18n.T(cmdData, "moderation.target_above_bot", cmdName))
 	}
 
 	return nil
@@ -183,7 +188,7 @@ var ModerationCommands = []*commands.YAGCommand{
 		RequiredArgs:  3,
 		Arguments: []*dcmd.ArgDef{
 			{Name: "User", Type: dcmd.UserID},
-			{Name: "Duration", Type: &commands.DurationArg{}, Default: time.Duration(0)},
+			{Name: "Duration", Type: &timezonecompanion.TimeArg{Strict: true}, Default: time.Duration(0)},
 			{Name: "Reason", Type: dcmd.String},
 		},
 		ArgSwitches: []*dcmd.ArgDef{
@@ -208,7 +213,7 @@ var ModerationCommands = []*commands.YAGCommand{
 			}
 
 			if utf8.RuneCountInString(reason) > 470 {
-				return "Error: Reason too long (can be max 470 characters).", nil
+				return i18n.T(parsed, "moderation.reason_too_long", 470), nil
 			}
 
 			err = checkHierarchy(parsed, parsed.Args[0].Int64())
@@ -277,7 +282,7 @@ var ModerationCommands = []*commands.YAGCommand{
 			}
 			targetMem, _ := bot.GetMember(parsed.GuildData.GS.ID, targetID)
 			if targetMem != nil {
-				return "User is not banned!", nil
+				return i18n.T(parsed, "moderation.not_banned"), nil
 			}
 
 			isNotBanned, err := UnbanUser(config, parsed.GuildData.GS.ID, parsed.Author, reason, target)
@@ -286,7 +291,7 @@ var ModerationCommands = []*commands.YAGCommand{
 				return nil, err
 			}
 			if isNotBanned {
-				return "User is not banned!", nil
+				return i18n.T(parsed, "moderation.not_banned"), nil
 			}
 
 			if parsed.TriggerType != 3 {
@@ -331,11 +336,11 @@ var ModerationCommands = []*commands.YAGCommand{
 
 			member, err := bot.GetMember(parsed.GuildData.GS.ID, target.ID)
 			if err != nil || member == nil {
-				return "Member not found", err
+				return i18n.T(parsed, "moderation.member_not_found"), err
 			}
 
 			if utf8.RuneCountInString(reason) > 470 {
-				return "Error: Reason too long (can be max 470 characters).", nil
+				return i18n.T(parsed, "moderation.reason_too_long", 470), nil
 			}
 
 			err = checkHierarchy(parsed, parsed.Args[0].Int64())
@@ -377,7 +382,7 @@ var ModerationCommands = []*commands.YAGCommand{
 		RequiredArgs:  3,
 		Arguments: []*dcmd.ArgDef{
 			{Name: "User", Type: dcmd.UserID},
-			{Name: "Duration", Type: &commands.DurationArg{}},
+			{Name: "Duration", Type: &timezonecompanion.TimeArg{Strict: true}},
 			{Name: "Reason", Type: dcmd.String},
 		},
 		RequiredDiscordPermsHelp:  "KickMembers or ManageServer",
@@ -393,7 +398,7 @@ var ModerationCommands = []*commands.YAGCommand{
 			}
 
 			if config.MuteRole == "" {
-				return fmt.Sprintf("No mute role selected. Select one at <%s/moderation>", web.ManageServerURL(parsed.GuildData)), nil
+				return i18n.T(parsed, "moderation.no_mute_role_selected", web.ManageServerURL(parsed.GuildData)), nil
 			}
 
 			reason := parsed.Args[2].Str()
@@ -414,7 +419,7 @@ var ModerationCommands = []*commands.YAGCommand{
 
 			member, err := bot.GetMember(parsed.GuildData.GS.ID, target.ID)
 			if err != nil || member == nil {
-				return "Member not found", err
+				return i18n.T(parsed, "moderation.member_not_found"), err
 			}
 
 			var msg *discordgo.Message
@@ -461,7 +466,7 @@ var ModerationCommands = []*commands.YAGCommand{
 			}
 
 			if config.MuteRole == "" {
-				return "No mute role set up, assign a mute role in the control panel", nil
+				return i18n.T(parsed, "moderation.no_mute_role"), nil
 			}
 
 			reason := parsed.Args[1].Str()
@@ -472,7 +477,7 @@ var ModerationCommands = []*commands.YAGCommand{
 
 			member, err := bot.GetMember(parsed.GuildData.GS.ID, target.ID)
 			if err != nil || member == nil {
-				return "Member not found", err
+				return i18n.T(parsed, "moderation.member_not_found"), err
 			}
 
 			var msg *discordgo.Message
@@ -532,11 +537,12 @@ var ModerationCommands = []*commands.YAGCommand{
 				d = time.Minute
 			}
 			if d > MaxTimeOutDuration {
-				return fmt.Sprintf("Error: Max duration of Timeouts can be %v days", (MaxTimeOutDuration.Hours() / 24)), nil
+				maxDays := int(MaxTimeOutDuration.Hours() / 24)
+				return i18n.TPlural(parsed, "moderation.timeout_too_long", maxDays, maxDays), nil
 			}
 			member, err := bot.GetMember(parsed.GuildData.GS.ID, target.ID)
 			if err != nil || member == nil {
-				return "Member not found", err
+				return i18n.T(parsed, "moderation.member_not_found"), err
 			}
 
 			var msg *discordgo.Message
@@ -585,12 +591,12 @@ var ModerationCommands = []*commands.YAGCommand{
 
 			member, err := bot.GetMember(parsed.GuildData.GS.ID, target.ID)
 			if err != nil || member == nil {
-				return "Member not found", err
+				return i18n.T(parsed, "moderation.member_not_found"), err
 			}
 
 			memberTimeout := member.Member.CommunicationDisabledUntil
 			if memberTimeout == nil || memberTimeout.Before(time.Now()) {
-				return "Member is not timed out", nil
+				return i18n.T(parsed, "moderation.not_timed_out"), nil
 			}
 
 			err = RemoveTimeout(config, parsed.GuildData.GS.ID, parsed.Author, reason, &member.User)
@@ -634,14 +640,14 @@ var ModerationCommands = []*commands.YAGCommand{
 			target := temp.User
 
 			if target.ID == parsed.Author.ID {
-				return "You can't report yourself, silly.", nil
+				return i18n.T(parsed, "moderation.report_self"), nil
 			}
 
 			logLink := CreateLogs(parsed.GuildData.GS.ID, parsed.GuildData.CS.ID, parsed.Author)
 
 			channelID := config.IntReportChannel()
 			if channelID == 0 {
-				return "No report channel set up", nil
+				return i18n.T(parsed, "moderation.no_report_channel"), nil
 			}
 
 			topContent := fmt.Sprintf("%s reported **%s (ID %d)**", parsed.Author.Mention(), target.String(), target.ID)
@@ -668,12 +674,12 @@ var ModerationCommands = []*commands.YAGCommand{
 
 			_, err = common.BotSession.ChannelMessageSendComplex(channelID, send)
 			if err != nil {
-				return "Something went wrong while sending your report!", err
+				return i18n.T(parsed, "moderation.report_failed"), err
 			}
 
 			// Don't bother sending confirmation if it is done in the report channel
 			if channelID != parsed.ChannelID || parsed.SlashCommandTriggerData != nil {
-				return "User reported to the proper authorities!", nil
+				return i18n.T(parsed, "moderation.reported"), nil
 			}
 
 			return nil, nil
@@ -826,10 +832,6 @@ var ModerationCommands = []*commands.YAGCommand{
 			time.Sleep(time.Second)
 
 			numDeleted, err := AdvancedDeleteMessages(parsed.GuildData.GS.ID, parsed.ChannelID, triggerID, userFilter, re, invertRegexMatch, toID, fromID, ma, minAge, pe, attachments, num, limitFetch)
-			deleteMessageWord := "messages"
-			if numDeleted == 1 {
-				deleteMessageWord = "message"
-			}
 
 			if parsed.TriggerType != 3 {
 				err = common.BotSession.ChannelMessageDelete(parsed.ChannelID, parsed.TraditionalTriggerData.Message.ID)
@@ -838,7 +840,7 @@ var ModerationCommands = []*commands.YAGCommand{
 				}
 			}
 
-			return dcmd.NewTemporaryResponse(time.Second*5, fmt.Sprintf("Deleted %d %s! :')", numDeleted, deleteMessageWord), true), err
+			return dcmd.NewTemporaryResponse(time.Second*5, i18n.TPlural(parsed, "moderation.clean_deleted", numDeleted, numDeleted), true), err
 		},
 	},
 	{
@@ -851,6 +853,9 @@ var ModerationCommands = []*commands.YAGCommand{
 			{Name: "User", Type: dcmd.UserID},
 			{Name: "Reason", Type: dcmd.String},
 		},
+		ArgSwitches: []*dcmd.ArgDef{
+			{Name: "points", Help: "Warning points, overrides the point rules", Type: &dcmd.IntArg{Min: 0, Max: 100}},
+		},
 		RequiredDiscordPermsHelp:  "ManageMessages or ManageServer",
 		ApplicationCommandEnabled: true,
 		DefaultEnabled:            false,
@@ -868,7 +873,7 @@ var ModerationCommands = []*commands.YAGCommand{
 
 			member, err := bot.GetMember(parsed.GuildData.GS.ID, target.ID)
 			if err != nil || member == nil {
-				return "Member not found", err
+				return i18n.T(parsed, "moderation.member_not_found"), err
 			}
 
 			var msg *discordgo.Message
@@ -877,7 +882,12 @@ var ModerationCommands = []*commands.YAGCommand{
 				proof, err = getMessageReferenceContent(parsed.TraditionalTriggerData)
 			}
 
-			err = WarnUser(config, parsed.GuildData.GS.ID, parsed.GuildData.CS, msg, parsed.Author, target, parsed.Args[1].Str(), proof)
+			points := config.WarnPointsForReason(parsed.Args[1].Str())
+			if parsed.Switches["points"].Value != nil {
+				points = int64(parsed.Switches["points"].Int())
+			}
+
+			err = WarnUserWithPoints(config, parsed.GuildData.GS.ID, parsed.GuildData.CS, msg, parsed.Author, target, parsed.Args[1].Str(), proof, points)
 			if err != nil {
 				return nil, err
 			}
@@ -889,7 +899,16 @@ var ModerationCommands = []*commands.YAGCommand{
 				}
 			}
 
-			return generateGenericModEmbed(MAWarned, parsed.Author, target, parsed.Args[1].Str(), "", "", 4*7*24*time.Hour, config.GuildID), nil
+			embed := generateGenericModEmbed(MAWarned, parsed.Author, target, parsed.Args[1].Str(), "", "", 4*7*24*time.Hour, config.GuildID)
+			if config.WarnPointsEnabled {
+				activePoints, err := ActiveWarnPoints(config, parsed.GuildData.GS.ID, target.ID)
+				if err != nil {
+					return nil, err
+				}
+				embed.Fields = append(embed.Fields, warnPointsField(points, activePoints))
+			}
+
+			return embed, nil
 		},
 	},
 	{
@@ -922,23 +941,23 @@ var ModerationCommands = []*commands.YAGCommand{
 
 			member, err := bot.GetMember(parsed.GuildData.GS.ID, target.ID)
 			if err != nil || member == nil {
-				return "Member not found", err
+				return i18n.T(parsed, "moderation.member_not_found"), err
 			}
 
 			role := parsed.Args[1].Value.(*discordgo.Role)
 			if role == nil {
-				return "Couldn't find the specified role", nil
+				return i18n.T(parsed, "moderation.role_not_found"), nil
 			}
 
 			if !bot.IsMemberAboveRole(parsed.GuildData.GS, parsed.GuildData.MS, role) {
-				return "Can't give roles above you", nil
+				return i18n.T(parsed, "moderation.give_role_above"), nil
 			}
 
 			dur := parsed.Args[2].Value.(time.Duration)
 
 			// no point if the user has the role and is not updating the expiracy
 			if common.ContainsInt64Slice(member.Member.Roles, role.ID) && dur <= 0 {
-				return "That user already has that role", nil
+				return i18n.T(parsed, "moderation.already_has_role"), nil
 			}
 
 			err = common.AddRoleDS(member, role.ID)
@@ -998,16 +1017,16 @@ var ModerationCommands = []*commands.YAGCommand{
 
 			member, err := bot.GetMember(parsed.GuildData.GS.ID, target.ID)
 			if err != nil || member == nil {
-				return "Member not found", err
+				return i18n.T(parsed, "moderation.member_not_found"), err
 			}
 
 			role := parsed.Args[1].Value.(*discordgo.Role)
 			if role == nil {
-				return "Couldn't find the specified role", nil
+				return i18n.T(parsed, "moderation.role_not_found"), nil
 			}
 
 			if !bot.IsMemberAboveRole(parsed.GuildData.GS, parsed.GuildData.MS, role) {
-				return "Can't remove roles above you", nil
+				return i18n.T(parsed, "moderation.remove_role_above"), nil
 			}
 
 			err = common.RemoveRoleDS(member, role.ID)
@@ -1064,10 +1083,10 @@ var ModerationCommands = []*commands.YAGCommand{
 			}
 
 			if rl == 0 {
-				humanizedInterval = "none"
+				return i18n.T(parsed, "moderation.slowmode_disabled", channelID), nil
 			}
 
-			return "Slow mode in <#" + strconv.FormatInt(channelID, 10) + "> set to " + humanizedInterval, nil
+			return i18n.T(parsed, "moderation.slowmode_set", channelID, humanizedInterval), nil
 		},
 	},
 	{
@@ -1679,7 +1698,7 @@ var ModerationCommands = []*commands.YAGCommand{
 			}
 
 			if len(punishList) < 1 {
-				return "User has no log entries.", nil
+				return i18n.T(parsed, "moderation.no_log_entries"), nil
 			}
 
 			maxPage := int(math.Ceil(float64(len(punishList)) / float64(5)))
@@ -1718,7 +1737,7 @@ var ModerationCommands = []*commands.YAGCommand{
 			}
 
 			if len(punishList) < 1 {
-				return "User has no log entries.", nil
+				return i18n.T(parsed, "moderation.no_log_entries"), nil
 			}
 
 			maxPage := int(math.Ceil(float64(len(punishList)) / float64(5)))
@@ -1750,7 +1769,7 @@ var ModerationCommands = []*commands.YAGCommand{
 		RequiredArgs:              4,
 		Arguments: []*dcmd.ArgDef{
 			{Name: "User", Help: "The user you want to update the punishments of", Type: &commands.MemberArg{}},
-			{Name: "Type", Help: "The type of punishment (Warn, Mute, Kick, Ban)", Type: dcmd.String},
+			{Name: "Type", Help: "The type of punishment (Warn, Mute, Kick, Ban, Automod)", Type: dcmd.String},
 			{Name: "ID", Help: "The punishment ID, found in the modLogs", Type: dcmd.Int},
 			{Name: "UpdatedReason", Help: "The update reason for the punishment", Type: dcmd.String},
 		},
@@ -1760,8 +1779,8 @@ var ModerationCommands = []*commands.YAGCommand{
 			ID := parsed.Args[2].Int()
 			reason := parsed.Args[3].Str()
 
-			if !strings.Contains("WarnMuteKickBan", Type) {
-				return "Incorrect type, must be one of: 'Warn', 'Mute', 'Kick', 'Ban'", nil
+			if !strings.Contains("WarnMuteKickBanAutomod", Type) {
+				return i18n.T(parsed, "moderation.invalid_log_type"), nil
 			}
 
 			modLogs := ModLog{UserID: uint64(userID)}
@@ -1808,13 +1827,23 @@ var ModerationCommands = []*commands.YAGCommand{
 
 				model.Reason = reason
 				err = common.GORM.Model(&modLogs).Association("Bans").Append(model).Error
+			case "Automod":
+				model := AutomodAction{ID: uint(ID)}
+
+				err = common.GORM.Model(&modLogs).Association("AutomodActions").Find(&model).Error
+				if err != nil {
+					return nil, err
+				}
+
+				model.Reason = reason
+				err = common.GORM.Model(&modLogs).Association("AutomodActions").Append(model).Error
 			}
 
 			if err != nil {
 				return nil, err
 			}
 
-			return "Done.", nil
+			return i18n.T(parsed, "moderation.done"), nil
 		},
 	},
 	{
@@ -1831,7 +1860,7 @@ var ModerationCommands = []*commands.YAGCommand{
 		RequiredArgs:              3,
 		Arguments: []*dcmd.ArgDef{
 			{Name: "User", Help: "The user you want to delete the punishments of", Type: &commands.MemberArg{}},
-			{Name: "Type", Help: "The type of punishment (Warn, Mute, Kick, Ban)", Type: dcmd.String},
+			{Name: "Type", Help: "The type of punishment (Warn, Mute, Kick, Ban, Automod)", Type: dcmd.String},
 			{Name: "ID", Help: "The punishment ID, found in the modLogs", Type: dcmd.Int},
 		},
 		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
@@ -1839,8 +1868,8 @@ var ModerationCommands = []*commands.YAGCommand{
 			Type := strings.Title(parsed.Args[1].Str())
 			ID := parsed.Args[2].Int()
 
-			if !strings.Contains("WarnMuteKickBan", Type) {
-				return "Incorrect type, must be one of: 'Warn', 'Mute', 'Kick', 'Ban'", nil
+			if !strings.Contains("WarnMuteKickBanAutomod", Type) {
+				return i18n.T(parsed, "moderation.invalid_log_type"), nil
 			}
 
 			modLogs := ModLog{UserID: uint64(userID)}
@@ -1863,15 +1892,128 @@ var ModerationCommands = []*commands.YAGCommand{
 				model := Ban{ID: uint(ID)}
 
 				err = common.GORM.Model(&modLogs).Association("Bans").Delete(&model).Error
+			case "Automod":
+				model := AutomodAction{ID: uint(ID)}
+
+				err = common.GORM.Model(&modLogs).Association("AutomodActions").Delete(&model).Error
 			}
 
 			if err != nil {
 				return nil, err
 			}
 
-			return "Done.", nil
+			return i18n.T(parsed, "moderation.done"), nil
 		},
 	},
+	{
+		CustomEnabled:             true,
+		CmdCategory:               commands.CategoryModeration,
+		Name:                      "Case",
+		Description:               "Shows a moderation case",
+		RequireDiscordPerms:       []int64{discordgo.PermissionKickMembers},
+		RequiredDiscordPermsHelp:  "KickMembers",
+		ApplicationCommandEnabled: true,
+		DefaultEnabled:            true,
+		RequiredArgs:              1,
+		Arguments: []*dcmd.ArgDef{
+			{Name: "ID", Help: "The case number", Type: dcmd.Int},
+		},
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			modCase, err := findCaseCmd(parsed, parsed.Args[0].Int64())
+			if err != nil {
+				return nil, err
+			}
+
+			return caseEmbed(modCase), nil
+		},
+	},
+	{
+		CustomEnabled:             true,
+		CmdCategory:               commands.CategoryModeration,
+		Name:                      "Reason",
+		Description:               "Changes the reason of a moderation case, also updating its modlog entry",
+		RequireDiscordPerms:       []int64{discordgo.PermissionKickMembers},
+		RequiredDiscordPermsHelp:  "KickMembers",
+		ApplicationCommandEnabled: true,
+		DefaultEnabled:            true,
+		IsResponseEphemeral:       true,
+		RequiredArgs:              2,
+		Arguments: []*dcmd.ArgDef{
+			{Name: "ID", Help: "The case number", Type: dcmd.Int},
+			{Name: "Reason", Help: "The new reason", Type: dcmd.String},
+		},
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			modCase, err := findCaseCmd(parsed, parsed.Args[0].Int64())
+			if err != nil {
+				return nil, err
+			}
+
+			reason := common.CutStringShort(parsed.Args[1].Str(), 1000)
+			err = UpdateCaseReason(modCase, reason)
+			if err != nil {
+				return nil, err
+			}
+
+			return i18n.T(parsed, "moderation.case_reason_updated", modCase.CaseNumber), nil
+		},
+	},
+	{
+		CustomEnabled:             true,
+		CmdCategory:               commands.CategoryModeration,
+		Name:                      "Cases",
+		Description:               "Lists the moderation cases of a user",
+		RequireDiscordPerms:       []int64{discordgo.PermissionKickMembers},
+		RequiredDiscordPermsHelp:  "KickMembers",
+		ApplicationCommandEnabled: true,
+		DefaultEnabled:            true,
+		RequiredArgs:              1,
+		Arguments: []*dcmd.ArgDef{
+			{Name: "User", Type: dcmd.UserID},
+			{Name: "Page", Type: dcmd.Int, Default: 0},
+		},
+		RunFunc: paginatedmessages.PaginatedCommand(1, func(parsed *dcmd.Data, p *paginatedmessages.PaginatedMessage, page int) (*discordgo.MessageEmbed, error) {
+			const perPage = 10
+
+			guildID := parsed.GuildData.GS.ID
+			userID := parsed.Args[0].Int64()
+
+			var total int
+			err := common.GORM.Model(&ModerationCase{}).Where("guild_id = ? AND user_id = ?", guildID, userID).Count(&total).Error
+			if err != nil {
+				return nil, err
+			}
+
+			var cases []*ModerationCase
+			err = common.GORM.Where("guild_id = ? AND user_id = ?", guildID, userID).Order("case_number desc").Limit(perPage).Offset((page - 1) * perPage).Find(&cases).Error
+			if err != nil {
+				return nil, err
+			}
+
+			if len(cases) < 1 && p != nil && p.LastResponse != nil { //Dont send No Results error on first execution
+				return nil, paginatedmessages.ErrNoResults
+			}
+
+			lines := make([]string, 0, len(cases))
+			for _, v := range cases {
+				lines = append(lines, caseLine(v))
+			}
+
+			desc := strings.Join(lines, "\n")
+			if desc == "" {
+				desc = "No cases"
+			}
+
+			name := strconv.FormatInt(userID, 10)
+			if len(cases) > 0 {
+				name = cases[0].Username
+			}
+
+			return &discordgo.MessageEmbed{
+				Title:       fmt.Sprintf("Cases of %s (%d total)", name, total),
+				Description: desc,
+			}, nil
+		}),
+	},
 	{
 		CustomEnabled:             true,
 		CmdCategory:               commands.CategoryModeration,
@@ -2055,6 +2197,10 @@ func modLogPager(member *dstate.MemberState, p *paginatedmessages.PaginatedMessa
 			embed.Description = embed.Description + fmt.Sprintf("\n **Duration**: %s", entry.Duration)
 		}
 
+		if entry.Points > 0 {
+			embed.Description = embed.Description + fmt.Sprintf("\n **Points**: %d", entry.Points)
+		}
+
 		embed.Description = embed.Description + fmt.Sprintf("\n **Given By**: %s\n **Given At**: <t:%d:f>\n **Log**: %s\n", entry.Author.Mention(), entry.GivenAt.Unix(), fmt.Sprintf("[Link](%s)", entry.LogLink))
 	}
 
@@ -2065,12 +2211,17 @@ func generatePunishList(guildID int64, userID uint64) ([]ModLogEntry, error) {
 	modLogs := ModLog{UserID: userID}
 	var modLogEntries []ModLogEntry
 
-	err := common.GORM.Model(&modLogs).Preload("Warns").Preload("Mutes").Preload("Kicks").Preload("Bans").First(&modLogs).Error
+	err := common.GORM.Model(&modLogs).Preload("Warns").Preload("Mutes").Preload("Kicks").Preload("Bans").Preload("AutomodActions").First(&modLogs).Error
 	if err != nil {
 		log.Warn(err)
 		return []ModLogEntry{}, nil
 	}
 
+	config, err := GetConfig(guildID)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, entry := range modLogs.Warns {
 		authorID, parseErr := strconv.ParseInt(entry.AuthorID, 10, 64)
 		member, parseErr := bot.GetMember(guildID, authorID)
@@ -2078,7 +2229,12 @@ func generatePunishList(guildID int64, userID uint64) ([]ModLogEntry, error) {
 			return nil, err
 		}
 
-		modLogEntries = append(modLogEntries, ModLogEntry{Type: "Warn", ID: uint64(entry.ID), Author: member.User, LogLink: entry.LogLink, Reason: entry.Reason, GivenAt: entry.CreatedAt})
+		modLogEntry := ModLogEntry{Type: "Warn", ID: uint64(entry.ID), Author: member.User, LogLink: entry.LogLink, Reason: entry.Reason, GivenAt: entry.CreatedAt}
+		if config.WarnPointsEnabled {
+			modLogEntry.Points = entry.Points
+		}
+
+		modLogEntries = append(modLogEntries, modLogEntry)
 	}
 
 	for _, entry := range modLogs.Mutes {
@@ -2113,6 +2269,16 @@ func generatePunishList(guildID int64, userID uint64) ([]ModLogEntry, error) {
 		modLogEntries = append(modLogEntries, ModLogEntry{Type: "Ban", ID: uint64(entry.ID), Author: member.User, LogLink: entry.LogLink, Reason: entry.Reason, Duration: entry.Duration, GivenAt: entry.CreatedAt})
 	}
 
+	for _, entry := range modLogs.AutomodActions {
+		authorID, parseErr := strconv.ParseInt(entry.AuthorID, 10, 64)
+		member, parseErr := bot.GetMember(guildID, authorID)
+		if parseErr != nil {
+			return nil, err
+		}
+
+		modLogEntries = append(modLogEntries, ModLogEntry{Type: "Automod", ID: uint64(entry.ID), Author: member.User, LogLink: entry.LogLink, Reason: entry.Reason, Duration: entry.Duration, GivenAt: entry.CreatedAt})
+	}
+
 	return modLogEntries, nil
 }
 
@@ -2296,3 +2462,12 @@ func AdvancedDeleteMessages(guildID, channelID int64, triggerID int64, filterUse
 
 	return len(toDelete), err
 }
+
+func findCaseCmd(parsed *dcmd.Data, caseNumber int64) (*ModerationCase, error) {
+	modCase, err := FindCase(parsed.GuildData.GS.ID, caseNumber)
+	if err == gorm.ErrRecordNotFound {
+		return nil, commands.NewUserError(i18n.T(parsed, "moderation.case_not_found", caseNumber))
+	}
+
+	return modCase, err
+}
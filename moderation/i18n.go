@@ -0,0 +1,14 @@
+package moderation
+
+import (
+	"embed"
+
+	"github.com/cirelion/flint/common/i18n"
+)
+
+//go:embed assets/i18n/*.json
+var catalogFS embed.FS
+
+func init() {
+	i18n.MustRegisterCatalogFS(catalogFS, "assets/i18n")
+}
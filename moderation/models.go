@@ -52,6 +52,11 @@ type Config struct {
 	UnmuteMessage           string        `valid:"template,5000"`
 	DefaultMuteDuration     sql.NullInt64 `gorm:"default:10" valid:"0,"`
 
+	// Ban appeals
+	AppealsEnabled     bool
+	AppealChannel      int64 `valid:"channel,true"`
+	AppealCooldownDays int64 `gorm:"default:30" valid:"1,365"`
+
 	// Warn
 	WarnCommandsEnabled    bool
 	WarnCmdRoles           pq.Int64Array `gorm:"type:bigint[]" valid:"role,true"`
@@ -59,6 +64,13 @@ type Config struct {
 	WarnSendToModlog       bool
 	WarnMessage            string `valid:"template,5000"`
 
+	// Warning points
+	WarnPointsEnabled   bool
+	WarnDefaultPoints   int64  `valid:"0,100"`
+	WarnPointsDecayDays int64  `valid:"0,3650"`
+	WarnPointRules      string `valid:",5000"`
+	WarnEscalation      string `valid:",2000"`
+
 	// Message logs
 	EditLogChannel   string        `valid:"channel,true"`
 	DeleteLogChannel string        `valid:"channel,true"`
@@ -94,6 +106,7 @@ type Config struct {
 	LogBans          bool
 	LogKicks         bool `gorm:"default:true"`
 	LogTimeouts      bool
+	LogAutomod       bool
 
 	GiveRoleCmdEnabled bool
 	GiveRoleCmdModlog  bool
@@ -186,6 +199,7 @@ type WarningModel struct {
 
 	Message  string
 	LogsLink string
+	Points   int64
 }
 
 func (w *WarningModel) TableName() string {
@@ -201,6 +215,8 @@ type ModLog struct {
 	Kicks []Kick `gorm:"foreignKey:ModLogID;references:UserID"`
 	Bans  []Ban  `gorm:"foreignKey:ModLogID;references:UserID"`
 
+	AutomodActions []AutomodAction `gorm:"foreignKey:ModLogID;references:UserID"`
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -213,6 +229,7 @@ type Warn struct {
 	Reason  string
 	LogLink string
 	Proof   string
+	Points  int64
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -259,6 +276,21 @@ type Ban struct {
 	UpdatedAt time.Time
 }
 
+// AutomodAction is an action taken by Discord's native AutoMod, such as a blocked message or a timeout
+type AutomodAction struct {
+	ID       uint `gorm:"primary_key"`
+	ModLogID uint64
+	AuthorID string
+
+	Reason   string
+	Duration time.Duration
+	LogLink  string
+	Proof    string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
 type WatchList struct {
 	UserID   uint64 `gorm:"primary_key"`
 	GuildID  int64  `gorm:"index"`
@@ -52,7 +52,23 @@ func RegisterPlugin() {
 	common.RegisterPlugin(plugin)
 
 	configstore.RegisterConfig(configstore.SQL, &Config{})
-	common.GORM.AutoMigrate(&Config{}, &WarningModel{}, &MuteModel{}, &WatchList{}, &Feud{}, &VerbalWarning{}, &ModLog{}, &Warn{}, &Mute{}, &Kick{}, &Ban{})
+
+	// the points columns can't have a gorm default as gorm would leave 0 out of inserts,
+	// so they're added here first to give the rows from before warning points 1 point
+	for _, q := range warnPointsMigrations {
+		err := common.GORM.Exec(q).Error
+		if err != nil {
+			logger.WithError(err).Error("failed adding warning points columns")
+		}
+	}
+
+	common.GORM.AutoMigrate(&Config{}, &WarningModel{}, &MuteModel{}, &WatchList{}, &Feud{}, &VerbalWarning{}, &ModLog{}, &Warn{}, &Mute{}, &Kick{}, &Ban{}, &AutomodAction{}, &ModerationCase{}, &caseCounter{}, &BanAppeal{})
+}
+
+var warnPointsMigrations = []string{
+	`ALTER TABLE IF EXISTS moderation_configs ADD COLUMN IF NOT EXISTS warn_default_points BIGINT NOT NULL DEFAULT 1;`,
+	`ALTER TABLE IF EXISTS moderation_warnings ADD COLUMN IF NOT EXISTS points BIGINT NOT NULL DEFAULT 1;`,
+	`ALTER TABLE IF EXISTS warns ADD COLUMN IF NOT EXISTS points BIGINT NOT NULL DEFAULT 1;`,
 }
 
 func getConfigIfNotSet(guildID int64, config *Config) (*Config, error) {
@@ -71,6 +87,8 @@ func GetConfig(guildID int64) (*Config, error) {
 	var config Config
 	err := configstore.Cached.GetGuildConfig(context.Background(), guildID, &config)
 	if err == configstore.ErrNotFound {
+		config.GuildID = guildID
+		config.WarnDefaultPoints = 1
 		err = nil
 	}
 	return &config, err
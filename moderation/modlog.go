@@ -3,7 +3,6 @@ package moderation
 import (
 	"fmt"
 	"github.com/cirelion/flint/bot"
-	"regexp"
 	"strings"
 	"time"
 
@@ -17,6 +16,9 @@ type ModlogAction struct {
 	Color  int
 
 	Footer string
+
+	// Case is the case type recorded for this action, empty if it's not tracked as a case
+	Case string
 }
 
 func (m ModlogAction) String() string {
@@ -29,17 +31,19 @@ func (m ModlogAction) String() string {
 }
 
 var (
-	MAMute           = ModlogAction{Prefix: "Muted", Emoji: "🔇", Color: 0x57728e}
-	MAUnmute         = ModlogAction{Prefix: "Unmuted", Emoji: "🔊", Color: 0x62c65f}
-	MAKick           = ModlogAction{Prefix: "Kicked", Emoji: "👢", Color: 0xf2a013}
-	MABanned         = ModlogAction{Prefix: "Banned", Emoji: "🔨", Color: 0xd64848}
-	MAUnbanned       = ModlogAction{Prefix: "Unbanned", Emoji: "🔓", Color: 0x62c65f}
-	MAWarned         = ModlogAction{Prefix: "Warned", Emoji: "⚠", Color: 0xfca253}
-	MATimeoutAdded   = ModlogAction{Prefix: "Timed out", Emoji: "⏱", Color: 0x9b59b6}
-	MATimeoutRemoved = ModlogAction{Prefix: "Timeout removed from", Emoji: "⏱", Color: 0x9b59b6}
+	MAMute           = ModlogAction{Prefix: "Muted", Emoji: "🔇", Color: 0x57728e, Case: CaseMute}
+	MAUnmute         = ModlogAction{Prefix: "Unmuted", Emoji: "🔊", Color: 0x62c65f, Case: CaseUnmute}
+	MAKick           = ModlogAction{Prefix: "Kicked", Emoji: "👢", Color: 0xf2a013, Case: CaseKick}
+	MABanned         = ModlogAction{Prefix: "Banned", Emoji: "🔨", Color: 0xd64848, Case: CaseBan}
+	MAUnbanned       = ModlogAction{Prefix: "Unbanned", Emoji: "🔓", Color: 0x62c65f, Case: CaseUnban}
+	MAWarned         = ModlogAction{Prefix: "Warned", Emoji: "⚠", Color: 0xfca253, Case: CaseWarn}
+	MATimeoutAdded   = ModlogAction{Prefix: "Timed out", Emoji: "⏱", Color: 0x9b59b6, Case: CaseTimeout}
+	MATimeoutRemoved = ModlogAction{Prefix: "Timeout removed from", Emoji: "⏱", Color: 0x9b59b6, Case: CaseTimeoutRemoved}
 	MAGiveRole       = ModlogAction{Prefix: "", Emoji: "➕", Color: 0x53fcf9}
 	MARemoveRole     = ModlogAction{Prefix: "", Emoji: "➖", Color: 0x53fcf9}
 	MAClearWarnings  = ModlogAction{Prefix: "Cleared warnings", Emoji: "👌", Color: 0x62c65f}
+	MAAutomodBlocked = ModlogAction{Prefix: "Blocked by AutoMod", Emoji: "🛡", Color: 0x5865f2, Case: CaseAutomodBlocked}
+	MAAutomodTimeout = ModlogAction{Prefix: "Timed out by AutoMod", Emoji: "🛡", Color: 0x9b59b6, Case: CaseAutomodTimeout}
 )
 
 func generateGenericModEmbed(action ModlogAction, author *discordgo.User, target *discordgo.User, reason string, logLink string, proof string, duration time.Duration, guildID int64) *discordgo.MessageEmbed {
@@ -54,7 +58,7 @@ func generateGenericModEmbed(action ModlogAction, author *discordgo.User, target
 	}
 
 	if duration > 0 {
-		embed.Description = embed.Description + fmt.Sprintf("\n**Duration:** %s", common.HumanizeDuration(common.DurationPrecisionMinutes, duration))
+		embed.Description = embed.Description + fmt.Sprintf("\n**Duration:** %s (until <t:%d:f>)", common.HumanizeDuration(common.DurationPrecisionMinutes, duration), time.Now().Add(duration).Unix())
 	}
 	if proof != "" {
 		embed.Description = embed.Description + fmt.Sprintf("\n**Proof:** %s", proof)
@@ -75,14 +79,21 @@ func generateGenericModEmbed(action ModlogAction, author *discordgo.User, target
 	return embed
 }
 
-func CreateModlogEmbed(config *Config, author *discordgo.User, action ModlogAction, target *discordgo.User, reason, logLink string, proof string, duration time.Duration) error {
+// CreateModlogEmbed records the action as a case and posts it in the modlog channel, if one is set
+func CreateModlogEmbed(config *Config, author *discordgo.User, action ModlogAction, target *discordgo.User, reason, logLink string, proof string, duration time.Duration, fields ...*discordgo.MessageEmbedField) error {
+	modCase := recordCase(config.GuildID, author, action, target, reason, logLink, proof, duration)
+
 	channelID := config.IntActionChannel()
-	config.GetGuildID()
 	if channelID == 0 {
 		return nil
 	}
 	embed := generateGenericModEmbed(action, author, target, reason, logLink, proof, duration, config.GuildID)
-	_, err := common.BotSession.ChannelMessageSendEmbed(channelID, embed)
+	embed.Fields = append(embed.Fields, fields...)
+	if modCase != nil {
+		embed.Title = fmt.Sprintf("Case #%d | %s", modCase.CaseNumber, embed.Title)
+	}
+
+	msg, err := common.BotSession.ChannelMessageSendEmbed(channelID, embed)
 	if err != nil {
 		if common.IsDiscordErr(err, discordgo.ErrCodeMissingAccess, discordgo.ErrCodeMissingPermissions, discordgo.ErrCodeUnknownChannel) {
 			// disable the modlog
@@ -93,30 +104,99 @@ func CreateModlogEmbed(config *Config, author *discordgo.User, action ModlogActi
 		return err
 	}
 
-	return err
+	if modCase != nil {
+		// remember the message so the reason can be edited later on
+		err = common.GORM.Model(modCase).Updates(map[string]interface{}{"modlog_channel_id": channelID, "modlog_message_id": msg.ID}).Error
+		common.LogIgnoreError(err, "[moderation] failed saving modlog message of case", nil)
+	}
+
+	return nil
 }
 
-var (
-	logsRegex = regexp.MustCompile(`\(\[Logs\]\(.*\)\)`)
-)
+// CreateAutomodRuleEmbed logs the creation, update or removal of one of Discord's native AutoMod rules
+func CreateAutomodRuleEmbed(config *Config, rule *discordgo.AutoModerationRule, change string) error {
+	channelID := config.IntActionChannel()
+	if channelID == 0 {
+		return nil
+	}
 
-func updateEmbedReason(author *discordgo.User, reason string, embed *discordgo.MessageEmbed) {
-	const checkStr = "📄**Reason:**"
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("AutoMod Rule %s", change),
+		Color:       MAAutomodBlocked.Color,
+		Description: fmt.Sprintf(">>> **Rule:** %s (%d)\n**Trigger:** %s", rule.Name, rule.ID, AutomodTriggerTypeName(rule.TriggerType)),
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
 
-	index := strings.Index(embed.Description, checkStr)
-	withoutReason := embed.Description[:index+len(checkStr)]
+	if len(rule.Actions) > 0 {
+		actions := make([]string, 0, len(rule.Actions))
+		for _, v := range rule.Actions {
+			actions = append(actions, AutomodActionTypeName(v.Type))
+		}
 
-	logsLink := logsRegex.FindString(embed.Description)
-	if logsLink != "" {
-		logsLink = " " + logsLink
+		embed.Description += fmt.Sprintf("\n**Actions:** %s", strings.Join(actions, ", "))
 	}
 
-	embed.Description = withoutReason + " " + reason + logsLink
+	if rule.CreatorID != 0 {
+		embed.Description += fmt.Sprintf("\n**Created by:** <@%d>", rule.CreatorID)
+	}
 
-	if author != nil {
-		embed.Author = &discordgo.MessageEmbedAuthor{
-			Name:    fmt.Sprintf("%s (ID %d)", author.String(), author.ID),
-			IconURL: discordgo.EndpointUserAvatar(author.ID, author.Avatar),
+	_, err := common.BotSession.ChannelMessageSendEmbed(channelID, embed)
+	if err != nil && common.IsDiscordErr(err, discordgo.ErrCodeMissingAccess, discordgo.ErrCodeMissingPermissions, discordgo.ErrCodeUnknownChannel) {
+		// disable the modlog
+		config.ActionChannel = ""
+		config.Save(config.GetGuildID())
+		return nil
+	}
+
+	return err
+}
+
+func AutomodTriggerTypeName(t discordgo.AutoModerationRuleTriggerType) string {
+	switch t {
+	case discordgo.AutoModerationEventTriggerKeyword:
+		return "Keyword"
+	case discordgo.AutoModerationEventTriggerHarmfulLink:
+		return "Harmful link"
+	case discordgo.AutoModerationEventTriggerSpam:
+		return "Spam"
+	case discordgo.AutoModerationEventTriggerKeywordPreset:
+		return "Keyword preset"
+	}
+
+	return "Unknown"
+}
+
+func AutomodActionTypeName(t discordgo.AutoModerationActionType) string {
+	switch t {
+	case discordgo.AutoModerationRuleActionBlockMessage:
+		return "Block message"
+	case discordgo.AutoModerationRuleActionSendAlertMessage:
+		return "Send alert"
+	case discordgo.AutoModerationRuleActionTimeout:
+		return "Timeout"
+	}
+
+	return "Unknown"
+}
+
+// updateEmbedReason replaces the reason in a modlog embed made by generateGenericModEmbed, returns false if it has none
+func updateEmbedReason(reason string, embed *discordgo.MessageEmbed) bool {
+	const checkStr = "**Reason:** "
+
+	start := strings.Index(embed.Description, checkStr)
+	if start == -1 {
+		return false
+	}
+	start += len(checkStr)
+
+	// the reason is followed by the optional duration and proof lines
+	end := len(embed.Description)
+	for _, next := range []string{"\n**Duration:**", "\n**Proof:**"} {
+		if i := strings.Index(embed.Description[start:], next); i != -1 && start+i < end {
+			end = start + i
 		}
 	}
+
+	embed.Description = embed.Description[:start] + reason + embed.Description[end:]
+	return true
 }
@@ -1,6 +1,7 @@
 package moderation
 
 import (
+	"fmt"
 	"math/rand"
 	"strconv"
 	"strings"
@@ -55,6 +56,9 @@ func (p *Plugin) BotInit() {
 	eventsystem.AddHandlerAsyncLast(p, LockMemberMuteMW(HandleMemberJoin), eventsystem.EventGuildMemberAdd)
 	eventsystem.AddHandlerAsyncLast(p, LockMemberMuteMW(HandleGuildMemberUpdate), eventsystem.EventGuildMemberUpdate)
 	eventsystem.AddHandlerAsyncLast(p, HandleGuildMemberTimeoutChange, eventsystem.EventGuildMemberUpdate)
+	eventsystem.AddHandlerAsyncLast(p, HandleAutomodActionExecution, eventsystem.EventAutoModerationActionExecution)
+	eventsystem.AddHandlerAsyncLastLegacy(p, handleAppealInteraction, eventsystem.EventInteractionCreate)
+	eventsystem.AddHandlerAsyncLast(p, HandleAutomodRuleChange, eventsystem.EventAutoModerationRuleCreate, eventsystem.EventAutoModerationRuleUpdate, eventsystem.EventAutoModerationRuleDelete)
 
 	eventsystem.AddHandlerAsyncLastLegacy(p, bot.ConcurrentEventHandler(HandleGuildCreate), eventsystem.EventGuildCreate)
 	eventsystem.AddHandlerAsyncLast(p, HandleChannelCreateUpdate, eventsystem.EventChannelCreate, eventsystem.EventChannelUpdate)
@@ -311,6 +315,106 @@ func HandleGuildMemberTimeoutChange(evt *eventsystem.EventData) (retry bool, err
 	return false, nil
 }
 
+// HandleAutomodActionExecution mirrors blocks and timeouts done by Discord's native AutoMod into the modlog
+func HandleAutomodActionExecution(evt *eventsystem.EventData) (retry bool, err error) {
+	data := evt.AutoModerationActionExecution()
+
+	var action ModlogAction
+	var duration time.Duration
+	switch data.Action.Type {
+	case discordgo.AutoModerationRuleActionBlockMessage:
+		action = MAAutomodBlocked
+	case discordgo.AutoModerationRuleActionTimeout:
+		action = MAAutomodTimeout
+		if data.Action.Metadata != nil {
+			duration = time.Duration(data.Action.Metadata.Duration) * time.Second
+		}
+	default:
+		// Alerts are already posted by discord in the configured alert channel
+		return false, nil
+	}
+
+	config, err := GetConfig(data.GuildID)
+	if err != nil {
+		return true, errors.WithStackIf(err)
+	}
+
+	if !config.LogAutomod {
+		return false, nil
+	}
+
+	reason := automodExecutionReason(data)
+	content := common.CutStringShort(data.Content, 1000)
+
+	err = SaveModLog("Automod", data.GuildID, discordgo.StrID(common.BotUser.ID), uint64(data.UserID), reason, "", content, duration)
+	if err != nil {
+		return false, errors.WithStackIf(err)
+	}
+
+	target := &discordgo.User{ID: data.UserID}
+	if ms, err := bot.GetMember(data.GuildID, data.UserID); err == nil {
+		target = &ms.User
+	}
+
+	err = CreateModlogEmbed(config, common.BotUser, action, target, reason, "", content, duration)
+	if err != nil {
+		logger.WithError(err).WithField("guild", data.GuildID).Error("Failed sending automod log message")
+	}
+
+	return false, nil
+}
+
+func automodExecutionReason(data *discordgo.AutoModerationActionExecution) string {
+	ruleName := strconv.FormatInt(data.RuleID, 10)
+	rule, err := common.BotSession.AutoModerationRule(data.GuildID, data.RuleID)
+	if err == nil {
+		ruleName = rule.Name
+	}
+
+	reason := fmt.Sprintf("Discord AutoMod rule %s (%s)", ruleName, AutomodTriggerTypeName(data.RuleTriggerType))
+	if data.MatchedKeyword != "" {
+		reason += fmt.Sprintf(", matched `%s`", data.MatchedKeyword)
+	}
+
+	return reason
+}
+
+func HandleAutomodRuleChange(evt *eventsystem.EventData) (retry bool, err error) {
+	var rule *discordgo.AutoModerationRule
+	var change string
+	switch evt.Type {
+	case eventsystem.EventAutoModerationRuleCreate:
+		rule = evt.AutoModerationRuleCreate().AutoModerationRule
+		change = "Created"
+	case eventsystem.EventAutoModerationRuleUpdate:
+		rule = evt.AutoModerationRuleUpdate().AutoModerationRule
+		change = "Updated"
+	case eventsystem.EventAutoModerationRuleDelete:
+		rule = evt.AutoModerationRuleDelete().AutoModerationRule
+		change = "Deleted"
+	}
+
+	if rule == nil {
+		return false, nil
+	}
+
+	config, err := GetConfig(rule.GuildID)
+	if err != nil {
+		return true, errors.WithStackIf(err)
+	}
+
+	if !config.LogAutomod {
+		return false, nil
+	}
+
+	err = CreateAutomodRuleEmbed(config, rule, change)
+	if err != nil {
+		logger.WithError(err).WithField("guild", rule.GuildID).Error("Failed sending automod rule log message")
+	}
+
+	return false, nil
+}
+
 func HandleGuildBanAddRemove(evt *eventsystem.EventData) {
 	var user *discordgo.User
 	var guildID = evt.GS.ID
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"time"
 
 	"github.com/cirelion/flint/common"
 	"github.com/cirelion/flint/common/cplogs"
@@ -17,6 +18,9 @@ import (
 //go:embed assets/moderation.html
 var PageHTML string
 
+//go:embed assets/appeal.html
+var AppealPageHTML string
+
 var (
 	panelLogKeyUpdatedSettings = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "moderation_settings_updated", FormatString: "Updated moderation config"})
 	panelLogKeyClearWarnings   = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "moderation_warnings_cleared", FormatString: "Cleared %d moderation user warnings"})
@@ -24,6 +28,7 @@ var (
 
 func (p *Plugin) InitWeb() {
 	web.AddHTMLTemplate("moderation/assets/moderation.html", PageHTML)
+	web.AddHTMLTemplate("moderation/assets/appeal.html", AppealPageHTML)
 
 	web.AddSidebarItem(web.SidebarCategoryTools, &web.SidebarItem{
 		Name: "Moderation",
@@ -47,6 +52,12 @@ func (p *Plugin) InitWeb() {
 	subMux.Handle(pat.Post(""), postHandler)
 	subMux.Handle(pat.Post("/"), postHandler)
 	subMux.Handle(pat.Post("/clear_server_warnings"), clearServerWarnings)
+
+	// the appeal page is public, banned users can't access the control panel of the server
+	getAppealHandler := web.ControllerHandler(HandleGetAppeal, "moderation_appeal_page")
+	postAppealHandler := web.ControllerPostHandler(HandlePostAppeal, getAppealHandler, AppealForm{})
+	web.ServerPublicMux.Handle(pat.Get("/appeal"), web.RequireSessionMiddleware(getAppealHandler))
+	web.ServerPublicMux.Handle(pat.Post("/appeal"), web.RequireSessionMiddleware(postAppealHandler))
 }
 
 // HandleModeration servers the moderation page itself
@@ -107,6 +118,123 @@ func HandleClearServerWarnings(w http.ResponseWriter, r *http.Request) (web.Temp
 	return templateData, nil
 }
 
+type AppealForm struct {
+	Appeal string `valid:",20,2000"`
+}
+
+// HandleGetAppeal shows the banned user their ban reason and the appeal form, if they're allowed to appeal
+func HandleGetAppeal(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	user := web.ContextUser(ctx)
+
+	config, err := GetConfig(activeGuild.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	if !config.AppealsEnabled || config.AppealChannel == 0 {
+		templateData.AddAlerts(web.ErrorAlert("Ban appeals are not enabled on this server"))
+		return templateData, nil
+	}
+
+	ban, err := common.BotSession.GuildBan(activeGuild.ID, user.ID)
+	if err != nil {
+		notBanned, err := isNotFound(err)
+		if notBanned {
+			templateData["NotBanned"] = true
+			return templateData, nil
+		}
+		return templateData, err
+	}
+
+	templateData["BanReason"] = appealBanReason(activeGuild.ID, user.ID, ban.Reason)
+
+	last, err := lastAppeal(activeGuild.ID, user.ID)
+	if err != nil {
+		return templateData, err
+	}
+	templateData["LastAppeal"] = last
+
+	if last != nil && last.Status == AppealStatusPending {
+		return templateData, nil
+	}
+
+	if left := appealCooldownLeft(config, last, time.Now()); left > 0 {
+		templateData["CooldownLeft"] = common.HumanizeDuration(common.DurationPrecisionHours, left)
+		return templateData, nil
+	}
+
+	templateData["CanAppeal"] = true
+	return templateData, nil
+}
+
+// HandlePostAppeal submits the appeal to the appeal channel
+func HandlePostAppeal(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	user := web.ContextUser(ctx)
+	form := ctx.Value(common.ContextKeyParsedForm).(*AppealForm)
+
+	config, err := GetConfig(activeGuild.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	if !config.AppealsEnabled || config.AppealChannel == 0 {
+		return templateData, web.NewPublicError("Ban appeals are not enabled on this server")
+	}
+
+	ban, err := common.BotSession.GuildBan(activeGuild.ID, user.ID)
+	if err != nil {
+		notBanned, err := isNotFound(err)
+		if notBanned {
+			return templateData, web.NewPublicError("You're not banned from this server")
+		}
+		return templateData, err
+	}
+
+	last, err := lastAppeal(activeGuild.ID, user.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	if last != nil && last.Status == AppealStatusPending {
+		return templateData, web.NewPublicError("You already have an appeal awaiting review")
+	}
+
+	if appealCooldownLeft(config, last, time.Now()) > 0 {
+		return templateData, web.NewPublicError("You can't appeal again yet")
+	}
+
+	if !appealRatelimiter.AllowN(activeGuild.ID, time.Now(), 1) {
+		return templateData, web.NewPublicError("This server is receiving too many appeals right now, try again later")
+	}
+
+	appeal := &BanAppeal{
+		GuildID:   activeGuild.ID,
+		UserID:    user.ID,
+		Username:  user.String(),
+		BanReason: appealBanReason(activeGuild.ID, user.ID, ban.Reason),
+		Content:   form.Appeal,
+		Status:    AppealStatusPending,
+	}
+
+	err = common.GORM.Create(appeal).Error
+	if err != nil {
+		return templateData, err
+	}
+
+	err = postAppeal(config, appeal, user)
+	if err != nil {
+		common.GORM.Delete(appeal)
+		web.CtxLogger(ctx).WithError(err).Error("failed posting ban appeal")
+		return templateData, web.NewPublicError("Failed sending your appeal to the staff of this server, try again later")
+	}
+
+	return templateData, nil
+}
+
 var _ web.PluginWithServerHomeWidget = (*Plugin)(nil)
 
 func (p *Plugin) LoadServerHomeWidget(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
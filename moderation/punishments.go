@@ -353,6 +353,8 @@ func UnbanUser(config *Config, guildID int64, author *discordgo.User, reason str
 	//modLog Entry handling
 	if config.LogUnbans {
 		err = CreateModlogEmbed(config, author, action, user, reason, "", "", -1)
+	} else {
+		_, err = CreateCase(guildID, author, action, user, reason, "", "", -1)
 	}
 	return false, err
 }
@@ -625,6 +627,8 @@ func SaveModLog(variant string, guildID int64, authorID string, userID uint64, r
 		err = common.GORM.Model(&modLogs).Preload("Kicks").Association("Kicks").Append(&Kick{AuthorID: authorID, Reason: reason, LogLink: logLink, Proof: proof}).Error
 	case "Ban":
 		err = common.GORM.Model(&modLogs).Preload("Bans").Association("Bans").Append(&Ban{AuthorID: authorID, Reason: reason, Duration: duration, LogLink: logLink, Proof: proof}).Error
+	case "Automod":
+		err = common.GORM.Model(&modLogs).Preload("AutomodActions").Association("AutomodActions").Append(&AutomodAction{AuthorID: authorID, Reason: reason, Duration: duration, LogLink: logLink, Proof: proof}).Error
 	}
 
 	if err != nil {
@@ -634,7 +638,29 @@ func SaveModLog(variant string, guildID int64, authorID string, userID uint64, r
 	return nil
 }
 
+// saveWarnModLog is SaveModLog for warnings, which also keep track of the points they were given
+func saveWarnModLog(guildID int64, authorID string, userID uint64, reason string, logLink string, proof string, points int64) error {
+	modLogs := ModLog{UserID: userID, GuildID: guildID}
+
+	err := common.GORM.Model(&modLogs).FirstOrCreate(&modLogs).Error
+	if err != nil {
+		return err
+	}
+
+	return common.GORM.Model(&modLogs).Association("Warns").Append(&Warn{AuthorID: authorID, Reason: reason, LogLink: logLink, Proof: proof, Points: points}).Error
+}
+
 func WarnUser(config *Config, guildID int64, channel *dstate.ChannelState, msg *discordgo.Message, author *discordgo.User, target *discordgo.User, message string, proof string) error {
+	config, err := getConfigIfNotSet(guildID, config)
+	if err != nil {
+		return common.ErrWithCaller(err)
+	}
+
+	return WarnUserWithPoints(config, guildID, channel, msg, author, target, message, proof, config.WarnPointsForReason(message))
+}
+
+// WarnUserWithPoints warns the user with the specified amount of warning points, escalating the punishment if that makes them reach a step on the escalation ladder
+func WarnUserWithPoints(config *Config, guildID int64, channel *dstate.ChannelState, msg *discordgo.Message, author *discordgo.User, target *discordgo.User, message string, proof string, points int64) error {
 	warning := &WarningModel{
 		GuildID:               guildID,
 		UserID:                discordgo.StrID(target.ID),
@@ -642,6 +668,7 @@ func WarnUser(config *Config, guildID int64, channel *dstate.ChannelState, msg *
 		AuthorUsernameDiscrim: author.String(),
 
 		Message: message,
+		Points:  points,
 	}
 
 	var channelID int64
@@ -671,13 +698,32 @@ func WarnUser(config *Config, guildID int64, channel *dstate.ChannelState, msg *
 	}
 
 	// go bot.SendDM(target.ID, fmt.Sprintf("**%s**: You have been warned for: %s", bot.GuildName(guildID), message))
-	err = SaveModLog("Warn", guildID, discordgo.StrID(author.ID), uint64(target.ID), message, warning.LogsLink, proof, 0)
+	err = saveWarnModLog(guildID, discordgo.StrID(author.ID), uint64(target.ID), message, warning.LogsLink, proof, points)
 	if err != nil {
 		return err
 	}
 
+	var fields []*discordgo.MessageEmbedField
+	var activePoints int64
+	if config.WarnPointsEnabled {
+		activePoints, err = ActiveWarnPoints(config, guildID, target.ID)
+		if err != nil {
+			return common.ErrWithCaller(err)
+		}
+		fields = append(fields, warnPointsField(points, activePoints))
+	}
+
 	if config.WarnSendToModlog && config.ActionChannel != "" {
-		err = CreateModlogEmbed(config, author, MAWarned, target, message, warning.LogsLink, proof, 4*7*24*time.Hour)
+		err = CreateModlogEmbed(config, author, MAWarned, target, message, warning.LogsLink, proof, 4*7*24*time.Hour, fields...)
+	} else {
+		_, err = CreateCase(guildID, author, MAWarned, target, message, warning.LogsLink, proof, 4*7*24*time.Hour)
+	}
+	if err != nil {
+		return common.ErrWithCaller(err)
+	}
+
+	if config.WarnPointsEnabled {
+		err = escalateWarnings(config, guildID, target, activePoints-points, activePoints)
 		if err != nil {
 			return common.ErrWithCaller(err)
 		}
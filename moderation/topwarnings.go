@@ -2,6 +2,7 @@ package moderation
 
 import (
 	"database/sql"
+	"time"
 
 	// "github.com/cirelion/flint/lib/discordgo"
 	// "github.com/cirelion/flint/lib/dstate"
@@ -14,18 +15,25 @@ type WarnRankEntry struct {
 	UserID    int64  `json:"user_id"`
 	Username  string `json:"username"`
 	WarnCount int64  `json:"warn_count"`
+	Points    int64  `json:"points"`
 }
 
 func TopWarns(guildID int64, offset, limit int) ([]*WarnRankEntry, error) {
-	const query = `SELECT rank, warn_count, user_id FROM
+	const query = `SELECT rank, warn_count, points, user_id FROM
 	(
-		SELECT RANK() OVER (ORDER BY count(message) DESC) AS rank, count(*) as warn_count, user_id
+		SELECT RANK() OVER (ORDER BY count(message) DESC) AS rank, count(*) as warn_count,
+		COALESCE(SUM(points) FILTER (WHERE created_at > $4), 0) as points, user_id
 		FROM moderation_warnings WHERE guild_id = $1 group by user_id
 	) AS warns
 	ORDER BY warn_count desc
 	LIMIT $2 OFFSET $3`
 
-	rows, err := common.PQ.Query(query, guildID, limit, offset)
+	config, err := GetConfig(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := common.PQ.Query(query, guildID, limit, offset, warnPointsCutoff(config, time.Now()))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return []*WarnRankEntry{}, nil
@@ -41,7 +49,8 @@ func TopWarns(guildID int64, offset, limit int) ([]*WarnRankEntry, error) {
 		//var tmp []*dstate.MemberState
 		var userID int64
 		var warncount int64
-		var err = rows.Scan(&rank, &warncount, &userID)
+		var points int64
+		var err = rows.Scan(&rank, &warncount, &points, &userID)
 		if err != nil {
 			return nil, err
 		}
@@ -57,6 +66,7 @@ func TopWarns(guildID int64, offset, limit int) ([]*WarnRankEntry, error) {
 			Rank:      rank,
 			UserID:    userID,
 			WarnCount: warncount,
+			Points:    points,
 			Username:  username,
 		})
 	}
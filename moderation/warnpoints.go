@@ -0,0 +1,226 @@
+package moderation
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/cirelion/flint/bot"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/web"
+)
+
+// Actions the warning point escalation ladder can take
+const (
+	EscalateTimeout = "timeout"
+	EscalateMute    = "mute"
+	EscalateKick    = "kick"
+	EscalateBan     = "ban"
+)
+
+const maxTimeoutDuration = 28 * 24 * time.Hour
+
+// WarnPointRule gives warnings whose reason contains Keyword the specified amount of points
+type WarnPointRule struct {
+	Keyword string
+	Points  int64
+}
+
+// WarnEscalationStep is a single step of the escalation ladder, taken once a user reaches Points active warning points
+type WarnEscalationStep struct {
+	Points   int64
+	Action   string
+	Duration time.Duration
+}
+
+// ParseWarnPointRules parses the "keyword = points" lines of the point rules setting
+func ParseWarnPointRules(s string) ([]*WarnPointRule, error) {
+	var rules []*WarnPointRule
+	for i, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		sep := strings.LastIndex(line, "=")
+		if sep == -1 {
+			return nil, errors.Errorf("line %d: expected \"keyword = points\"", i+1)
+		}
+
+		keyword := strings.ToLower(strings.TrimSpace(line[:sep]))
+		if keyword == "" {
+			return nil, errors.Errorf("line %d: missing keyword", i+1)
+		}
+
+		points, err := strconv.ParseInt(strings.TrimSpace(line[sep+1:]), 10, 64)
+		if err != nil || points < 0 || points > 100 {
+			return nil, errors.Errorf("line %d: points has to be a number between 0 and 100", i+1)
+		}
+
+		rules = append(rules, &WarnPointRule{Keyword: keyword, Points: points})
+	}
+
+	return rules, nil
+}
+
+// ParseWarnEscalation parses the "<points> <action> [duration]" lines of the escalation setting, sorted by points
+func ParseWarnEscalation(s string) ([]*WarnEscalationStep, error) {
+	var steps []*WarnEscalationStep
+	for i, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) < 2 {
+			return nil, errors.Errorf("line %d: expected \"<points> <timeout|mute|kick|ban> [duration]\"", i+1)
+		}
+
+		points, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil || points < 1 {
+			return nil, errors.Errorf("line %d: points has to be a number above 0", i+1)
+		}
+
+		step := &WarnEscalationStep{Points: points, Action: strings.ToLower(fields[1])}
+		if len(fields) > 2 {
+			step.Duration, err = common.ParseDuration(strings.Join(fields[2:], ""))
+			if err != nil {
+				return nil, errors.Errorf("line %d: invalid duration", i+1)
+			}
+		}
+
+		switch step.Action {
+		case EscalateTimeout:
+			if step.Duration <= 0 || step.Duration > maxTimeoutDuration {
+				return nil, errors.Errorf("line %d: timeouts need a duration of at most 28 days", i+1)
+			}
+		case EscalateMute, EscalateBan:
+		case EscalateKick:
+			if step.Duration != 0 {
+				return nil, errors.Errorf("line %d: kicks can't have a duration", i+1)
+			}
+		default:
+			return nil, errors.Errorf("line %d: unknown action %q", i+1, fields[1])
+		}
+
+		for _, v := range steps {
+			if v.Points == step.Points {
+				return nil, errors.Errorf("line %d: there's already a step at %d points", i+1, points)
+			}
+		}
+
+		steps = append(steps, step)
+	}
+
+	sort.Slice(steps, func(i, j int) bool {
+		return steps[i].Points < steps[j].Points
+	})
+
+	return steps, nil
+}
+
+var _ web.CustomValidator = (*Config)(nil)
+
+func (c *Config) Validate(tmpl web.TemplateData) (ok bool) {
+	if _, err := ParseWarnPointRules(c.WarnPointRules); err != nil {
+		tmpl.AddAlerts(web.ErrorAlert("Warning point rules, " + err.Error()))
+		return false
+	}
+
+	if _, err := ParseWarnEscalation(c.WarnEscalation); err != nil {
+		tmpl.AddAlerts(web.ErrorAlert("Warning escalation, " + err.Error()))
+		return false
+	}
+
+	return true
+}
+
+// WarnPointsForReason returns the points of the first rule matching the reason, or the default amount if none do
+func (c *Config) WarnPointsForReason(reason string) int64 {
+	rules, _ := ParseWarnPointRules(c.WarnPointRules)
+
+	reason = strings.ToLower(reason)
+	for _, v := range rules {
+		if strings.Contains(reason, v.Keyword) {
+			return v.Points
+		}
+	}
+
+	return c.WarnDefaultPoints
+}
+
+// warnPointsCutoff returns the time warnings have to be given after to still count towards the active points
+func warnPointsCutoff(config *Config, now time.Time) time.Time {
+	if config.WarnPointsDecayDays < 1 {
+		return time.Time{}
+	}
+
+	return now.Add(-time.Duration(config.WarnPointsDecayDays) * 24 * time.Hour)
+}
+
+// ActiveWarnPoints returns the sum of the points of the warnings of the user that haven't decayed yet
+func ActiveWarnPoints(config *Config, guildID, userID int64) (int64, error) {
+	const q = `SELECT COALESCE(SUM(points), 0) FROM moderation_warnings WHERE guild_id = $1 AND user_id = $2 AND created_at > $3`
+
+	var points int64
+	err := common.PQ.QueryRow(q, guildID, discordgo.StrID(userID), warnPointsCutoff(config, time.Now())).Scan(&points)
+	return points, err
+}
+
+// escalationStep returns the highest step that was crossed going from before to after points
+func escalationStep(steps []*WarnEscalationStep, before, after int64) *WarnEscalationStep {
+	var crossed *WarnEscalationStep
+	for _, v := range steps {
+		if v.Points > before && v.Points <= after {
+			crossed = v
+		}
+	}
+
+	return crossed
+}
+
+// escalateWarnings punishes the user if their new warning made them reach a step of the escalation ladder
+func escalateWarnings(config *Config, guildID int64, target *discordgo.User, before, after int64) error {
+	steps, err := ParseWarnEscalation(config.WarnEscalation)
+	if err != nil {
+		return err
+	}
+
+	step := escalationStep(steps, before, after)
+	if step == nil {
+		return nil
+	}
+
+	reason := fmt.Sprintf("Reached %d warning points", after)
+	if step.Action == EscalateBan {
+		return BanUserWithDuration(config, guildID, nil, nil, common.BotUser, reason, "", target, step.Duration, int(config.DefaultBanDeleteDays.Int64))
+	}
+
+	member, err := bot.GetMember(guildID, target.ID)
+	if err != nil || member == nil {
+		// not in the server anymore, nothing to do
+		return nil
+	}
+
+	switch step.Action {
+	case EscalateTimeout:
+		return TimeoutUser(config, guildID, nil, nil, common.BotUser, reason, "", target, step.Duration)
+	case EscalateMute:
+		return MuteUnmuteUser(config, true, guildID, nil, nil, common.BotUser, reason, "", member, int(step.Duration.Minutes()))
+	case EscalateKick:
+		return KickUser(config, guildID, nil, nil, common.BotUser, reason, "", target, 0)
+	}
+
+	return nil
+}
+
+func warnPointsField(points, active int64) *discordgo.MessageEmbedField {
+	return &discordgo.MessageEmbedField{
+		Name:  "Warning points",
+		Value: fmt.Sprintf("+%d (%d active)", points, active),
+	}
+}
@@ -0,0 +1,94 @@
+package moderation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWarnEscalation(t *testing.T) {
+	steps, err := ParseWarnEscalation("10 ban\n\n3 timeout 1h\n6 KICK\n8 mute 1d12h")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []WarnEscalationStep{
+		{Points: 3, Action: EscalateTimeout, Duration: time.Hour},
+		{Points: 6, Action: EscalateKick},
+		{Points: 8, Action: EscalateMute, Duration: 36 * time.Hour},
+		{Points: 10, Action: EscalateBan},
+	}
+
+	if len(steps) != len(expected) {
+		t.Fatalf("expected %d steps, got %d", len(expected), len(steps))
+	}
+
+	for i, v := range expected {
+		if *steps[i] != v {
+			t.Errorf("step %d: expected %+v, got %+v", i, v, *steps[i])
+		}
+	}
+
+	invalid := []string{
+		"3",
+		"abc timeout 1h",
+		"3 timeout",
+		"3 timeout 30d",
+		"3 kick 1h",
+		"3 explode",
+		"3 kick\n3 ban",
+	}
+
+	for _, v := range invalid {
+		if _, err := ParseWarnEscalation(v); err == nil {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestWarnPointsForReason(t *testing.T) {
+	config := &Config{WarnDefaultPoints: 1, WarnPointRules: "spam = 2\nracial slur = 5"}
+
+	cases := map[string]int64{
+		"Spamming in general": 2,
+		"used a RACIAL SLUR":  5,
+		"being rude":          1,
+	}
+
+	for reason, expected := range cases {
+		if points := config.WarnPointsForReason(reason); points != expected {
+			t.Errorf("%q: expected %d points, got %d", reason, expected, points)
+		}
+	}
+
+	if _, err := ParseWarnPointRules("spam 2"); err == nil {
+		t.Error("expected a rule without = to be invalid")
+	}
+}
+
+func TestEscalationStep(t *testing.T) {
+	steps, _ := ParseWarnEscalation("3 timeout 1h\n6 kick\n10 ban")
+
+	cases := []struct {
+		before, after int64
+		expected      string
+	}{
+		{0, 1, ""},
+		{2, 3, EscalateTimeout},
+		{3, 4, ""},
+		{2, 7, EscalateKick},
+		{5, 12, EscalateBan},
+		{10, 11, ""},
+	}
+
+	for _, c := range cases {
+		step := escalationStep(steps, c.before, c.after)
+		action := ""
+		if step != nil {
+			action = step.Action
+		}
+
+		if action != c.expected {
+			t.Errorf("%d -> %d: expected %q, got %q", c.before, c.after, c.expected, action)
+		}
+	}
+}
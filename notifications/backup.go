@@ -0,0 +1,32 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cirelion/flint/backup"
+	"github.com/cirelion/flint/common/configstore"
+)
+
+var _ backup.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	return GetConfig(guildID)
+}
+
+func (p *Plugin) ImportBackup(ctx context.Context, ic *backup.ImportContext, data json.RawMessage) error {
+	var config Config
+	err := json.Unmarshal(data, &config)
+	if err != nil {
+		return err
+	}
+
+	ic.Remap.Tagged(&config)
+	err = ic.ValidateForm("Notifications", &config)
+	if err != nil {
+		return err
+	}
+
+	config.GuildID = ic.GS.ID
+	return configstore.SQL.SetGuildConfig(ctx, &config)
+}
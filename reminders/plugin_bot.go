@@ -16,6 +16,7 @@ import (
 	"github.com/cirelion/flint/lib/dcmd"
 	"github.com/cirelion/flint/lib/discordgo"
 	"github.com/cirelion/flint/lib/dstate"
+	"github.com/cirelion/flint/timezonecompanion"
 	"github.com/jinzhu/gorm"
 )
 
@@ -39,11 +40,11 @@ var cmds = []*commands.YAGCommand{
 	{
 		CmdCategory:  commands.CategoryTool,
 		Name:         "Remindme",
-		Description:  "Schedules a reminder, example: 'remindme 1h30min are you still alive?'",
+		Description:  "Schedules a reminder, example: 'remindme 1h30min are you still alive?' or 'remindme \"tomorrow at 5pm\" are you still alive?'",
 		Aliases:      []string{"remind", "reminder"},
 		RequiredArgs: 2,
 		Arguments: []*dcmd.ArgDef{
-			{Name: "Time", Type: &commands.DurationArg{}},
+			{Name: "Time", Type: &timezonecompanion.TimeArg{}},
 			{Name: "Message", Type: dcmd.String},
 		},
 		ArgSwitches: []*dcmd.ArgDef{
@@ -0,0 +1,134 @@
+package rolecommands
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/cirelion/flint/backup"
+	"github.com/cirelion/flint/rolecommands/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// roleCommandsBackup is the exported role commands and groups, role menus are not included as they belong to a posted message
+type roleCommandsBackup struct {
+	Groups   []*models.RoleGroup   `json:"groups"`
+	Commands []*models.RoleCommand `json:"commands"`
+}
+
+var _ backup.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	groups, err := models.RoleGroups(qm.Where("guild_id = ?", guildID), qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cmds, err := models.RoleCommands(qm.Where("guild_id = ?", guildID), qm.OrderBy("position asc, id asc")).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groups) < 1 && len(cmds) < 1 {
+		return nil, nil
+	}
+
+	return &roleCommandsBackup{Groups: groups, Commands: cmds}, nil
+}
+
+// ImportBackup updates the groups and commands with the same name and creates the rest,
+// existing ones that are not in the backup are kept as removing them would also remove them from the role menus using them
+func (p *Plugin) ImportBackup(ctx context.Context, ic *backup.ImportContext, data json.RawMessage) error {
+	var exported roleCommandsBackup
+	err := json.Unmarshal(data, &exported)
+	if err != nil {
+		return err
+	}
+
+	existingGroups, err := models.RoleGroups(qm.Where("guild_id = ?", ic.GS.ID)).AllG(ctx)
+	if err != nil {
+		return err
+	}
+
+	groupsByName := make(map[string]*models.RoleGroup)
+	for _, v := range existingGroups {
+		groupsByName[strings.ToLower(v.Name)] = v
+	}
+
+	// maps the group ids in the backup to the groups on this server
+	groupIDs := make(map[int64]int64)
+	for _, g := range exported.Groups {
+		oldID := g.ID
+		g.GuildID = ic.GS.ID
+		g.RequireRoles = ic.Remap.Roles(g.RequireRoles)
+		g.IgnoreRoles = ic.Remap.Roles(g.IgnoreRoles)
+
+		if existing, ok := groupsByName[strings.ToLower(g.Name)]; ok {
+			g.ID = existing.ID
+			_, err = g.UpdateG(ctx, boil.Infer())
+		} else if len(groupsByName) >= 1000 {
+			ic.Warn("Role commands: skipped group %s, max 1000 role groups allowed", g.Name)
+			continue
+		} else {
+			g.ID = 0
+			err = g.InsertG(ctx, boil.Infer())
+			groupsByName[strings.ToLower(g.Name)] = g
+		}
+
+		if err != nil {
+			return err
+		}
+
+		groupIDs[oldID] = g.ID
+	}
+
+	existingCmds, err := models.RoleCommands(qm.Where("guild_id = ?", ic.GS.ID)).AllG(ctx)
+	if err != nil {
+		return err
+	}
+
+	cmdsByName := make(map[string]*models.RoleCommand)
+	for _, v := range existingCmds {
+		cmdsByName[strings.ToLower(v.Name)] = v
+	}
+
+	for _, cmd := range exported.Commands {
+		cmd.GuildID = ic.GS.ID
+		cmd.Role = ic.Remap.Role(cmd.Role)
+		cmd.RequireRoles = ic.Remap.Roles(cmd.RequireRoles)
+		cmd.IgnoreRoles = ic.Remap.Roles(cmd.IgnoreRoles)
+		if cmd.Role == 0 {
+			// the missing role is reported by the remapper
+			continue
+		}
+
+		if cmd.RoleGroupID.Valid {
+			if newID, ok := groupIDs[cmd.RoleGroupID.Int64]; ok {
+				cmd.RoleGroupID = null.Int64From(newID)
+			} else {
+				cmd.RoleGroupID = null.Int64{}
+			}
+		}
+
+		if existing, ok := cmdsByName[strings.ToLower(cmd.Name)]; ok {
+			cmd.ID = existing.ID
+			_, err = cmd.UpdateG(ctx, boil.Infer())
+		} else if len(cmdsByName) >= 1000 {
+			ic.Warn("Role commands: skipped %s, max 1000 role commands allowed", cmd.Name)
+			continue
+		} else {
+			cmd.ID = 0
+			err = cmd.InsertG(ctx, boil.Infer())
+			cmdsByName[strings.ToLower(cmd.Name)] = cmd
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	sendEvictMenuCachePubSub(ic.GS.ID)
+	return nil
+}
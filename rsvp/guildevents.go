@@ -0,0 +1,303 @@
+package rsvp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cirelion/flint/bot"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate"
+	"github.com/cirelion/flint/rsvp/models"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// Discord requires an end time for events not happening in a voice channel,
+// this is also when the native discord event is marked as completed after starting
+const externalGuildEventLength = time.Hour * 2
+
+// Max amount of interested users imported from the discord event per update
+const maxImportedGuildEventUsers = 500
+
+// The interested users of a discord event are imported at most once per this many seconds,
+// as updates happen every second close to the start of the event
+const guildEventImportIntervalSeconds = 60
+
+// parseGuildEventLocation parses the input as either a voice or stage channel, or a free text location
+func parseGuildEventLocation(gs *dstate.GuildSet, input string) (voiceChannelID int64, location string, ok bool) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return 0, "", false
+	}
+
+	if strings.HasPrefix(input, "<#") && strings.HasSuffix(input, ">") {
+		if parsed, err := strconv.ParseInt(input[2:len(input)-1], 10, 64); err == nil {
+			if cs := gs.GetChannel(parsed); cs != nil && isVoiceChannel(cs) {
+				return cs.ID, "", true
+			}
+		}
+
+		return 0, "", false
+	}
+
+	if parsed, err := strconv.ParseInt(input, 10, 64); err == nil {
+		if cs := gs.GetChannel(parsed); cs != nil && isVoiceChannel(cs) {
+			return cs.ID, "", true
+		}
+	}
+
+	for _, v := range gs.Channels {
+		if isVoiceChannel(&v) && strings.EqualFold(v.Name, input) {
+			return v.ID, "", true
+		}
+	}
+
+	if utf8.RuneCountInString(input) > 100 {
+		return 0, "", false
+	}
+
+	return 0, input, true
+}
+
+func isVoiceChannel(cs *dstate.ChannelState) bool {
+	return cs.Type == discordgo.ChannelTypeGuildVoice || cs.Type == discordgo.ChannelTypeGuildStageVoice
+}
+
+func guildEventParams(m *models.RSVPSession) *discordgo.GuildScheduledEventParams {
+	startsAt := m.StartsAt
+	params := &discordgo.GuildScheduledEventParams{
+		Name:               m.Title,
+		Description:        fmt.Sprintf("Sign up here: https://discord.com/channels/%d/%d/%d", m.GuildID, m.ChannelID, m.MessageID),
+		PrivacyLevel:       discordgo.GuildScheduledEventPrivacyLevelGuildOnly,
+		ScheduledStartTime: &startsAt,
+	}
+
+	channelID := discordgo.NullableID(m.VoiceChannelID)
+	params.ChannelID = &channelID
+
+	if m.VoiceChannelID != 0 {
+		params.EntityType = discordgo.GuildScheduledEventEntityTypeVoice
+
+		if gs := bot.State.GetGuild(m.GuildID); gs != nil {
+			if cs := gs.GetChannel(m.VoiceChannelID); cs != nil && cs.Type == discordgo.ChannelTypeGuildStageVoice {
+				params.EntityType = discordgo.GuildScheduledEventEntityTypeStageInstance
+			}
+		}
+
+		return params
+	}
+
+	endsAt := startsAt.Add(externalGuildEventLength)
+	params.EntityType = discordgo.GuildScheduledEventEntityTypeExternal
+	params.EntityMetadata = &discordgo.GuildScheduledEventEntityMetadata{Location: m.Location}
+	params.ScheduledEndTime = &endsAt
+
+	return params
+}
+
+// CreateGuildEvent creates the native discord event for the session, if it has a location set
+func CreateGuildEvent(m *models.RSVPSession) error {
+	if m.DiscordEventID != 0 || (m.VoiceChannelID == 0 && m.Location == "") {
+		return nil
+	}
+
+	evt, err := common.BotSession.GuildScheduledEventCreate(m.GuildID, guildEventParams(m))
+	if err != nil {
+		return err
+	}
+
+	m.DiscordEventID = evt.ID
+	_, err = m.UpdateG(context.Background(), boil.Whitelist("discord_event_id"))
+	return err
+}
+
+// UpdateGuildEvent syncs the title, time and location of the session to the native discord event
+func UpdateGuildEvent(m *models.RSVPSession) error {
+	if m.DiscordEventID == 0 {
+		return CreateGuildEvent(m)
+	}
+
+	_, err := common.BotSession.GuildScheduledEventEdit(m.GuildID, m.DiscordEventID, guildEventParams(m))
+	if common.IsDiscordErr(err, discordgo.ErrCodeUnknownGuildScheduledEvent) {
+		// deleted on discord's end, stop syncing
+		return clearGuildEvent(m)
+	}
+
+	return err
+}
+
+// StartGuildEvent marks the native discord event as active
+func StartGuildEvent(m *models.RSVPSession) error {
+	return setGuildEventStatus(m, discordgo.GuildScheduledEventStatusActive)
+}
+
+// CompleteGuildEvent marks the native discord event as completed, the session is gone by then so it only needs the ids
+func CompleteGuildEvent(guildID, discordEventID int64) error {
+	_, err := common.BotSession.GuildScheduledEventEdit(guildID, discordEventID, &discordgo.GuildScheduledEventParams{
+		Status: discordgo.GuildScheduledEventStatusCompleted,
+	})
+
+	if common.IsDiscordErr(err, discordgo.ErrCodeUnknownGuildScheduledEvent) {
+		return nil
+	}
+
+	return err
+}
+
+// CancelGuildEvent cancels the native discord event and unlinks it from the session
+func CancelGuildEvent(m *models.RSVPSession) error {
+	err := setGuildEventStatus(m, discordgo.GuildScheduledEventStatusCanceled)
+	if err != nil {
+		return err
+	}
+
+	return clearGuildEvent(m)
+}
+
+func setGuildEventStatus(m *models.RSVPSession, status discordgo.GuildScheduledEventStatus) error {
+	if m.DiscordEventID == 0 {
+		return nil
+	}
+
+	_, err := common.BotSession.GuildScheduledEventEdit(m.GuildID, m.DiscordEventID, &discordgo.GuildScheduledEventParams{
+		Status: status,
+	})
+
+	if common.IsDiscordErr(err, discordgo.ErrCodeUnknownGuildScheduledEvent) {
+		return nil
+	}
+
+	return err
+}
+
+// markGuildEventImported returns false if the users of the event were already imported in the last guildEventImportIntervalSeconds
+func markGuildEventImported(messageID int64) (bool, error) {
+	var resp string
+	err := common.RedisPool.Do(radix.Cmd(&resp, "SET", "rsvp_guild_event_imported:"+strconv.FormatInt(messageID, 10), "1", "EX", strconv.Itoa(guildEventImportIntervalSeconds), "NX"))
+	if err != nil {
+		return false, err
+	}
+
+	return resp == "OK", nil
+}
+
+func clearGuildEvent(m *models.RSVPSession) error {
+	m.DiscordEventID = 0
+	_, err := m.UpdateG(context.Background(), boil.Whitelist("discord_event_id"))
+	return err
+}
+
+// ImportGuildEventUsers adds the users marked as interested in the native discord event as participants,
+// users who already responded to the rsvp are left as is. Imported participants who are no longer interested are removed again.
+// Does nothing if the users were already imported in the last guildEventImportIntervalSeconds.
+func ImportGuildEventUsers(m *models.RSVPSession) error {
+	if m.DiscordEventID == 0 {
+		return nil
+	}
+
+	if ok, err := markGuildEventImported(m.MessageID); err != nil || !ok {
+		return err
+	}
+
+	existing := make(map[int64]*models.RSVPParticipant)
+	if m.R != nil {
+		for _, v := range m.R.RSVPSessionsMessageRSVPParticipants {
+			existing[v.UserID] = v
+		}
+	}
+
+	interested := make(map[int64]bool)
+	complete := false
+
+	var toAdd []*models.RSVPParticipant
+	after := int64(0)
+	for fetched := 0; fetched < maxImportedGuildEventUsers; {
+		users, err := common.BotSession.GuildScheduledEventUsers(m.GuildID, m.DiscordEventID, 100, false, 0, after)
+		if err != nil {
+			if common.IsDiscordErr(err, discordgo.ErrCodeUnknownGuildScheduledEvent) {
+				return clearGuildEvent(m)
+			}
+
+			return err
+		}
+
+		for _, v := range users {
+			if v.User == nil {
+				continue
+			}
+
+			after = v.User.ID
+			interested[v.User.ID] = true
+			if _, ok := existing[v.User.ID]; ok || v.User.Bot {
+				continue
+			}
+
+			participant := &models.RSVPParticipant{
+				RSVPSessionsMessageID:   m.MessageID,
+				UserID:                  v.User.ID,
+				GuildID:                 m.GuildID,
+				JoinState:               int16(ParticipantStateJoining),
+				MarkedAsParticipatingAt: time.Now(),
+				FromDiscordEvent:        true,
+			}
+			existing[v.User.ID] = participant
+			toAdd = append(toAdd, participant)
+		}
+
+		if len(users) < 100 {
+			complete = true
+			break
+		}
+
+		fetched += len(users)
+	}
+
+	if len(toAdd) > 0 {
+		err := m.AddRSVPSessionsMessageRSVPParticipantsG(context.Background(), true, toAdd...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !complete {
+		// we don't know who's missing if we didn't get the full list
+		return nil
+	}
+
+	var toRemove []interface{}
+	for _, v := range existing {
+		if v.FromDiscordEvent && !interested[v.UserID] {
+			toRemove = append(toRemove, v.UserID)
+		}
+	}
+
+	if len(toRemove) < 1 {
+		return nil
+	}
+
+	_, err := models.RSVPParticipants(
+		models.RSVPParticipantWhere.RSVPSessionsMessageID.EQ(m.MessageID),
+		models.RSVPParticipantWhere.FromDiscordEvent.EQ(true),
+		qm.WhereIn("user_id IN ?", toRemove...),
+	).DeleteAll(context.Background(), common.PQ)
+	if err != nil {
+		return err
+	}
+
+	// keep the loaded participants in sync for the embed update
+	remaining := m.R.RSVPSessionsMessageRSVPParticipants[:0]
+	for _, v := range m.R.RSVPSessionsMessageRSVPParticipants {
+		if !v.FromDiscordEvent || interested[v.UserID] {
+			remaining = append(remaining, v)
+		}
+	}
+	m.R.RSVPSessionsMessageRSVPParticipants = remaining
+
+	return nil
+}
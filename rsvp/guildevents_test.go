@@ -0,0 +1,47 @@
+package rsvp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate"
+)
+
+func TestParseGuildEventLocation(t *testing.T) {
+	gs := &dstate.GuildSet{
+		Channels: []dstate.ChannelState{
+			{ID: 100, Name: "general", Type: discordgo.ChannelTypeGuildText},
+			{ID: 200, Name: "Game Night", Type: discordgo.ChannelTypeGuildVoice},
+			{ID: 300, Name: "stage", Type: discordgo.ChannelTypeGuildStageVoice},
+		},
+	}
+
+	cases := []struct {
+		input    string
+		voice    int64
+		location string
+		ok       bool
+	}{
+		{input: "", ok: false},
+		{input: "   ", ok: false},
+		{input: "<#200>", voice: 200, ok: true},
+		{input: "<#300>", voice: 300, ok: true},
+		{input: "<#100>", ok: false},
+		{input: "<#999>", ok: false},
+		{input: "200", voice: 200, ok: true},
+		{input: "game night", voice: 200, ok: true},
+		{input: "general", location: "general", ok: true},
+		{input: "  The park  ", location: "The park", ok: true},
+		{input: "12345", location: "12345", ok: true},
+		{input: strings.Repeat("a", 100), location: strings.Repeat("a", 100), ok: true},
+		{input: strings.Repeat("a", 101), ok: false},
+	}
+
+	for _, c := range cases {
+		voice, location, ok := parseGuildEventLocation(gs, c.input)
+		if voice != c.voice || location != c.location || ok != c.ok {
+			t.Errorf("%q: got (%d, %q, %v), expected (%d, %q, %v)", c.input, voice, location, ok, c.voice, c.location, c.ok)
+		}
+	}
+}
@@ -29,6 +29,7 @@ type RSVPParticipant struct {
 	JoinState               int16     `boil:"join_state" json:"join_state" toml:"join_state" yaml:"join_state"`
 	ReminderEnabled         bool      `boil:"reminder_enabled" json:"reminder_enabled" toml:"reminder_enabled" yaml:"reminder_enabled"`
 	MarkedAsParticipatingAt time.Time `boil:"marked_as_participating_at" json:"marked_as_participating_at" toml:"marked_as_participating_at" yaml:"marked_as_participating_at"`
+	FromDiscordEvent        bool      `boil:"from_discord_event" json:"from_discord_event" toml:"from_discord_event" yaml:"from_discord_event"`
 
 	R *rsvpParticipantR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L rsvpParticipantL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -41,6 +42,7 @@ var RSVPParticipantColumns = struct {
 	JoinState               string
 	ReminderEnabled         string
 	MarkedAsParticipatingAt string
+	FromDiscordEvent        string
 }{
 	UserID:                  "user_id",
 	RSVPSessionsMessageID:   "rsvp_sessions_message_id",
@@ -48,6 +50,7 @@ var RSVPParticipantColumns = struct {
 	JoinState:               "join_state",
 	ReminderEnabled:         "reminder_enabled",
 	MarkedAsParticipatingAt: "marked_as_participating_at",
+	FromDiscordEvent:        "from_discord_event",
 }
 
 // Generated where
@@ -107,6 +110,7 @@ var RSVPParticipantWhere = struct {
 	JoinState               whereHelperint16
 	ReminderEnabled         whereHelperbool
 	MarkedAsParticipatingAt whereHelpertime_Time
+	FromDiscordEvent        whereHelperbool
 }{
 	UserID:                  whereHelperint64{field: "\"rsvp_participants\".\"user_id\""},
 	RSVPSessionsMessageID:   whereHelperint64{field: "\"rsvp_participants\".\"rsvp_sessions_message_id\""},
@@ -114,6 +118,7 @@ var RSVPParticipantWhere = struct {
 	JoinState:               whereHelperint16{field: "\"rsvp_participants\".\"join_state\""},
 	ReminderEnabled:         whereHelperbool{field: "\"rsvp_participants\".\"reminder_enabled\""},
 	MarkedAsParticipatingAt: whereHelpertime_Time{field: "\"rsvp_participants\".\"marked_as_participating_at\""},
+	FromDiscordEvent:        whereHelperbool{field: "\"rsvp_participants\".\"from_discord_event\""},
 }
 
 // RSVPParticipantRels is where relationship names are stored.
@@ -137,9 +142,9 @@ func (*rsvpParticipantR) NewStruct() *rsvpParticipantR {
 type rsvpParticipantL struct{}
 
 var (
-	rsvpParticipantAllColumns            = []string{"user_id", "rsvp_sessions_message_id", "guild_id", "join_state", "reminder_enabled", "marked_as_participating_at"}
+	rsvpParticipantAllColumns            = []string{"user_id", "rsvp_sessions_message_id", "guild_id", "join_state", "reminder_enabled", "marked_as_participating_at", "from_discord_event"}
 	rsvpParticipantColumnsWithoutDefault = []string{"user_id", "rsvp_sessions_message_id", "guild_id", "join_state", "reminder_enabled", "marked_as_participating_at"}
-	rsvpParticipantColumnsWithDefault    = []string{}
+	rsvpParticipantColumnsWithDefault    = []string{"from_discord_event"}
 	rsvpParticipantPrimaryKeyColumns     = []string{"rsvp_sessions_message_id", "user_id"}
 )
 
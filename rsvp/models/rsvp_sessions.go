@@ -35,6 +35,9 @@ type RSVPSession struct {
 	MaxParticipants int       `boil:"max_participants" json:"max_participants" toml:"max_participants" yaml:"max_participants"`
 	SendReminders   bool      `boil:"send_reminders" json:"send_reminders" toml:"send_reminders" yaml:"send_reminders"`
 	SentReminders   bool      `boil:"sent_reminders" json:"sent_reminders" toml:"sent_reminders" yaml:"sent_reminders"`
+	DiscordEventID  int64     `boil:"discord_event_id" json:"discord_event_id" toml:"discord_event_id" yaml:"discord_event_id"`
+	VoiceChannelID  int64     `boil:"voice_channel_id" json:"voice_channel_id" toml:"voice_channel_id" yaml:"voice_channel_id"`
+	Location        string    `boil:"location" json:"location" toml:"location" yaml:"location"`
 
 	R *rsvpSessionR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L rsvpSessionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -53,6 +56,9 @@ var RSVPSessionColumns = struct {
 	MaxParticipants string
 	SendReminders   string
 	SentReminders   string
+	DiscordEventID  string
+	VoiceChannelID  string
+	Location        string
 }{
 	MessageID:       "message_id",
 	GuildID:         "guild_id",
@@ -66,6 +72,9 @@ var RSVPSessionColumns = struct {
 	MaxParticipants: "max_participants",
 	SendReminders:   "send_reminders",
 	SentReminders:   "sent_reminders",
+	DiscordEventID:  "discord_event_id",
+	VoiceChannelID:  "voice_channel_id",
+	Location:        "location",
 }
 
 // Generated where
@@ -101,6 +110,9 @@ var RSVPSessionWhere = struct {
 	MaxParticipants whereHelperint
 	SendReminders   whereHelperbool
 	SentReminders   whereHelperbool
+	DiscordEventID  whereHelperint64
+	VoiceChannelID  whereHelperint64
+	Location        whereHelperstring
 }{
 	MessageID:       whereHelperint64{field: "\"rsvp_sessions\".\"message_id\""},
 	GuildID:         whereHelperint64{field: "\"rsvp_sessions\".\"guild_id\""},
@@ -114,6 +126,9 @@ var RSVPSessionWhere = struct {
 	MaxParticipants: whereHelperint{field: "\"rsvp_sessions\".\"max_participants\""},
 	SendReminders:   whereHelperbool{field: "\"rsvp_sessions\".\"send_reminders\""},
 	SentReminders:   whereHelperbool{field: "\"rsvp_sessions\".\"sent_reminders\""},
+	DiscordEventID:  whereHelperint64{field: "\"rsvp_sessions\".\"discord_event_id\""},
+	VoiceChannelID:  whereHelperint64{field: "\"rsvp_sessions\".\"voice_channel_id\""},
+	Location:        whereHelperstring{field: "\"rsvp_sessions\".\"location\""},
 }
 
 // RSVPSessionRels is where relationship names are stored.
@@ -137,9 +152,9 @@ func (*rsvpSessionR) NewStruct() *rsvpSessionR {
 type rsvpSessionL struct{}
 
 var (
-	rsvpSessionAllColumns            = []string{"message_id", "guild_id", "channel_id", "local_id", "author_id", "created_at", "starts_at", "title", "description", "max_participants", "send_reminders", "sent_reminders"}
+	rsvpSessionAllColumns            = []string{"message_id", "guild_id", "channel_id", "local_id", "author_id", "created_at", "starts_at", "title", "description", "max_participants", "send_reminders", "sent_reminders", "discord_event_id", "voice_channel_id", "location"}
 	rsvpSessionColumnsWithoutDefault = []string{"message_id", "guild_id", "channel_id", "local_id", "author_id", "created_at", "starts_at", "title", "description", "max_participants", "send_reminders", "sent_reminders"}
-	rsvpSessionColumnsWithDefault    = []string{}
+	rsvpSessionColumnsWithDefault    = []string{"discord_event_id", "voice_channel_id", "location"}
 	rsvpSessionPrimaryKeyColumns     = []string{"message_id"}
 )
 
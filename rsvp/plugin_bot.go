@@ -31,6 +31,7 @@ func (p *Plugin) BotInit() {
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleMessageCreate, eventsystem.EventMessageCreate)
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleInteractionCreate, eventsystem.EventInteractionCreate)
 	scheduledevents2.RegisterHandler("rsvp_update_session", int64(0), p.handleScheduledUpdate)
+	scheduledevents2.RegisterHandler("rsvp_complete_guild_event", int64(0), handleScheduledCompleteGuildEvent)
 }
 
 var _ commands.CommandProvider = (*Plugin)(nil)
@@ -111,8 +112,9 @@ func (p *Plugin) AddCommands() {
 		RequiredArgs: 1,
 		ArgSwitches: []*dcmd.ArgDef{
 			{Name: "title", Help: "Change the title of the event", Type: dcmd.String},
-			{Name: "time", Help: "Change the start time of the event", Type: dcmd.String},
+			{Name: "time", Help: "Change the start time of the event", Type: &timezonecompanion.TimeArg{}},
 			{Name: "max", Help: "Change max participants", Type: dcmd.Int},
+			{Name: "location", Help: "Change the voice channel or location of the event in the server's events tab, `none` to remove it from there", Type: dcmd.String},
 		},
 		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
 			m, err := models.RSVPSessions(
@@ -139,18 +141,31 @@ func (p *Plugin) AddCommands() {
 
 			timeChanged := false
 			if parsed.Switch("time").Value != nil {
-				registeredTimezone := timezonecompanion.GetUserTimezone(parsed.Author.ID)
-				if registeredTimezone == nil || UTCRegex.MatchString(parsed.Switch("time").Str()) {
-					registeredTimezone = time.UTC
-				}
+				m.StartsAt = time.Now().Add(parsed.Switch("time").Value.(time.Duration))
+				timeChanged = true
+			}
 
-				t, err := dateParser.Parse(parsed.Switch("time").Str(), time.Now().In(registeredTimezone))
-				if err != nil || t == nil {
-					return "failed parsing the date; " + err.Error(), nil
+			locationChanged := false
+			if parsed.Switch("location").Value != nil {
+				input := parsed.Switch("location").Str()
+				if strings.EqualFold(input, "none") {
+					err = CancelGuildEvent(m)
+					if err != nil {
+						return nil, err
+					}
+
+					m.VoiceChannelID = 0
+					m.Location = ""
+				} else {
+					voiceChannel, location, ok := parseGuildEventLocation(parsed.GuildData.GS, input)
+					if !ok {
+						return "Invalid location, use a voice or stage channel, or a location of at most 100 characters", nil
+					}
+
+					m.VoiceChannelID = voiceChannel
+					m.Location = location
+					locationChanged = true
 				}
-
-				m.StartsAt = t.Time
-				timeChanged = true
 			}
 
 			_, err = m.UpdateG(parsed.Context(), boil.Infer())
@@ -158,6 +173,13 @@ func (p *Plugin) AddCommands() {
 				return nil, err
 			}
 
+			if m.DiscordEventID != 0 || locationChanged {
+				err = UpdateGuildEvent(m)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			if timeChanged {
 				_, err := eventModels.ScheduledEvents(qm.Where("event_name='rsvp_update_session' AND  guild_id = ? AND data::text::bigint = ? AND processed = false", parsed.GuildData.GS.ID, m.MessageID)).DeleteAll(parsed.Context(), common.PQ)
 				if err != nil {
@@ -232,6 +254,11 @@ func (p *Plugin) AddCommands() {
 				return nil, err
 			}
 
+			err = CancelGuildEvent(m)
+			if err != nil {
+				logger.WithError(err).WithField("guild", m.GuildID).Error("failed cancelling discord event")
+			}
+
 			_, err = m.DeleteG(parsed.Context())
 			if err != nil {
 				return nil, err
@@ -371,7 +398,21 @@ func UpdateEventEmbed(m *models.RSVPSession) error {
 	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 		Name:  "Time",
 		Value: fmt.Sprintf("<t:%d:F> (UTC: `%s`)", m.StartsAt.Unix(), UTCTime.Format(timeFormat)),
-	}, &discordgo.MessageEmbedField{
+	})
+
+	if m.VoiceChannelID != 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "Location",
+			Value: fmt.Sprintf("<#%d>", m.VoiceChannelID),
+		})
+	} else if m.Location != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "Location",
+			Value: m.Location,
+		})
+	}
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 		Name:  "Reactions usage",
 		Value: "React to mark you as a participant, undecided, or not joining",
 	})
@@ -560,10 +601,16 @@ func (p *Plugin) handleScheduledUpdate(evt *eventModels.ScheduledEvent, data int
 		return false, err
 	}
 
+	err = ImportGuildEventUsers(m)
+	if err != nil {
+		logger.WithError(err).WithField("guild", m.GuildID).Error("failed importing discord event users")
+	}
+
 	err = UpdateEventEmbed(m)
 	if err != nil {
 		code, _ := common.DiscordError(err)
 		if code == discordgo.ErrCodeUnknownMessage || code == discordgo.ErrCodeUnknownChannel {
+			CancelGuildEvent(m)
 			m.DeleteG(context.Background())
 			return false, nil
 		}
@@ -588,6 +635,17 @@ func (p *Plugin) handleScheduledUpdate(evt *eventModels.ScheduledEvent, data int
 	return false, err
 }
 
+func handleScheduledCompleteGuildEvent(evt *eventModels.ScheduledEvent, data interface{}) (retry bool, err error) {
+	discordEventID := *(data.(*int64))
+
+	err = CompleteGuildEvent(evt.GuildID, discordEventID)
+	if err != nil {
+		return scheduledevents2.CheckDiscordErrRetry(err), err
+	}
+
+	return false, nil
+}
+
 type ParticipantState int16
 
 const (
@@ -601,7 +659,17 @@ func (p *Plugin) startEvent(m *models.RSVPSession) error {
 
 	p.sendReminders(m, "Event starting now!", "The event you signed up for: **"+m.Title+"** is starting now!")
 
-	_, err := m.DeleteG(context.Background())
+	err := StartGuildEvent(m)
+	if err != nil {
+		logger.WithError(err).WithField("guild", m.GuildID).Error("failed starting discord event")
+	} else if m.DiscordEventID != 0 {
+		err = scheduledevents2.ScheduleEvent("rsvp_complete_guild_event", m.GuildID, m.StartsAt.Add(externalGuildEventLength), m.DiscordEventID)
+		if err != nil {
+			logger.WithError(err).WithField("guild", m.GuildID).Error("failed scheduling completion of discord event")
+		}
+	}
+
+	_, err = m.DeleteG(context.Background())
 	return err
 }
 
@@ -685,8 +753,12 @@ func (p *Plugin) handleInteractionCreate(evt *eventsystem.EventData) {
 		}
 	}
 
+	// responding here takes over from the interest on the discord event, so it's no longer removed along with it
+	wasImported := participant.FromDiscordEvent
+	participant.FromDiscordEvent = false
+
 	if joining {
-		if participant.JoinState == int16(ParticipantStateJoining) {
+		if participant.JoinState == int16(ParticipantStateJoining) && !wasImported {
 			// already at this state
 			return
 		}
@@ -6,17 +6,10 @@ import (
 	"sync"
 
 	"github.com/cirelion/flint/common"
-	"github.com/cirelion/flint/lib/when"
-	"github.com/cirelion/flint/lib/when/rules"
-	wcommon "github.com/cirelion/flint/lib/when/rules/common"
-	"github.com/cirelion/flint/lib/when/rules/en"
-	"github.com/cirelion/flint/timezonecompanion/trules"
 )
 
 var (
 	logger = common.GetPluginLogger(&Plugin{})
-
-	dateParser *when.Parser
 )
 
 const (
@@ -26,23 +19,6 @@ const (
 	EventUndecided = "event_undecided"
 )
 
-func init() {
-	dateParser = when.New(&rules.Options{
-		Distance:     10,
-		MatchByOrder: true})
-
-	dateParser.Add(
-		en.Weekday(rules.Override),
-		en.CasualDate(rules.Override),
-		en.CasualTime(rules.Override),
-		trules.Hour(rules.Override),
-		trules.HourMinute(rules.Override),
-		en.Deadline(rules.Override),
-		en.ExactMonthDate(rules.Override),
-	)
-	dateParser.Add(wcommon.All...)
-}
-
 type Plugin struct {
 	setupSessions   []*SetupSession
 	setupSessionsMU sync.Mutex
@@ -20,6 +20,12 @@ CREATE TABLE IF NOT EXISTS rsvp_sessions (
 	sent_reminders BOOLEAN NOT NULL
 );
 `, `
+ALTER TABLE rsvp_sessions ADD COLUMN IF NOT EXISTS discord_event_id BIGINT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE rsvp_sessions ADD COLUMN IF NOT EXISTS voice_channel_id BIGINT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE rsvp_sessions ADD COLUMN IF NOT EXISTS location TEXT NOT NULL DEFAULT '';
+`, `
 CREATE TABLE IF NOT EXISTS rsvp_participants (
 	user_id BIGINT NOT NULL,
 	rsvp_sessions_message_id BIGINT NOT NULL REFERENCES rsvp_sessions(message_id) ON DELETE CASCADE,
@@ -32,4 +38,6 @@ CREATE TABLE IF NOT EXISTS rsvp_participants (
 
 	PRIMARY KEY(rsvp_sessions_message_id, user_id)
 );
+`, `
+ALTER TABLE rsvp_participants ADD COLUMN IF NOT EXISTS from_discord_event BOOLEAN NOT NULL DEFAULT false;
 `}
@@ -3,7 +3,6 @@ package rsvp
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -29,6 +28,7 @@ const (
 	SetupStateMaxParticipants
 	SetupStateWhen
 	SetupStateWhenConfirm
+	SetupStateLocation
 )
 
 type SetupSession struct {
@@ -49,6 +49,10 @@ type SetupSession struct {
 	Channel         int64
 	When            time.Time
 
+	// optional, set when a discord event should be created as well
+	VoiceChannel int64
+	Location     string
+
 	LastAction time.Time
 	stopCH     chan bool
 	stopped    bool
@@ -90,6 +94,8 @@ func (s *SetupSession) handleMessage(m *discordgo.Message) {
 		s.handleMessageSetupStateWhen(m)
 	case SetupStateWhenConfirm:
 		s.handleMessageSetupStateWhenConfirm(m)
+	case SetupStateLocation:
+		s.handleMessageSetupStateLocation(m)
 	}
 }
 
@@ -180,28 +186,25 @@ func (s *SetupSession) handleMessageSetupStateMaxParticipants(m *discordgo.Messa
 	s.sendMessage("Set max participants to **%d**, now please enter when this event starts, in either your registered time zone (using the `setz` command) or UTC. (example: `tomorrow 10pm`, `10 may 2pm UTC`)", s.MaxParticipants)
 }
 
-var UTCRegex = regexp.MustCompile(`(?i)\butc\b`)
-
 func (s *SetupSession) handleMessageSetupStateWhen(m *discordgo.Message) {
-	registeredTimezone := timezonecompanion.GetUserTimezone(s.AuthorID)
-	if registeredTimezone == nil || UTCRegex.MatchString(m.Content) {
-		registeredTimezone = time.UTC
+	locale := ""
+	if gs := bot.State.GetGuild(s.GuildID); gs != nil {
+		locale = gs.PreferredLocale
 	}
 
-	now := time.Now().In(registeredTimezone)
-	t, err := dateParser.Parse(m.Content, now)
-	// t, err := dateparse.ParseAny(m.Content)
-	if err != nil || t == nil {
+	now := time.Now()
+	t, err := timezonecompanion.ParseTime(m.Content, now, timezonecompanion.UserLocation(s.AuthorID, m.Content), locale)
+	if err != nil {
 		s.sendMessage("Couldn't understand that date, Please try changing the format a little bit and try again\n||Error: %v||", err)
 		return
 	}
 
-	s.When = t.Time
+	s.When = t
 	s.State = SetupStateWhenConfirm
 
-	in := common.HumanizeDuration(common.DurationPrecisionMinutes, t.Time.Sub(now))
+	in := common.HumanizeDuration(common.DurationPrecisionMinutes, t.Sub(now))
 
-	s.sendMessage("Set the starting time of the event to **<t:%d>** (%s) (in **%s**), is this correct? (`yes/no`)", t.Time.Unix(), t.Time.UTC().Format("02 Jan 2006 15:04 MST"), in)
+	s.sendMessage("Set the starting time of the event to **<t:%d>** (%s) (in **%s**), is this correct? (`yes/no`)", t.Unix(), t.UTC().Format("02 Jan 2006 15:04 MST"), in)
 }
 
 func (s *SetupSession) handleMessageSetupStateWhenConfirm(m *discordgo.Message) {
@@ -211,13 +214,44 @@ func (s *SetupSession) handleMessageSetupStateWhenConfirm(m *discordgo.Message)
 	}
 
 	if lower[0] == 'y' {
-		s.Finish()
+		s.State = SetupStateLocation
+		s.sendMessage("Should this event also show up in the server's events tab? Enter the voice or stage channel it takes place in, a location (example: `Minecraft server`), or `no` to skip.")
 	} else {
 		s.State = SetupStateWhen
 		s.sendMessage("Please enter when this event starts. (example: `tomorrow 10pm`, `10 may 2pm`)")
 	}
 }
 
+func (s *SetupSession) handleMessageSetupStateLocation(m *discordgo.Message) {
+	lower := strings.ToLower(strings.TrimSpace(m.Content))
+	if lower == "no" || lower == "skip" || lower == "none" {
+		s.Finish()
+		return
+	}
+
+	gs := bot.State.GetGuild(m.GuildID)
+	if gs == nil {
+		logger.WithField("guild", m.GuildID).Error("Guild not found")
+		return
+	}
+
+	voiceChannel, location, ok := parseGuildEventLocation(gs, m.Content)
+	if !ok {
+		s.sendMessage("Couldn't use that, enter a voice or stage channel, a location of at most 100 characters, or `no` to skip.")
+		return
+	}
+
+	hasPerms, err := bot.BotHasPermissionGS(gs, voiceChannel, discordgo.PermissionManageEvents)
+	if err != nil || !hasPerms {
+		s.sendMessage("The bot needs the `Manage Events` permission to create the event, give it the permission and try again, or `no` to skip.")
+		return
+	}
+
+	s.VoiceChannel = voiceChannel
+	s.Location = location
+	s.Finish()
+}
+
 func (s *SetupSession) Finish() {
 
 	// reserve the message
@@ -260,6 +294,9 @@ func (s *SetupSession) Finish() {
 		Title:           s.Title,
 		MaxParticipants: s.MaxParticipants,
 		SendReminders:   true,
+
+		VoiceChannelID: s.VoiceChannel,
+		Location:       s.Location,
 	}
 
 	err = m.InsertG(context.Background(), boil.Infer())
@@ -283,6 +320,11 @@ func (s *SetupSession) Finish() {
 		return
 	}
 
+	guildEventErr := CreateGuildEvent(m)
+	if guildEventErr != nil {
+		logger.WithError(guildEventErr).WithField("guild", s.GuildID).Error("failed creating discord event")
+	}
+
 	go s.remove()
 
 	// finish by deleting the setup messages
@@ -296,6 +338,10 @@ func (s *SetupSession) Finish() {
 		common.BotSession.DeleteInteractionResponse(common.BotApplication.ID, s.interactionToken)
 		common.BotSession.DeleteFollowupMessage(common.BotApplication.ID, s.interactionToken, s.followupMessageID)
 	}
+
+	if guildEventErr != nil {
+		common.BotSession.ChannelMessageSend(s.SetupChannel, "[RSVP Event Setup]: Created the event, but failed creating it in the server's events tab, make sure the bot has the `Manage Events` permission.")
+	}
 }
 
 func (s *SetupSession) abortError(msg string, err error) {
@@ -0,0 +1,56 @@
+package recordevents
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cirelion/flint/bot/eventsystem"
+	"github.com/cirelion/flint/commands"
+	"github.com/cirelion/flint/common/config"
+	"github.com/cirelion/flint/lib/dcmd"
+	"github.com/cirelion/flint/stdcommands/util"
+)
+
+var confRecordingsDir = config.RegisterOption("yagpdb.event_recordings.dir", "Directory gateway event recordings are written to, replay them with the -replay flag", "event_recordings")
+
+var Command = &commands.YAGCommand{
+	CmdCategory:          commands.CategoryDebug,
+	HideFromCommandsPage: true,
+	Name:                 "recordevents",
+	Description:          "Records all gateway events of a server to a file on the node it's on, for replaying offline with -replay. Bot Owner Only",
+	HideFromHelp:         true,
+	Arguments: []*dcmd.ArgDef{
+		{Name: "Duration", Type: &commands.DurationArg{}, Default: time.Minute * 30},
+	},
+	ArgSwitches: []*dcmd.ArgDef{
+		{Name: "server", Help: "The server to record, defaults to the current one", Type: dcmd.BigInt},
+		{Name: "stop", Help: "Stop recording"},
+	},
+	RunFunc: util.RequireOwner(func(data *dcmd.Data) (interface{}, error) {
+		guildID := data.GuildData.GS.ID
+		if data.Switch("server").Value != nil {
+			guildID = data.Switch("server").Int64()
+		}
+
+		if data.Switch("stop").Bool() {
+			path, events, ok := eventsystem.StopRecording(guildID)
+			if !ok {
+				return "Not recording the events of that server on this node", nil
+			}
+
+			return fmt.Sprintf("Stopped recording, wrote %d events to `%s`", events, path), nil
+		}
+
+		duration := data.Args[0].Value.(time.Duration)
+		if duration > time.Hour*24 {
+			return "Can't record for more than 24 hours", nil
+		}
+
+		path, err := eventsystem.StartRecording(guildID, confRecordingsDir.GetString(), duration)
+		if err != nil {
+			return "Failed starting the recording: " + err.Error(), nil
+		}
+
+		return fmt.Sprintf("Recording the events of %d to `%s` for %s, or until stopped with `-stop`", guildID, path, duration), nil
+	}),
+}
@@ -0,0 +1,82 @@
+package roll
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/cirelion/flint/common"
+	"github.com/mediocregopher/radix/v3"
+)
+
+const (
+	// Rolls kept in the history of a channel
+	MaxHistory = 50
+
+	historyExpire = time.Hour * 24 * 7
+)
+
+// HistoryEntry is a roll in the history of a channel, the result can be verified by rolling the expression with the seed
+type HistoryEntry struct {
+	Time       time.Time `json:"time"`
+	UserID     int64     `json:"user_id"`
+	Username   string    `json:"username"`
+	Expression string    `json:"expression"`
+	Result     string    `json:"result"`
+	Seed       int64     `json:"seed"`
+}
+
+func historyKey(channelID int64) string {
+	return "roll_history:" + strconv.FormatInt(channelID, 10)
+}
+
+// newSeed returns a seed that can't be predicted before the roll
+func newSeed() int64 {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return time.Now().UnixNano()
+	}
+
+	return int64(binary.LittleEndian.Uint64(b[:]) >> 1)
+}
+
+func addHistory(channelID int64, entry *HistoryEntry) {
+	serialized, err := json.Marshal(entry)
+	if err != nil {
+		logger.WithError(err).Error("failed marshaling roll history entry")
+		return
+	}
+
+	key := historyKey(channelID)
+	err = common.MultipleCmds(
+		radix.Cmd(nil, "LPUSH", key, string(serialized)),
+		radix.Cmd(nil, "LTRIM", key, "0", strconv.Itoa(MaxHistory-1)),
+		radix.FlatCmd(nil, "EXPIRE", key, int(historyExpire.Seconds())),
+	)
+	if err != nil {
+		logger.WithError(err).WithField("channel", channelID).Error("failed adding roll history entry")
+	}
+}
+
+// getHistory returns the last rolls in the channel, newest first
+func getHistory(channelID int64, limit int) ([]*HistoryEntry, error) {
+	var raw [][]byte
+	err := common.RedisPool.Do(radix.Cmd(&raw, "LRANGE", historyKey(channelID), "0", strconv.Itoa(limit-1)))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*HistoryEntry, 0, len(raw))
+	for _, v := range raw {
+		var entry HistoryEntry
+		if err := json.Unmarshal(v, &entry); err != nil {
+			continue
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	return entries, nil
+}
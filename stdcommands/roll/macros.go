@@ -0,0 +1,265 @@
+package roll
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/commands"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/lib/dice"
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	MaxMacros     = 25
+	MaxSheets     = 10
+	MaxSheetStats = 30
+	MaxStatValue  = 1000
+)
+
+var nameRegex = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// Macro is a roll saved by a user under a name
+type Macro struct {
+	ID         int64 `gorm:"primary_key"`
+	UserID     int64 `gorm:"index"`
+	Name       string
+	Expression string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (m Macro) TableName() string {
+	return "roll_macros"
+}
+
+// CharacterSheet is a named set of stats that rolls can reference, like {str} in 1d20+{str},
+// the stats of the active sheet of the user are used
+type CharacterSheet struct {
+	ID     int64 `gorm:"primary_key"`
+	UserID int64 `gorm:"index"`
+	Name   string
+	Active bool
+
+	// json encoded map of stat name to value
+	Stats string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (c CharacterSheet) TableName() string {
+	return "roll_character_sheets"
+}
+
+func (c *CharacterSheet) StatMap() map[string]int {
+	stats := make(map[string]int)
+	if c.Stats != "" {
+		json.Unmarshal([]byte(c.Stats), &stats)
+	}
+
+	return stats
+}
+
+func (c *CharacterSheet) String() string {
+	stats := c.StatMap()
+
+	names := make([]string, 0, len(stats))
+	for k := range stats {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	out := "**" + c.Name + "**:"
+	if len(names) < 1 {
+		return out + " no stats"
+	}
+
+	for _, v := range names {
+		out += fmt.Sprintf(" `%s=%d`", v, stats[v])
+	}
+
+	return out
+}
+
+func validateName(name string) error {
+	if !nameRegex.MatchString(name) {
+		return commands.NewUserError("Names can only contain lowercase letters, numbers, - and _, and can be max 32 characters long")
+	}
+
+	if _, ok := subCommands[name]; ok {
+		return commands.NewUserError("`" + name + "` is reserved")
+	}
+
+	if dice.IsRoll(name) {
+		return commands.NewUserError("`" + name + "` looks like a dice roll, pick another name")
+	}
+
+	return nil
+}
+
+func findMacro(userID int64, name string) (*Macro, error) {
+	var macro Macro
+	err := common.GORM.Where("user_id = ? AND name = ?", userID, name).First(&macro).Error
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &macro, nil
+}
+
+func saveMacro(userID int64, name, expression string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+
+	// make sure it's valid, variables are checked when rolling as the sheet can change
+	vars := make(map[string]int)
+	for _, v := range dice.VariableNames(expression) {
+		vars[v] = 0
+	}
+
+	expanded, _ := dice.ExpandVariables(expression, vars)
+	if _, _, err := dice.Roll(expanded); err != nil {
+		return commands.NewUserError(err.Error())
+	}
+
+	existing, err := findMacro(userID, name)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		var count int
+		err = common.GORM.Model(&Macro{}).Where("user_id = ?", userID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		if count >= MaxMacros {
+			return commands.NewUserErrorf("You can have max %d macros", MaxMacros)
+		}
+
+		existing = &Macro{UserID: userID, Name: name}
+	}
+
+	existing.Expression = expression
+	return common.GORM.Save(existing).Error
+}
+
+func deleteMacro(userID int64, name string) (bool, error) {
+	result := common.GORM.Where("user_id = ? AND name = ?", userID, name).Delete(&Macro{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func getMacros(userID int64) ([]*Macro, error) {
+	var macros []*Macro
+	err := common.GORM.Where("user_id = ?", userID).Order("name asc").Find(&macros).Error
+	return macros, err
+}
+
+func getSheets(userID int64) ([]*CharacterSheet, error) {
+	var sheets []*CharacterSheet
+	err := common.GORM.Where("user_id = ?", userID).Order("name asc").Find(&sheets).Error
+	return sheets, err
+}
+
+func activeSheet(userID int64) (*CharacterSheet, error) {
+	var sheet CharacterSheet
+	err := common.GORM.Where("user_id = ? AND active = true", userID).First(&sheet).Error
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &sheet, nil
+}
+
+// updateSheet makes the sheet the active one of the user, creating it if needed and setting the stats in the assignments
+// (stat=value, or stat= to remove it)
+func updateSheet(userID int64, name string, assignments []string) (*CharacterSheet, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	var sheet CharacterSheet
+	err := common.GORM.Where("user_id = ? AND name = ?", userID, name).First(&sheet).Error
+	if err != nil {
+		if !gorm.IsRecordNotFoundError(err) {
+			return nil, err
+		}
+
+		var count int
+		err = common.GORM.Model(&CharacterSheet{}).Where("user_id = ?", userID).Count(&count).Error
+		if err != nil {
+			return nil, err
+		}
+
+		if count >= MaxSheets {
+			return nil, commands.NewUserErrorf("You can have max %d character sheets", MaxSheets)
+		}
+
+		sheet = CharacterSheet{UserID: userID, Name: name}
+	}
+
+	stats := sheet.StatMap()
+	for _, v := range assignments {
+		split := strings.SplitN(v, "=", 2)
+		if len(split) != 2 {
+			return nil, commands.NewUserError("Stats are set with `stat=value`, got `" + v + "`")
+		}
+
+		stat := strings.ToLower(split[0])
+		if !nameRegex.MatchString(stat) {
+			return nil, commands.NewUserError("Invalid stat name `" + stat + "`")
+		}
+
+		if split[1] == "" {
+			delete(stats, stat)
+			continue
+		}
+
+		value, err := strconv.Atoi(split[1])
+		if err != nil || value > MaxStatValue || value < -MaxStatValue {
+			return nil, commands.NewUserErrorf("Stat values have to be whole numbers between -%d and %d, got `%s`", MaxStatValue, MaxStatValue, split[1])
+		}
+
+		stats[stat] = value
+	}
+
+	if len(stats) > MaxSheetStats {
+		return nil, commands.NewUserErrorf("A sheet can have max %d stats", MaxSheetStats)
+	}
+
+	encoded, err := json.Marshal(stats)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet.Stats = string(encoded)
+	sheet.Active = true
+
+	err = common.GORM.Model(&CharacterSheet{}).Where("user_id = ? AND name != ?", userID, name).Update("active", false).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = common.GORM.Save(&sheet).Error
+	return &sheet, err
+}
+
+func deleteSheet(userID int64, name string) (bool, error) {
+	result := common.GORM.Where("user_id = ? AND name = ?", userID, name).Delete(&CharacterSheet{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -2,19 +2,45 @@ package roll
 
 import (
 	"fmt"
-	"math/rand"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cirelion/flint/commands"
+	"github.com/cirelion/flint/common"
 	"github.com/cirelion/flint/lib/dcmd"
 	"github.com/cirelion/flint/lib/dice"
 )
 
+var logger = common.GetFixedPrefixLogger("roll_cmd")
+
+// subCommands are the keywords handled by the roll command itself, macros and sheets can't use these names
+var subCommands map[string]func(data *dcmd.Data, args []string) (interface{}, error)
+
+func init() {
+	// set in init as saving macros checks the names against this
+	subCommands = map[string]func(data *dcmd.Data, args []string) (interface{}, error){
+		"save":    cmdSave,
+		"delete":  cmdDelete,
+		"macros":  cmdMacros,
+		"sheet":   cmdSheet,
+		"history": cmdHistory,
+		"verify":  cmdVerify,
+	}
+}
+
 var Command = &commands.YAGCommand{
-	CmdCategory:     commands.CategoryFun,
-	Name:            "Roll",
-	Description:     "Roll dices, specify nothing for 6 sides, specify a number for max sides, or rpg dice syntax.",
-	LongDescription: "Example: `-roll 2d6`",
+	CmdCategory: commands.CategoryFun,
+	Name:        "Roll",
+	Description: "Roll dices, specify nothing for 6 sides, specify a number for max sides, or rpg dice syntax.",
+	LongDescription: "Example: `-roll 2d6`, `-roll 1d20adv+5` (advantage, `dis` for disadvantage), `-roll 3d6!` (exploding dice)\n\n" +
+		"**Macros**\n" +
+		"`-roll save <name> <dice>` saves a roll, `-roll <name>` rolls it, `-roll delete <name>` deletes it and `-roll macros` lists your macros and sheets.\n\n" +
+		"**Character sheets**\n" +
+		"`-roll sheet <name> str=3 dex=-1` creates or updates a sheet and makes it active, `-roll sheet <name>` switches to it, " +
+		"`-roll sheet delete <name>` deletes it. Rolls can use the stats of the active sheet, like `-roll 1d20+{str}`.\n\n" +
+		"**History**\n" +
+		"`-roll history` shows the last rolls in the channel with their seeds, `-roll verify <seed> <dice>` rolls again with a seed to check a result.",
 	Arguments: []*dcmd.ArgDef{
 		{Name: "Sides", Default: 0, Type: dcmd.Int},
 		{Name: "RPG-Dice", Type: dcmd.String},
@@ -25,19 +51,15 @@ var Command = &commands.YAGCommand{
 	RunFunc: func(data *dcmd.Data) (interface{}, error) {
 		if data.Args[1].Value != nil {
 			// Special dice syntax if string
-			r, _, err := dice.Roll(strings.ToLower(data.Args[1].Str()))
-			if err != nil {
-				return err.Error(), nil
+			input := strings.ToLower(strings.TrimSpace(data.Args[1].Str()))
+			fields := strings.Fields(input)
+			if len(fields) > 0 {
+				if f, ok := subCommands[fields[0]]; ok {
+					return f(data, fields[1:])
+				}
 			}
 
-			output := r.String()
-			if len(output) > 100 {
-				output = output[:100] + "..."
-			} else {
-				output = strings.TrimSuffix(output, "([])")
-			}
-
-			return ":game_die: " + output, nil
+			return rollExpression(data, input)
 		}
 
 		// normal, n sides dice rolling
@@ -46,8 +68,226 @@ var Command = &commands.YAGCommand{
 			sides = 6
 		}
 
-		result := rand.Intn(sides)
-		output := fmt.Sprintf(":game_die: %d (1 - %d)", result+1, sides)
+		expression := "1d" + strconv.Itoa(sides)
+		seed := newSeed()
+		r, _, err := dice.RollSeeded(expression, seed)
+		if err != nil {
+			return err.Error(), nil
+		}
+
+		result := r.Int()
+		addHistory(data.ChannelID, &HistoryEntry{
+			Time:       time.Now(),
+			UserID:     data.Author.ID,
+			Username:   data.Author.Username,
+			Expression: expression,
+			Result:     strconv.Itoa(result),
+			Seed:       seed,
+		})
+
+		output := fmt.Sprintf(":game_die: %d (1 - %d)", result, sides)
 		return output, nil
 	},
 }
+
+// rollExpression rolls the dice expression or macro, expanding stats from the active character sheet
+func rollExpression(data *dcmd.Data, input string) (interface{}, error) {
+	expression := input
+	if nameRegex.MatchString(input) && !dice.IsRoll(input) {
+		macro, err := findMacro(data.Author.ID, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if macro != nil {
+			expression = macro.Expression
+		}
+	}
+
+	if len(dice.VariableNames(expression)) > 0 {
+		sheet, err := activeSheet(data.Author.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if sheet == nil {
+			return "You don't have an active character sheet, create one with `roll sheet <name> stat=value...`", nil
+		}
+
+		expression, err = dice.ExpandVariables(expression, sheet.StatMap())
+		if err != nil {
+			return err.Error() + " on sheet " + sheet.Name, nil
+		}
+	}
+
+	seed := newSeed()
+	r, _, err := dice.RollSeeded(expression, seed)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	output := formatResult(r)
+	addHistory(data.ChannelID, &HistoryEntry{
+		Time:       time.Now(),
+		UserID:     data.Author.ID,
+		Username:   data.Author.Username,
+		Expression: expression,
+		Result:     output,
+		Seed:       seed,
+	})
+
+	return ":game_die: " + output, nil
+}
+
+func formatResult(r dice.RollResult) string {
+	output := r.String()
+	if len(output) > 100 {
+		output = output[:100] + "..."
+	} else {
+		output = strings.TrimSuffix(output, "([])")
+	}
+
+	return output
+}
+
+func cmdSave(data *dcmd.Data, args []string) (interface{}, error) {
+	if len(args) < 2 {
+		return "Usage: `roll save <name> <dice>`", nil
+	}
+
+	err := saveMacro(data.Author.ID, args[0], strings.Join(args[1:], " "))
+	if err != nil {
+		return nil, err
+	}
+
+	return "Saved macro `" + args[0] + "`", nil
+}
+
+func cmdDelete(data *dcmd.Data, args []string) (interface{}, error) {
+	if len(args) != 1 {
+		return "Usage: `roll delete <name>`", nil
+	}
+
+	deleted, err := deleteMacro(data.Author.ID, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if !deleted {
+		return "You don't have a macro called `" + args[0] + "`", nil
+	}
+
+	return "Deleted macro `" + args[0] + "`", nil
+}
+
+func cmdMacros(data *dcmd.Data, args []string) (interface{}, error) {
+	macros, err := getMacros(data.Author.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sheets, err := getSheets(data.Author.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(macros) < 1 && len(sheets) < 1 {
+		return "You don't have any macros or character sheets", nil
+	}
+
+	var out strings.Builder
+	if len(macros) > 0 {
+		out.WriteString("**Macros**\n")
+		for _, v := range macros {
+			fmt.Fprintf(&out, "`%s`: `%s`\n", v.Name, v.Expression)
+		}
+	}
+
+	if len(sheets) > 0 {
+		out.WriteString("**Character sheets**\n")
+		for _, v := range sheets {
+			out.WriteString(v.String())
+			if v.Active {
+				out.WriteString(" (active)")
+			}
+			out.WriteString("\n")
+		}
+	}
+
+	return out.String(), nil
+}
+
+func cmdSheet(data *dcmd.Data, args []string) (interface{}, error) {
+	if len(args) < 1 {
+		sheet, err := activeSheet(data.Author.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if sheet == nil {
+			return "You don't have an active character sheet, create one with `roll sheet <name> stat=value...`", nil
+		}
+
+		return sheet.String(), nil
+	}
+
+	if args[0] == "delete" {
+		if len(args) != 2 {
+			return "Usage: `roll sheet delete <name>`", nil
+		}
+
+		deleted, err := deleteSheet(data.Author.ID, args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if !deleted {
+			return "You don't have a character sheet called `" + args[1] + "`", nil
+		}
+
+		return "Deleted character sheet `" + args[1] + "`", nil
+	}
+
+	sheet, err := updateSheet(data.Author.ID, args[0], args[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return "Active character sheet: " + sheet.String(), nil
+}
+
+func cmdHistory(data *dcmd.Data, args []string) (interface{}, error) {
+	entries, err := getHistory(data.ChannelID, 10)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) < 1 {
+		return "No rolls in this channel recently", nil
+	}
+
+	var out strings.Builder
+	for _, v := range entries {
+		fmt.Fprintf(&out, "<t:%d:R> **%s** `%s`: %s (seed `%d`)\n", v.Time.Unix(), v.Username, v.Expression, v.Result, v.Seed)
+	}
+
+	return out.String(), nil
+}
+
+func cmdVerify(data *dcmd.Data, args []string) (interface{}, error) {
+	if len(args) < 2 {
+		return "Usage: `roll verify <seed> <dice>`", nil
+	}
+
+	seed, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return "Invalid seed `" + args[0] + "`", nil
+	}
+
+	r, _, err := dice.RollSeeded(strings.Join(args[1:], " "), seed)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	return ":game_die: " + formatResult(r), nil
+}
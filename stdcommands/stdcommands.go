@@ -38,6 +38,7 @@ import (
 	"github.com/cirelion/flint/stdcommands/memstats"
 	"github.com/cirelion/flint/stdcommands/ping"
 
+	"github.com/cirelion/flint/stdcommands/recordevents"
 	"github.com/cirelion/flint/stdcommands/roll"
 	"github.com/cirelion/flint/stdcommands/setstatus"
 	"github.com/cirelion/flint/stdcommands/simpleembed"
@@ -130,6 +131,7 @@ func (p *Plugin) AddCommands() {
 		_break.Command,
 		sleep.Command,
 		toggledbg.Command,
+		recordevents.Command,
 		globalrl.Command,
 		listflags.Command,
 	)
@@ -144,4 +146,5 @@ func (p *Plugin) BotInit() {
 
 func RegisterPlugin() {
 	common.RegisterPlugin(&Plugin{})
+	common.GORM.AutoMigrate(&roll.Macro{}, &roll.CharacterSheet{})
 }
@@ -0,0 +1,129 @@
+package tickets
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"github.com/cirelion/flint/backup"
+	"github.com/cirelion/flint/commands"
+	"github.com/cirelion/flint/tickets/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// ticketsBackup is the exported ticket settings, panels are not included as they belong to a posted message
+type ticketsBackup struct {
+	Config *models.TicketConfig `json:"config"`
+	Types  []*models.TicketType `json:"types"`
+}
+
+var _ backup.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	conf, err := models.FindTicketConfigG(ctx, guildID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return nil, err
+		}
+
+		conf = nil
+	}
+
+	types, err := models.TicketTypes(models.TicketTypeWhere.GuildID.EQ(guildID), qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ticketsBackup{Config: conf, Types: types}, nil
+}
+
+func (p *Plugin) ImportBackup(ctx context.Context, ic *backup.ImportContext, data json.RawMessage) error {
+	var exported ticketsBackup
+	err := json.Unmarshal(data, &exported)
+	if err != nil {
+		return err
+	}
+
+	if exported.Config != nil {
+		err = importTicketConfig(ctx, ic, exported.Config)
+		if err != nil {
+			return err
+		}
+	}
+
+	return importTicketTypes(ctx, ic, exported.Types)
+}
+
+func importTicketConfig(ctx context.Context, ic *backup.ImportContext, conf *models.TicketConfig) error {
+	remap := ic.Remap
+	conf.GuildID = ic.GS.ID
+	conf.TicketsChannelCategory = remap.Channel(conf.TicketsChannelCategory)
+	conf.StatusChannel = remap.Channel(conf.StatusChannel)
+	conf.TicketsTranscriptsChannel = remap.Channel(conf.TicketsTranscriptsChannel)
+	conf.TicketsTranscriptsChannelAdminOnly = remap.Channel(conf.TicketsTranscriptsChannelAdminOnly)
+	conf.ModRoles = remap.Roles(conf.ModRoles)
+	conf.AdminRoles = remap.Roles(conf.AdminRoles)
+
+	err := conf.UpsertG(ctx, true, []string{"guild_id"}, boil.Infer(), boil.Infer())
+	if err != nil {
+		return err
+	}
+
+	commands.PubsubSendUpdateSlashCommandsPermissions(ic.GS.ID)
+	return RescheduleInactivityChecks(ctx, conf)
+}
+
+// importTicketTypes replaces the ticket types, existing types with the same name are updated instead
+// so the panels using them keep working
+func importTicketTypes(ctx context.Context, ic *backup.ImportContext, types []*models.TicketType) error {
+	existing, err := models.TicketTypes(models.TicketTypeWhere.GuildID.EQ(ic.GS.ID)).AllG(ctx)
+	if err != nil {
+		return err
+	}
+
+	byName := make(map[string]*models.TicketType)
+	for _, v := range existing {
+		byName[strings.ToLower(v.Name)] = v
+	}
+
+	if len(types) > MaxTicketTypes {
+		ic.Warn("Tickets: only the first %d ticket types were imported", MaxTicketTypes)
+		types = types[:MaxTicketTypes]
+	}
+
+	kept := make(map[int64]bool)
+	for _, tt := range types {
+		tt.GuildID = ic.GS.ID
+		tt.ChannelCategory = ic.Remap.Channel(tt.ChannelCategory)
+		tt.TranscriptsChannel = ic.Remap.Channel(tt.TranscriptsChannel)
+		tt.StaffRoles = ic.Remap.Roles(tt.StaffRoles)
+
+		if current, ok := byName[strings.ToLower(tt.Name)]; ok && !kept[current.ID] {
+			tt.ID = current.ID
+			kept[current.ID] = true
+			_, err = tt.UpdateG(ctx, boil.Infer())
+		} else {
+			tt.ID = 0
+			err = tt.InsertG(ctx, boil.Infer())
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, v := range existing {
+		if kept[v.ID] {
+			continue
+		}
+
+		_, err = v.DeleteG(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
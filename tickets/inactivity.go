@@ -0,0 +1,206 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/cirelion/flint/bot"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/i18n"
+	"github.com/cirelion/flint/common/scheduledevents2"
+	seventsmodels "github.com/cirelion/flint/common/scheduledevents2/models"
+	"github.com/cirelion/flint/tickets/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const (
+	evtInactivityCheck    = "tickets_inactivity_check"
+	featureFlagInactivity = "tickets_inactivity_enabled"
+
+	// activity is only recorded once per interval to avoid a write on every message
+	activityUpdateInterval = time.Minute
+)
+
+type inactivityCheckData struct {
+	LocalID int64 `json:"local_id"`
+}
+
+func inactivityEnabled(conf *models.TicketConfig) bool {
+	return conf.Enabled && (conf.InactivityReminderHours > 0 || conf.InactivityCloseHours > 0)
+}
+
+// nextInactivityCheck returns when the reminder or auto close for the ticket is due next, false if neither is
+func nextInactivityCheck(conf *models.TicketConfig, ticket *models.Ticket) (time.Time, bool) {
+	var next time.Time
+	if conf.InactivityReminderHours > 0 && !ticket.InactivityReminded {
+		next = ticket.LastActivityAt.Add(time.Hour * time.Duration(conf.InactivityReminderHours))
+	}
+
+	if conf.InactivityCloseHours > 0 {
+		closeAt := ticket.LastActivityAt.Add(time.Hour * time.Duration(conf.InactivityCloseHours))
+		if next.IsZero() || closeAt.Before(next) {
+			next = closeAt
+		}
+	}
+
+	return next, !next.IsZero()
+}
+
+func scheduleInactivityCheck(conf *models.TicketConfig, ticket *models.Ticket) error {
+	if !inactivityEnabled(conf) || ticket.Pinned || ticket.ClosedAt.Valid {
+		return nil
+	}
+
+	next, ok := nextInactivityCheck(conf, ticket)
+	if !ok {
+		return nil
+	}
+
+	return scheduledevents2.ScheduleEvent(evtInactivityCheck, ticket.GuildID, next, &inactivityCheckData{LocalID: ticket.LocalID})
+}
+
+func clearInactivityChecks(ctx context.Context, guildID int64, localID int64) error {
+	query := "DELETE FROM scheduled_events WHERE event_name = $1 AND guild_id = $2 AND processed = false"
+	args := []interface{}{evtInactivityCheck, guildID}
+	if localID != 0 {
+		query += " AND (data->>'local_id')::bigint = $3"
+		args = append(args, localID)
+	}
+
+	_, err := common.PQ.ExecContext(ctx, query, args...)
+	return errors.WithStackIf(err)
+}
+
+// RescheduleInactivityChecks replaces the pending checks for all open tickets in the guild,
+// used when the inactivity settings change
+func RescheduleInactivityChecks(ctx context.Context, conf *models.TicketConfig) error {
+	err := clearInactivityChecks(ctx, conf.GuildID, 0)
+	if err != nil {
+		return err
+	}
+
+	if !inactivityEnabled(conf) {
+		return nil
+	}
+
+	tickets, err := models.Tickets(
+		models.TicketWhere.GuildID.EQ(conf.GuildID),
+		models.TicketWhere.ClosedAt.IsNull(),
+		models.TicketWhere.Pinned.EQ(false)).AllG(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tickets {
+		ttConf := conf
+		if t.TicketTypeID.Valid {
+			tt, err := findTicketType(ctx, t)
+			if err != nil {
+				return err
+			}
+			ttConf = configForTicketType(conf, tt)
+		}
+
+		err = scheduleInactivityCheck(ttConf, t)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// touchTicketActivity resets the inactivity timers of the ticket
+func touchTicketActivity(ctx context.Context, guildID, localID int64) {
+	_, err := models.Tickets(
+		models.TicketWhere.GuildID.EQ(guildID),
+		models.TicketWhere.LocalID.EQ(localID),
+		models.TicketWhere.ClosedAt.IsNull(),
+		models.TicketWhere.LastActivityAt.LT(time.Now().Add(-activityUpdateInterval)),
+	).UpdateAllG(ctx, models.M{
+		models.TicketColumns.LastActivityAt:     time.Now(),
+		models.TicketColumns.InactivityReminded: false,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed updating ticket activity")
+	}
+}
+
+func handleInactivityCheck(evt *seventsmodels.ScheduledEvent, data interface{}) (retry bool, err error) {
+	dataCast := data.(*inactivityCheckData)
+	ctx := context.Background()
+
+	ticket, err := models.FindTicketG(ctx, evt.GuildID, dataCast.LocalID)
+	if err != nil {
+		return false, errors.WithStackIf(err)
+	}
+
+	if ticket.ClosedAt.Valid || ticket.Pinned {
+		return false, nil
+	}
+
+	conf, err := models.FindTicketConfigG(ctx, evt.GuildID)
+	if err != nil {
+		return false, errors.WithStackIf(err)
+	}
+
+	tt, err := findTicketType(ctx, ticket)
+	if err != nil {
+		return true, err
+	}
+	conf = configForTicketType(conf, tt)
+
+	if !inactivityEnabled(conf) {
+		return false, nil
+	}
+
+	gs := bot.State.GetGuild(evt.GuildID)
+	if gs == nil || gs.GetChannel(ticket.ChannelID) == nil {
+		return false, nil
+	}
+
+	idle := time.Since(ticket.LastActivityAt)
+	if conf.InactivityCloseHours > 0 && idle >= time.Hour*time.Duration(conf.InactivityCloseHours) {
+		err = closeTicket(ctx, gs, conf, ticket, i18n.TranslatePlural(i18n.GuildLanguage(gs.ID), "tickets.inactivity_closed", conf.InactivityCloseHours, conf.InactivityCloseHours))
+		if err == errAlreadyClosing {
+			return false, nil
+		}
+
+		return scheduledevents2.CheckDiscordErrRetry(err), err
+	}
+
+	if conf.InactivityReminderHours > 0 && !ticket.InactivityReminded && idle >= time.Hour*time.Duration(conf.InactivityReminderHours) {
+		sendInactivityReminder(gs.Name, conf, ticket)
+
+		ticket.InactivityReminded = true
+		_, err = ticket.UpdateG(ctx, boil.Whitelist(models.TicketColumns.InactivityReminded))
+		if err != nil {
+			return true, err
+		}
+	}
+
+	// either there was activity since this was scheduled or the reminder was just sent
+	err = scheduleInactivityCheck(conf, ticket)
+	return err != nil, err
+}
+
+func sendInactivityReminder(guildName string, conf *models.TicketConfig, ticket *models.Ticket) {
+	lang := i18n.GuildLanguage(ticket.GuildID)
+	msg := i18n.Translate(lang, "tickets.inactivity_reminder")
+	if conf.InactivityCloseHours > 0 {
+		msg += " " + i18n.TranslatePlural(lang, "tickets.inactivity_close_warning", conf.InactivityCloseHours, conf.InactivityCloseHours)
+	}
+
+	if ticket.Modmail {
+		// the author is not in modmail ticket channels
+		bot.SendDM(ticket.AuthorID, i18n.Translate(lang, "tickets.inactivity_reminder_dm", guildName, msg))
+		return
+	}
+
+	_, err := common.BotSession.ChannelMessageSend(ticket.ChannelID, fmt.Sprintf("<@%d>, %s", ticket.AuthorID, msg))
+	if err != nil {
+		logger.WithError(err).WithField("guild", ticket.GuildID).Error("failed sending ticket inactivity reminder")
+	}
+}
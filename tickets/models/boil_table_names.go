@@ -5,10 +5,14 @@ package models
 
 var TableNames = struct {
 	TicketConfigs      string
+	TicketPanels       string
 	TicketParticipants string
+	TicketTypes        string
 	Tickets            string
 }{
 	TicketConfigs:      "ticket_configs",
+	TicketPanels:       "ticket_panels",
 	TicketParticipants: "ticket_participants",
+	TicketTypes:        "ticket_types",
 	Tickets:            "tickets",
 }
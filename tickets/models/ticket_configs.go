@@ -35,6 +35,10 @@ type TicketConfig struct {
 	ModRoles                           types.Int64Array `boil:"mod_roles" json:"mod_roles,omitempty" toml:"mod_roles" yaml:"mod_roles,omitempty"`
 	AdminRoles                         types.Int64Array `boil:"admin_roles" json:"admin_roles,omitempty" toml:"admin_roles" yaml:"admin_roles,omitempty"`
 	TicketsTranscriptsChannelAdminOnly int64            `boil:"tickets_transcripts_channel_admin_only" json:"tickets_transcripts_channel_admin_only" toml:"tickets_transcripts_channel_admin_only" yaml:"tickets_transcripts_channel_admin_only"`
+	ModmailEnabled                     bool             `boil:"modmail_enabled" json:"modmail_enabled" toml:"modmail_enabled" yaml:"modmail_enabled"`
+	InactivityReminderHours            int              `boil:"inactivity_reminder_hours" json:"inactivity_reminder_hours" toml:"inactivity_reminder_hours" yaml:"inactivity_reminder_hours"`
+	InactivityCloseHours               int              `boil:"inactivity_close_hours" json:"inactivity_close_hours" toml:"inactivity_close_hours" yaml:"inactivity_close_hours"`
+	SatisfactionSurvey                 bool             `boil:"satisfaction_survey" json:"satisfaction_survey" toml:"satisfaction_survey" yaml:"satisfaction_survey"`
 
 	R *ticketConfigR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L ticketConfigL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -52,6 +56,10 @@ var TicketConfigColumns = struct {
 	ModRoles                           string
 	AdminRoles                         string
 	TicketsTranscriptsChannelAdminOnly string
+	ModmailEnabled                     string
+	InactivityReminderHours            string
+	InactivityCloseHours               string
+	SatisfactionSurvey                 string
 }{
 	GuildID:                            "guild_id",
 	Enabled:                            "enabled",
@@ -64,6 +72,10 @@ var TicketConfigColumns = struct {
 	ModRoles:                           "mod_roles",
 	AdminRoles:                         "admin_roles",
 	TicketsTranscriptsChannelAdminOnly: "tickets_transcripts_channel_admin_only",
+	ModmailEnabled:                     "modmail_enabled",
+	InactivityReminderHours:            "inactivity_reminder_hours",
+	InactivityCloseHours:               "inactivity_close_hours",
+	SatisfactionSurvey:                 "satisfaction_survey",
 }
 
 var TicketConfigTableColumns = struct {
@@ -78,6 +90,10 @@ var TicketConfigTableColumns = struct {
 	ModRoles                           string
 	AdminRoles                         string
 	TicketsTranscriptsChannelAdminOnly string
+	ModmailEnabled                     string
+	InactivityReminderHours            string
+	InactivityCloseHours               string
+	SatisfactionSurvey                 string
 }{
 	GuildID:                            "ticket_configs.guild_id",
 	Enabled:                            "ticket_configs.enabled",
@@ -90,6 +106,10 @@ var TicketConfigTableColumns = struct {
 	ModRoles:                           "ticket_configs.mod_roles",
 	AdminRoles:                         "ticket_configs.admin_roles",
 	TicketsTranscriptsChannelAdminOnly: "ticket_configs.tickets_transcripts_channel_admin_only",
+	ModmailEnabled:                     "ticket_configs.modmail_enabled",
+	InactivityReminderHours:            "ticket_configs.inactivity_reminder_hours",
+	InactivityCloseHours:               "ticket_configs.inactivity_close_hours",
+	SatisfactionSurvey:                 "ticket_configs.satisfaction_survey",
 }
 
 // Generated where
@@ -173,6 +193,15 @@ func (w whereHelpertypes_Int64Array) GTE(x types.Int64Array) qm.QueryMod {
 func (w whereHelpertypes_Int64Array) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpertypes_Int64Array) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
+type whereHelperint struct{ field string }
+
+func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
 var TicketConfigWhere = struct {
 	GuildID                            whereHelperint64
 	Enabled                            whereHelperbool
@@ -185,6 +214,10 @@ var TicketConfigWhere = struct {
 	ModRoles                           whereHelpertypes_Int64Array
 	AdminRoles                         whereHelpertypes_Int64Array
 	TicketsTranscriptsChannelAdminOnly whereHelperint64
+	ModmailEnabled                     whereHelperbool
+	InactivityReminderHours            whereHelperint
+	InactivityCloseHours               whereHelperint
+	SatisfactionSurvey                 whereHelperbool
 }{
 	GuildID:                            whereHelperint64{field: "\"ticket_configs\".\"guild_id\""},
 	Enabled:                            whereHelperbool{field: "\"ticket_configs\".\"enabled\""},
@@ -197,6 +230,10 @@ var TicketConfigWhere = struct {
 	ModRoles:                           whereHelpertypes_Int64Array{field: "\"ticket_configs\".\"mod_roles\""},
 	AdminRoles:                         whereHelpertypes_Int64Array{field: "\"ticket_configs\".\"admin_roles\""},
 	TicketsTranscriptsChannelAdminOnly: whereHelperint64{field: "\"ticket_configs\".\"tickets_transcripts_channel_admin_only\""},
+	ModmailEnabled:                     whereHelperbool{field: "\"ticket_configs\".\"modmail_enabled\""},
+	InactivityReminderHours:            whereHelperint{field: "\"ticket_configs\".\"inactivity_reminder_hours\""},
+	InactivityCloseHours:               whereHelperint{field: "\"ticket_configs\".\"inactivity_close_hours\""},
+	SatisfactionSurvey:                 whereHelperbool{field: "\"ticket_configs\".\"satisfaction_survey\""},
 }
 
 // TicketConfigRels is where relationship names are stored.
@@ -216,9 +253,9 @@ func (*ticketConfigR) NewStruct() *ticketConfigR {
 type ticketConfigL struct{}
 
 var (
-	ticketConfigAllColumns            = []string{"guild_id", "enabled", "ticket_open_msg", "tickets_channel_category", "status_channel", "tickets_transcripts_channel", "download_attachments", "tickets_use_txt_transcripts", "mod_roles", "admin_roles", "tickets_transcripts_channel_admin_only"}
+	ticketConfigAllColumns            = []string{"guild_id", "enabled", "ticket_open_msg", "tickets_channel_category", "status_channel", "tickets_transcripts_channel", "download_attachments", "tickets_use_txt_transcripts", "mod_roles", "admin_roles", "tickets_transcripts_channel_admin_only", "modmail_enabled", "inactivity_reminder_hours", "inactivity_close_hours", "satisfaction_survey"}
 	ticketConfigColumnsWithoutDefault = []string{"guild_id", "enabled", "ticket_open_msg", "tickets_channel_category", "status_channel", "tickets_transcripts_channel", "download_attachments", "tickets_use_txt_transcripts"}
-	ticketConfigColumnsWithDefault    = []string{"mod_roles", "admin_roles", "tickets_transcripts_channel_admin_only"}
+	ticketConfigColumnsWithDefault    = []string{"mod_roles", "admin_roles", "tickets_transcripts_channel_admin_only", "modmail_enabled", "inactivity_reminder_hours", "inactivity_close_hours", "satisfaction_survey"}
 	ticketConfigPrimaryKeyColumns     = []string{"guild_id"}
 	ticketConfigGeneratedColumns      = []string{}
 )
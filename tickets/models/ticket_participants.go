@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,65 +24,79 @@ import (
 
 // TicketParticipant is an object representing the database table.
 type TicketParticipant struct {
-	TicketGuildID int64  `boil:"ticket_guild_id" json:"ticket_guild_id" toml:"ticket_guild_id" yaml:"ticket_guild_id"`
-	TicketLocalID int64  `boil:"ticket_local_id" json:"ticket_local_id" toml:"ticket_local_id" yaml:"ticket_local_id"`
-	UserID        int64  `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
-	Username      string `boil:"username" json:"username" toml:"username" yaml:"username"`
-	Discrim       string `boil:"discrim" json:"discrim" toml:"discrim" yaml:"discrim"`
-	IsStaff       bool   `boil:"is_staff" json:"is_staff" toml:"is_staff" yaml:"is_staff"`
+	TicketGuildID  int64     `boil:"ticket_guild_id" json:"ticket_guild_id" toml:"ticket_guild_id" yaml:"ticket_guild_id"`
+	TicketLocalID  int64     `boil:"ticket_local_id" json:"ticket_local_id" toml:"ticket_local_id" yaml:"ticket_local_id"`
+	UserID         int64     `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	Username       string    `boil:"username" json:"username" toml:"username" yaml:"username"`
+	Discrim        string    `boil:"discrim" json:"discrim" toml:"discrim" yaml:"discrim"`
+	IsStaff        bool      `boil:"is_staff" json:"is_staff" toml:"is_staff" yaml:"is_staff"`
+	FirstMessageAt null.Time `boil:"first_message_at" json:"first_message_at,omitempty" toml:"first_message_at" yaml:"first_message_at,omitempty"`
+	MessageCount   int       `boil:"message_count" json:"message_count" toml:"message_count" yaml:"message_count"`
 
 	R *ticketParticipantR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L ticketParticipantL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var TicketParticipantColumns = struct {
-	TicketGuildID string
-	TicketLocalID string
-	UserID        string
-	Username      string
-	Discrim       string
-	IsStaff       string
+	TicketGuildID  string
+	TicketLocalID  string
+	UserID         string
+	Username       string
+	Discrim        string
+	IsStaff        string
+	FirstMessageAt string
+	MessageCount   string
 }{
-	TicketGuildID: "ticket_guild_id",
-	TicketLocalID: "ticket_local_id",
-	UserID:        "user_id",
-	Username:      "username",
-	Discrim:       "discrim",
-	IsStaff:       "is_staff",
+	TicketGuildID:  "ticket_guild_id",
+	TicketLocalID:  "ticket_local_id",
+	UserID:         "user_id",
+	Username:       "username",
+	Discrim:        "discrim",
+	IsStaff:        "is_staff",
+	FirstMessageAt: "first_message_at",
+	MessageCount:   "message_count",
 }
 
 var TicketParticipantTableColumns = struct {
-	TicketGuildID string
-	TicketLocalID string
-	UserID        string
-	Username      string
-	Discrim       string
-	IsStaff       string
+	TicketGuildID  string
+	TicketLocalID  string
+	UserID         string
+	Username       string
+	Discrim        string
+	IsStaff        string
+	FirstMessageAt string
+	MessageCount   string
 }{
-	TicketGuildID: "ticket_participants.ticket_guild_id",
-	TicketLocalID: "ticket_participants.ticket_local_id",
-	UserID:        "ticket_participants.user_id",
-	Username:      "ticket_participants.username",
-	Discrim:       "ticket_participants.discrim",
-	IsStaff:       "ticket_participants.is_staff",
+	TicketGuildID:  "ticket_participants.ticket_guild_id",
+	TicketLocalID:  "ticket_participants.ticket_local_id",
+	UserID:         "ticket_participants.user_id",
+	Username:       "ticket_participants.username",
+	Discrim:        "ticket_participants.discrim",
+	IsStaff:        "ticket_participants.is_staff",
+	FirstMessageAt: "ticket_participants.first_message_at",
+	MessageCount:   "ticket_participants.message_count",
 }
 
 // Generated where
 
 var TicketParticipantWhere = struct {
-	TicketGuildID whereHelperint64
-	TicketLocalID whereHelperint64
-	UserID        whereHelperint64
-	Username      whereHelperstring
-	Discrim       whereHelperstring
-	IsStaff       whereHelperbool
+	TicketGuildID  whereHelperint64
+	TicketLocalID  whereHelperint64
+	UserID         whereHelperint64
+	Username       whereHelperstring
+	Discrim        whereHelperstring
+	IsStaff        whereHelperbool
+	FirstMessageAt whereHelpernull_Time
+	MessageCount   whereHelperint
 }{
-	TicketGuildID: whereHelperint64{field: "\"ticket_participants\".\"ticket_guild_id\""},
-	TicketLocalID: whereHelperint64{field: "\"ticket_participants\".\"ticket_local_id\""},
-	UserID:        whereHelperint64{field: "\"ticket_participants\".\"user_id\""},
-	Username:      whereHelperstring{field: "\"ticket_participants\".\"username\""},
-	Discrim:       whereHelperstring{field: "\"ticket_participants\".\"discrim\""},
-	IsStaff:       whereHelperbool{field: "\"ticket_participants\".\"is_staff\""},
+	TicketGuildID:  whereHelperint64{field: "\"ticket_participants\".\"ticket_guild_id\""},
+	TicketLocalID:  whereHelperint64{field: "\"ticket_participants\".\"ticket_local_id\""},
+	UserID:         whereHelperint64{field: "\"ticket_participants\".\"user_id\""},
+	Username:       whereHelperstring{field: "\"ticket_participants\".\"username\""},
+	Discrim:        whereHelperstring{field: "\"ticket_participants\".\"discrim\""},
+	IsStaff:        whereHelperbool{field: "\"ticket_participants\".\"is_staff\""},
+	FirstMessageAt: whereHelpernull_Time{field: "\"ticket_participants\".\"first_message_at\""},
+	MessageCount:   whereHelperint{field: "\"ticket_participants\".\"message_count\""},
 }
 
 // TicketParticipantRels is where relationship names are stored.
@@ -101,9 +116,9 @@ func (*ticketParticipantR) NewStruct() *ticketParticipantR {
 type ticketParticipantL struct{}
 
 var (
-	ticketParticipantAllColumns            = []string{"ticket_guild_id", "ticket_local_id", "user_id", "username", "discrim", "is_staff"}
+	ticketParticipantAllColumns            = []string{"ticket_guild_id", "ticket_local_id", "user_id", "username", "discrim", "is_staff", "first_message_at", "message_count"}
 	ticketParticipantColumnsWithoutDefault = []string{"ticket_guild_id", "ticket_local_id", "user_id", "username", "discrim", "is_staff"}
-	ticketParticipantColumnsWithDefault    = []string{}
+	ticketParticipantColumnsWithDefault    = []string{"first_message_at", "message_count"}
 	ticketParticipantPrimaryKeyColumns     = []string{"ticket_guild_id", "ticket_local_id", "user_id"}
 	ticketParticipantGeneratedColumns      = []string{}
 )
@@ -24,17 +24,24 @@ import (
 
 // Ticket is an object representing the database table.
 type Ticket struct {
-	GuildID               int64     `boil:"guild_id" json:"guild_id" toml:"guild_id" yaml:"guild_id"`
-	LocalID               int64     `boil:"local_id" json:"local_id" toml:"local_id" yaml:"local_id"`
-	ChannelID             int64     `boil:"channel_id" json:"channel_id" toml:"channel_id" yaml:"channel_id"`
-	Title                 string    `boil:"title" json:"title" toml:"title" yaml:"title"`
-	CreatedAt             time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	ClosedAt              null.Time `boil:"closed_at" json:"closed_at,omitempty" toml:"closed_at" yaml:"closed_at,omitempty"`
-	LogsID                int64     `boil:"logs_id" json:"logs_id" toml:"logs_id" yaml:"logs_id"`
-	AuthorID              int64     `boil:"author_id" json:"author_id" toml:"author_id" yaml:"author_id"`
-	AuthorUsernameDiscrim string    `boil:"author_username_discrim" json:"author_username_discrim" toml:"author_username_discrim" yaml:"author_username_discrim"`
-	Question              string    `boil:"question" json:"question" toml:"question" yaml:"question"`
-	Logs                  string    `boil:"logs" json:"logs" toml:"logs" yaml:"logs"`
+	GuildID               int64      `boil:"guild_id" json:"guild_id" toml:"guild_id" yaml:"guild_id"`
+	LocalID               int64      `boil:"local_id" json:"local_id" toml:"local_id" yaml:"local_id"`
+	ChannelID             int64      `boil:"channel_id" json:"channel_id" toml:"channel_id" yaml:"channel_id"`
+	Title                 string     `boil:"title" json:"title" toml:"title" yaml:"title"`
+	CreatedAt             time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ClosedAt              null.Time  `boil:"closed_at" json:"closed_at,omitempty" toml:"closed_at" yaml:"closed_at,omitempty"`
+	LogsID                int64      `boil:"logs_id" json:"logs_id" toml:"logs_id" yaml:"logs_id"`
+	AuthorID              int64      `boil:"author_id" json:"author_id" toml:"author_id" yaml:"author_id"`
+	AuthorUsernameDiscrim string     `boil:"author_username_discrim" json:"author_username_discrim" toml:"author_username_discrim" yaml:"author_username_discrim"`
+	Question              string     `boil:"question" json:"question" toml:"question" yaml:"question"`
+	Logs                  string     `boil:"logs" json:"logs" toml:"logs" yaml:"logs"`
+	Modmail               bool       `boil:"modmail" json:"modmail" toml:"modmail" yaml:"modmail"`
+	TicketTypeID          null.Int64 `boil:"ticket_type_id" json:"ticket_type_id,omitempty" toml:"ticket_type_id" yaml:"ticket_type_id,omitempty"`
+	LastActivityAt        time.Time  `boil:"last_activity_at" json:"last_activity_at" toml:"last_activity_at" yaml:"last_activity_at"`
+	InactivityReminded    bool       `boil:"inactivity_reminded" json:"inactivity_reminded" toml:"inactivity_reminded" yaml:"inactivity_reminded"`
+	Pinned                bool       `boil:"pinned" json:"pinned" toml:"pinned" yaml:"pinned"`
+	Rating                null.Int   `boil:"rating" json:"rating,omitempty" toml:"rating" yaml:"rating,omitempty"`
+	RatingComment         string     `boil:"rating_comment" json:"rating_comment" toml:"rating_comment" yaml:"rating_comment"`
 
 	R *ticketR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L ticketL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -52,6 +59,13 @@ var TicketColumns = struct {
 	AuthorUsernameDiscrim string
 	Question              string
 	Logs                  string
+	Modmail               string
+	TicketTypeID          string
+	LastActivityAt        string
+	InactivityReminded    string
+	Pinned                string
+	Rating                string
+	RatingComment         string
 }{
 	GuildID:               "guild_id",
 	LocalID:               "local_id",
@@ -64,6 +78,13 @@ var TicketColumns = struct {
 	AuthorUsernameDiscrim: "author_username_discrim",
 	Question:              "question",
 	Logs:                  "logs",
+	Modmail:               "modmail",
+	TicketTypeID:          "ticket_type_id",
+	LastActivityAt:        "last_activity_at",
+	InactivityReminded:    "inactivity_reminded",
+	Pinned:                "pinned",
+	Rating:                "rating",
+	RatingComment:         "rating_comment",
 }
 
 var TicketTableColumns = struct {
@@ -78,6 +99,13 @@ var TicketTableColumns = struct {
 	AuthorUsernameDiscrim string
 	Question              string
 	Logs                  string
+	Modmail               string
+	TicketTypeID          string
+	LastActivityAt        string
+	InactivityReminded    string
+	Pinned                string
+	Rating                string
+	RatingComment         string
 }{
 	GuildID:               "tickets.guild_id",
 	LocalID:               "tickets.local_id",
@@ -90,6 +118,13 @@ var TicketTableColumns = struct {
 	AuthorUsernameDiscrim: "tickets.author_username_discrim",
 	Question:              "tickets.question",
 	Logs:                  "tickets.logs",
+	Modmail:               "tickets.modmail",
+	TicketTypeID:          "tickets.ticket_type_id",
+	LastActivityAt:        "tickets.last_activity_at",
+	InactivityReminded:    "tickets.inactivity_reminded",
+	Pinned:                "tickets.pinned",
+	Rating:                "tickets.rating",
+	RatingComment:         "tickets.rating_comment",
 }
 
 // Generated where
@@ -139,6 +174,52 @@ func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
 func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
+type whereHelpernull_Int64 struct{ field string }
+
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+type whereHelpernull_Int struct{ field string }
+
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
 var TicketWhere = struct {
 	GuildID               whereHelperint64
 	LocalID               whereHelperint64
@@ -151,6 +232,13 @@ var TicketWhere = struct {
 	AuthorUsernameDiscrim whereHelperstring
 	Question              whereHelperstring
 	Logs                  whereHelperstring
+	Modmail               whereHelperbool
+	TicketTypeID          whereHelpernull_Int64
+	LastActivityAt        whereHelpertime_Time
+	InactivityReminded    whereHelperbool
+	Pinned                whereHelperbool
+	Rating                whereHelpernull_Int
+	RatingComment         whereHelperstring
 }{
 	GuildID:               whereHelperint64{field: "\"tickets\".\"guild_id\""},
 	LocalID:               whereHelperint64{field: "\"tickets\".\"local_id\""},
@@ -163,6 +251,13 @@ var TicketWhere = struct {
 	AuthorUsernameDiscrim: whereHelperstring{field: "\"tickets\".\"author_username_discrim\""},
 	Question:              whereHelperstring{field: "\"tickets\".\"question\""},
 	Logs:                  whereHelperstring{field: "\"tickets\".\"logs\""},
+	Modmail:               whereHelperbool{field: "\"tickets\".\"modmail\""},
+	TicketTypeID:          whereHelpernull_Int64{field: "\"tickets\".\"ticket_type_id\""},
+	LastActivityAt:        whereHelpertime_Time{field: "\"tickets\".\"last_activity_at\""},
+	InactivityReminded:    whereHelperbool{field: "\"tickets\".\"inactivity_reminded\""},
+	Pinned:                whereHelperbool{field: "\"tickets\".\"pinned\""},
+	Rating:                whereHelpernull_Int{field: "\"tickets\".\"rating\""},
+	RatingComment:         whereHelperstring{field: "\"tickets\".\"rating_comment\""},
 }
 
 // TicketRels is where relationship names are stored.
@@ -182,9 +277,9 @@ func (*ticketR) NewStruct() *ticketR {
 type ticketL struct{}
 
 var (
-	ticketAllColumns            = []string{"guild_id", "local_id", "channel_id", "title", "created_at", "closed_at", "logs_id", "author_id", "author_username_discrim", "question", "logs"}
+	ticketAllColumns            = []string{"guild_id", "local_id", "channel_id", "title", "created_at", "closed_at", "logs_id", "author_id", "author_username_discrim", "question", "logs", "modmail", "ticket_type_id", "last_activity_at", "inactivity_reminded", "pinned", "rating", "rating_comment"}
 	ticketColumnsWithoutDefault = []string{"guild_id", "local_id", "channel_id", "title", "created_at", "logs_id", "author_id", "author_username_discrim"}
-	ticketColumnsWithDefault    = []string{"closed_at", "question", "logs"}
+	ticketColumnsWithDefault    = []string{"closed_at", "question", "logs", "modmail", "ticket_type_id", "last_activity_at", "inactivity_reminded", "pinned", "rating", "rating_comment"}
 	ticketPrimaryKeyColumns     = []string{"guild_id", "local_id"}
 	ticketGeneratedColumns      = []string{}
 )
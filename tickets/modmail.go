@@ -0,0 +1,574 @@
+package tickets
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/cirelion/flint/bot"
+	"github.com/cirelion/flint/bot/botrest"
+	"github.com/cirelion/flint/bot/eventsystem"
+	"github.com/cirelion/flint/commands"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/featureflags"
+	"github.com/cirelion/flint/common/i18n"
+	prfx "github.com/cirelion/flint/common/prefix"
+	"github.com/cirelion/flint/common/pubsub"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate"
+	"github.com/cirelion/flint/tickets/models"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	ModmailPickGuild = "tickets_modmail_pick"
+
+	featureFlagModmail = "tickets_modmail_enabled"
+	featureFlagEnabled = "tickets_enabled"
+
+	// attachments bigger than this are linked instead of re-uploaded
+	modmailMaxAttachmentSize = 8 * 1024 * 1024
+	// max servers shown in the server picker, limited by the select menu
+	modmailMaxGuildChoices = 25
+	// max servers the membership of a user not in the state of this process is looked up for when looking for their servers
+	modmailMaxMemberFetches = 10
+	// users without an open ticket can only have their servers looked up once per this many seconds
+	modmailLookupCooldownSeconds = 10
+	// timeout for downloading attachments to re-upload them
+	modmailDownloadTimeout = time.Second * 30
+)
+
+func keyModmailPending(userID int64) string {
+	return "tickets_modmail_pending:" + strconv.FormatInt(userID, 10)
+}
+
+func keyModmailLookupCooldown(userID int64) string {
+	return "tickets_modmail_lookup_cooldown:" + strconv.FormatInt(userID, 10)
+}
+
+func keyModmailActiveGuild(userID int64) string {
+	return "tickets_modmail_active:" + strconv.FormatInt(userID, 10)
+}
+
+// modmailMessage is a message from a user waiting to be relayed, stored while they pick a server
+type modmailMessage struct {
+	ChannelID   int64                          `json:"channel_id"`
+	MessageID   int64                          `json:"message_id"`
+	Content     string                         `json:"content"`
+	Attachments []*discordgo.MessageAttachment `json:"attachments"`
+}
+
+var _ featureflags.PluginWithFeatureFlags = (*Plugin)(nil)
+
+func (p *Plugin) UpdateFeatureFlags(guildID int64) ([]string, error) {
+	conf, err := models.FindTicketConfigG(context.Background(), guildID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.WithStackIf(err)
+	}
+
+	var flags []string
+	if conf.Enabled {
+		flags = append(flags, featureFlagEnabled)
+	}
+
+	if conf.Enabled && conf.ModmailEnabled {
+		flags = append(flags, featureFlagModmail)
+	}
+
+	if inactivityEnabled(conf) {
+		flags = append(flags, featureFlagInactivity)
+	}
+
+	return flags, nil
+}
+
+func (p *Plugin) AllFeatureFlags() []string {
+	return []string{
+		featureFlagEnabled,    // set if tickets are enabled
+		featureFlagModmail,    // set if tickets and modmail are enabled
+		featureFlagInactivity, // set if inactivity reminders or auto close are enabled
+	}
+}
+
+func (p *Plugin) handleMessageCreate(evt *eventsystem.EventData) {
+	msg := evt.MessageCreate()
+	if msg.Author == nil || msg.Author.Bot {
+		return
+	}
+
+	if msg.GuildID == 0 {
+		handleModmailDM(msg.Message)
+		return
+	}
+
+	if !evt.HasFeatureFlag(featureFlagEnabled) {
+		return
+	}
+
+	ticket, err := openTicketInChannel(msg.GuildID, msg.ChannelID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", msg.GuildID).Error("failed retrieving open tickets")
+		return
+	}
+
+	if ticket == nil {
+		return
+	}
+
+	trackTicketParticipant(evt.Context(), msg.GuildID, ticket.LocalID, msg.Author)
+
+	if evt.HasFeatureFlag(featureFlagInactivity) {
+		touchTicketActivity(evt.Context(), msg.GuildID, ticket.LocalID)
+	}
+
+	if ticket.Modmail && evt.HasFeatureFlag(featureFlagModmail) {
+		handleModmailStaffMessage(evt, msg.Message, ticket.LocalID)
+	}
+}
+
+// handleModmailDM relays a DM to the open modmail ticket, or opens a new one
+func handleModmailDM(msg *discordgo.Message) {
+	// dm commands use the default prefix
+	if strings.HasPrefix(msg.Content, prfx.DefaultCommandPrefix()) || strings.HasPrefix(msg.Content, common.BotUser.Mention()) || (strings.TrimSpace(msg.Content) == "" && len(msg.Attachments) < 1) {
+		return
+	}
+
+	ctx := context.Background()
+	pending := &modmailMessage{
+		ChannelID:   msg.ChannelID,
+		MessageID:   msg.ID,
+		Content:     msg.Content,
+		Attachments: msg.Attachments,
+	}
+
+	openTickets, err := models.Tickets(qm.Where("author_id = ? AND modmail = true AND closed_at IS NULL", msg.Author.ID), qm.OrderBy("created_at desc")).AllG(ctx)
+	if err != nil {
+		logger.WithError(err).WithField("user", msg.Author.ID).Error("failed retrieving open modmail tickets")
+		return
+	}
+
+	if len(openTickets) > 0 {
+		var activeGuild int64
+		common.RedisPool.Do(radix.Cmd(&activeGuild, "GET", keyModmailActiveGuild(msg.Author.ID)))
+
+		for _, v := range openTickets {
+			if v.GuildID == activeGuild || len(openTickets) == 1 {
+				relayToTicket(ctx, v, msg.Author, pending)
+				return
+			}
+		}
+
+		guildIDs := make([]int64, 0, len(openTickets))
+		for _, v := range openTickets {
+			guildIDs = append(guildIDs, v.GuildID)
+		}
+
+		sendModmailGuildPicker(msg.Author, guildIDs, pending, "You have open conversations with the staff of multiple servers, which one should this message go to?")
+		return
+	}
+
+	if modmailLookupRatelimited(msg.Author.ID) {
+		return
+	}
+
+	guildIDs, err := modmailGuildsForUser(ctx, msg.Author.ID)
+	if err != nil {
+		logger.WithError(err).WithField("user", msg.Author.ID).Error("failed retrieving modmail servers")
+		return
+	}
+
+	switch len(guildIDs) {
+	case 0:
+		return
+	case 1:
+		openModmailTicket(ctx, guildIDs[0], msg.Author, pending)
+	default:
+		sendModmailGuildPicker(msg.Author, guildIDs, pending, "Which server's staff do you want to contact?")
+	}
+}
+
+// modmailGuildsForUser returns the servers with modmail enabled that the user is a member of.
+// Servers on this process are checked in the state first, the rest are looked up through the bot rest api of the
+// process they're on (which fetches the member if needed). As any user can trigger this by sending a dm those lookups
+// are limited to modmailMaxMemberFetches servers, so users in a lot of modmail servers might not see all of them.
+func modmailGuildsForUser(ctx context.Context, userID int64) ([]int64, error) {
+	configs, err := models.TicketConfigs(qm.Where("enabled = true AND modmail_enabled = true"), qm.Select("guild_id")).AllG(ctx)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	result := make([]int64, 0)
+	var notInState []int64
+	for _, v := range configs {
+		if ms := bot.State.GetMember(v.GuildID, userID); ms != nil && ms.Member != nil {
+			result = append(result, v.GuildID)
+			if len(result) >= modmailMaxGuildChoices {
+				return result, nil
+			}
+		} else {
+			notInState = append(notInState, v.GuildID)
+		}
+	}
+
+	for i, guildID := range notInState {
+		if i >= modmailMaxMemberFetches || len(result) >= modmailMaxGuildChoices {
+			break
+		}
+
+		members, err := botrest.GetMembers(guildID, userID)
+		if err != nil || len(members) < 1 || members[0] == nil || members[0].User == nil {
+			continue
+		}
+
+		result = append(result, guildID)
+	}
+
+	return result, nil
+}
+
+// modmailLookupRatelimited returns true if the user recently had their servers looked up
+func modmailLookupRatelimited(userID int64) bool {
+	var resp string
+	err := common.RedisPool.Do(radix.Cmd(&resp, "SET", keyModmailLookupCooldown(userID), "1", "EX", strconv.Itoa(modmailLookupCooldownSeconds), "NX"))
+	if err != nil {
+		logger.WithError(err).WithField("user", userID).Error("failed setting modmail lookup cooldown")
+		return true
+	}
+
+	return resp != "OK"
+}
+
+func sendModmailGuildPicker(user *discordgo.User, guildIDs []int64, pending *modmailMessage, content string) {
+	encoded, err := json.Marshal(pending)
+	if err != nil {
+		logger.WithError(err).Error("failed encoding pending modmail message")
+		return
+	}
+
+	err = common.RedisPool.Do(radix.Cmd(nil, "SET", keyModmailPending(user.ID), string(encoded), "EX", "600"))
+	if err != nil {
+		logger.WithError(err).WithField("user", user.ID).Error("failed storing pending modmail message")
+		return
+	}
+
+	options := make([]discordgo.SelectMenuOption, 0, len(guildIDs))
+	for _, v := range guildIDs {
+		name := strconv.FormatInt(v, 10)
+		if gs, err := botrest.GetGuild(v); err == nil {
+			name = gs.Name
+		}
+
+		options = append(options, discordgo.SelectMenuOption{
+			Label: name,
+			Value: strconv.FormatInt(v, 10),
+		})
+	}
+
+	channel, err := common.BotSession.UserChannelCreate(user.ID)
+	if err != nil {
+		logger.WithError(err).WithField("user", user.ID).Error("failed creating modmail dm channel")
+		return
+	}
+
+	_, err = common.BotSession.ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
+		Content: content,
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.SelectMenu{
+						CustomID:    ModmailPickGuild,
+						Placeholder: "Select a server",
+						Options:     options,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		logger.WithError(err).WithField("user", user.ID).Error("failed sending modmail server picker")
+	}
+}
+
+// handleModmailPickGuild is called on the process the picked server is on
+func handleModmailPickGuild(ic *discordgo.InteractionCreate) {
+	data := ic.MessageComponentData()
+	if len(data.Values) < 1 || ic.User == nil {
+		return
+	}
+
+	guildID, _ := strconv.ParseInt(data.Values[0], 10, 64)
+	if !bot.ReadyTracker.IsGuildOnProcess(guildID) {
+		return
+	}
+
+	lang := i18n.InteractionLanguage(&ic.Interaction)
+	content := i18n.Translate(lang, "tickets.modmail_sending")
+	gs := bot.State.GetGuild(guildID)
+	if gs != nil {
+		content = i18n.Translate(lang, "tickets.modmail_sending_to", gs.Name)
+	}
+
+	err := common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed responding to modmail server pick")
+	}
+
+	var encoded string
+	err = common.RedisPool.Do(radix.Cmd(&encoded, "GET", keyModmailPending(ic.User.ID)))
+	if err != nil {
+		logger.WithError(err).WithField("user", ic.User.ID).Error("failed retrieving pending modmail message")
+		return
+	}
+	common.RedisPool.Do(radix.Cmd(nil, "DEL", keyModmailPending(ic.User.ID)))
+
+	if encoded == "" {
+		bot.SendDM(ic.User.ID, i18n.Translate(lang, "tickets.modmail_expired"))
+		return
+	}
+
+	var pending modmailMessage
+	err = json.Unmarshal([]byte(encoded), &pending)
+	if err != nil {
+		logger.WithError(err).Error("failed decoding pending modmail message")
+		return
+	}
+
+	ctx := context.Background()
+	ticket, err := models.Tickets(qm.Where("guild_id = ? AND author_id = ? AND modmail = true AND closed_at IS NULL", guildID, ic.User.ID)).OneG(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		logger.WithError(err).WithField("guild", guildID).Error("failed retrieving modmail ticket")
+		return
+	}
+
+	if ticket != nil {
+		setModmailActiveGuild(ic.User.ID, guildID)
+		relayToTicket(ctx, ticket, ic.User, &pending)
+		return
+	}
+
+	openModmailTicket(ctx, guildID, ic.User, &pending)
+}
+
+func setModmailActiveGuild(userID, guildID int64) {
+	err := common.RedisPool.Do(radix.FlatCmd(nil, "SET", keyModmailActiveGuild(userID), guildID, "EX", 60*60*24*7))
+	if err != nil {
+		logger.WithError(err).WithField("user", userID).Error("failed setting active modmail server")
+	}
+}
+
+func openModmailTicket(ctx context.Context, guildID int64, user *discordgo.User, pending *modmailMessage) {
+	conf, err := models.FindTicketConfigG(ctx, guildID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.WithError(err).WithField("guild", guildID).Error("failed retrieving ticket config")
+		return
+	}
+
+	lang := i18n.GuildLanguage(guildID)
+	if conf == nil || !conf.Enabled || !conf.ModmailEnabled {
+		bot.SendDM(user.ID, i18n.Translate(lang, "tickets.modmail_disabled"))
+		return
+	}
+
+	gs, err := botrest.GetGuild(guildID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed retrieving guild for modmail")
+		return
+	}
+
+	ms, err := bot.GetMember(guildID, user.ID)
+	if err != nil {
+		bot.SendDM(user.ID, i18n.Translate(lang, "tickets.modmail_not_member"))
+		return
+	}
+
+	_, ticket, err := CreateModmailTicket(ctx, gs, ms, conf, "Modmail from "+user.Username, "Opened from DMs, replies in this channel are sent to the user.")
+	if err != nil {
+		if userErr, ok := err.(TicketUserError); ok {
+			bot.SendDM(user.ID, i18n.Translate(lang, "tickets.modmail_user_error", userErr.Translate(lang)))
+			return
+		}
+
+		logger.WithError(err).WithField("guild", guildID).Error("failed creating modmail ticket")
+		bot.SendDM(user.ID, i18n.Translate(lang, "tickets.modmail_failed"))
+		return
+	}
+
+	setModmailActiveGuild(user.ID, guildID)
+	if !relayToTicket(ctx, ticket, user, pending) {
+		return
+	}
+
+	bot.SendDM(user.ID, i18n.Translate(lang, "tickets.modmail_sent", gs.Name))
+}
+
+// relayToTicket posts the user's message in the ticket channel, returns false if it failed
+func relayToTicket(ctx context.Context, ticket *models.Ticket, user *discordgo.User, msg *modmailMessage) bool {
+	files, links := downloadModmailAttachments(msg.Attachments)
+
+	description := msg.Content
+	if len(links) > 0 {
+		description += "\n\n" + strings.Join(links, "\n")
+	}
+
+	_, err := common.BotSession.ChannelMessageSendComplex(ticket.ChannelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{{
+			Author: &discordgo.MessageEmbedAuthor{
+				Name:    user.String(),
+				IconURL: user.AvatarURL("64"),
+			},
+			Description: common.CutStringShort(description, 4000),
+			Color:       0x42b9f4,
+			Footer:      &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("User ID: %d", user.ID)},
+			Timestamp:   time.Now().Format(time.RFC3339),
+		}},
+		Files:           files,
+		AllowedMentions: discordgo.AllowedMentions{},
+	})
+
+	if err != nil {
+		lang := i18n.GuildLanguage(ticket.GuildID)
+		if common.IsDiscordErr(err, discordgo.ErrCodeUnknownChannel) {
+			// the channel was deleted without closing the ticket
+			ticket.ClosedAt.Time = time.Now()
+			ticket.ClosedAt.Valid = true
+			ticket.UpdateG(ctx, boil.Whitelist("closed_at"))
+			pubsub.EvictCacheSet(cachedOpenTickets, ticket.GuildID)
+
+			bot.SendDM(user.ID, i18n.Translate(lang, "tickets.modmail_previous_closed"))
+			return false
+		}
+
+		logger.WithError(err).WithField("guild", ticket.GuildID).Error("failed relaying modmail message to ticket")
+		bot.SendDM(user.ID, i18n.Translate(lang, "tickets.modmail_relay_failed"))
+		return false
+	}
+
+	if msg.MessageID != 0 {
+		common.BotSession.MessageReactionAdd(msg.ChannelID, msg.MessageID, "✅")
+	}
+
+	// relayed messages are sent by the bot, so they're not picked up as activity by the message handler
+	touchTicketActivity(ctx, ticket.GuildID, ticket.LocalID)
+
+	return true
+}
+
+// handleModmailStaffMessage relays a message sent in a modmail ticket channel to the user
+func handleModmailStaffMessage(evt *eventsystem.EventData, msg *discordgo.Message, localID int64) {
+	prefix, _ := commands.GetCommandPrefixBotEvt(evt)
+	if (prefix != "" && strings.HasPrefix(msg.Content, prefix)) || strings.HasPrefix(msg.Content, common.BotUser.Mention()) {
+		// commands are not relayed, notes and anonymous replies are done through them
+		return
+	}
+
+	ticket, err := models.FindTicketG(evt.Context(), msg.GuildID, localID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			logger.WithError(err).WithField("guild", msg.GuildID).Error("failed retrieving modmail ticket")
+		}
+		return
+	}
+
+	err = relayToUser(evt.GS, ticket, msg.Author, false, msg.Content, msg.Attachments)
+	if err != nil {
+		common.BotSession.ChannelMessageSend(msg.ChannelID, i18n.Translate(i18n.GuildLanguage(msg.GuildID), "tickets.relay_failed"))
+		return
+	}
+
+	common.BotSession.MessageReactionAdd(msg.ChannelID, msg.ID, "✅")
+}
+
+// relayToUser sends a staff reply to the DMs of the ticket author, anonymous replies hide who sent it
+func relayToUser(gs *dstate.GuildSet, ticket *models.Ticket, staff *discordgo.User, anonymous bool, content string, attachments []*discordgo.MessageAttachment) error {
+	files, links := downloadModmailAttachments(attachments)
+	if len(links) > 0 {
+		content += "\n\n" + strings.Join(links, "\n")
+	}
+
+	lang := i18n.GuildLanguage(gs.ID)
+	author := &discordgo.MessageEmbedAuthor{
+		Name:    staff.String(),
+		IconURL: staff.AvatarURL("64"),
+	}
+
+	if anonymous {
+		author = &discordgo.MessageEmbedAuthor{
+			Name:    i18n.Translate(lang, "tickets.staff"),
+			IconURL: discordgo.EndpointGuildIcon(gs.ID, gs.Icon),
+		}
+	}
+
+	channel, err := common.BotSession.UserChannelCreate(ticket.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	_, err = common.BotSession.ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{{
+			Author:      author,
+			Description: common.CutStringShort(content, 4000),
+			Color:       0x42b9f4,
+			Footer:      &discordgo.MessageEmbedFooter{Text: i18n.Translate(lang, "tickets.modmail_reply_footer", gs.Name)},
+			Timestamp:   time.Now().Format(time.RFC3339),
+		}},
+		Files:           files,
+		AllowedMentions: discordgo.AllowedMentions{},
+	})
+
+	return err
+}
+
+var modmailDownloadClient = &http.Client{
+	Timeout: modmailDownloadTimeout,
+}
+
+// downloadModmailAttachments downloads the attachments to be re-uploaded, returning links to the ones that are too big
+func downloadModmailAttachments(attachments []*discordgo.MessageAttachment) (files []*discordgo.File, links []string) {
+	for _, v := range attachments {
+		if v.Size > modmailMaxAttachmentSize {
+			links = append(links, v.URL)
+			continue
+		}
+
+		resp, err := modmailDownloadClient.Get(v.URL)
+		if err != nil {
+			links = append(links, v.URL)
+			continue
+		}
+
+		// read one more byte than the attachment size so a bigger response can be detected
+		data, err := io.ReadAll(io.LimitReader(resp.Body, int64(v.Size)+1))
+		resp.Body.Close()
+		if err != nil || resp.StatusCode < 200 || resp.StatusCode > 299 || len(data) > v.Size {
+			links = append(links, v.URL)
+			continue
+		}
+
+		files = append(files, &discordgo.File{
+			Name:   v.Filename,
+			Reader: bytes.NewReader(data),
+		})
+	}
+
+	return files, links
+}
@@ -0,0 +1,386 @@
+package tickets
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cirelion/flint/bot"
+	"github.com/cirelion/flint/bot/botrest"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/i18n"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/tickets/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	TicketPanelButton = "tickets_panel_type:"
+	TicketPanelSelect = "tickets_panel_select"
+	TicketTypeModal   = "tickets_type_modal:"
+
+	MaxTicketTypes        = 25
+	MaxTicketPanels       = 10
+	MaxTicketTypeQuestion = 5
+)
+
+// configForTicketType returns a copy of the config with the category, staff roles and transcripts channel
+// replaced by the ones set on the ticket type
+func configForTicketType(conf *models.TicketConfig, tt *models.TicketType) *models.TicketConfig {
+	if tt == nil {
+		return conf
+	}
+
+	cop := *conf
+	if tt.ChannelCategory != 0 {
+		cop.TicketsChannelCategory = tt.ChannelCategory
+	}
+
+	if len(tt.StaffRoles) > 0 {
+		cop.ModRoles = tt.StaffRoles
+	}
+
+	if tt.TranscriptsChannel != 0 {
+		cop.TicketsTranscriptsChannel = tt.TranscriptsChannel
+	}
+
+	return &cop
+}
+
+// findTicketType returns the type of the ticket, or nil if it has none or the type was deleted
+func findTicketType(ctx context.Context, ticket *models.Ticket) (*models.TicketType, error) {
+	if !ticket.TicketTypeID.Valid {
+		return nil, nil
+	}
+
+	tt, err := models.TicketTypes(
+		models.TicketTypeWhere.ID.EQ(ticket.TicketTypeID.Int64),
+		models.TicketTypeWhere.GuildID.EQ(ticket.GuildID)).OneG(ctx)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return tt, err
+}
+
+// typeQuestions returns the questions asked in the modal when opening a ticket of this type
+func typeQuestions(tt *models.TicketType) []string {
+	questions := make([]string, 0, len(tt.Questions))
+	for _, q := range tt.Questions {
+		if strings.TrimSpace(q) != "" {
+			questions = append(questions, q)
+		}
+	}
+
+	if len(questions) > MaxTicketTypeQuestion {
+		questions = questions[:MaxTicketTypeQuestion]
+	}
+
+	return questions
+}
+
+var customEmojiRegex = regexp.MustCompile(`^<(a?):([\w~]{1,32}):(\d{15,20})>$`)
+
+// parseComponentEmoji parses a unicode emoji or a custom emoji in the <:name:id> or <a:name:id> format
+func parseComponentEmoji(s string) discordgo.ComponentEmoji {
+	s = strings.TrimSpace(s)
+
+	m := customEmojiRegex.FindStringSubmatch(s)
+	if m == nil {
+		return discordgo.ComponentEmoji{Name: s}
+	}
+
+	id, _ := strconv.ParseInt(m[3], 10, 64)
+	return discordgo.ComponentEmoji{
+		Name:     m[2],
+		ID:       id,
+		Animated: m[1] == "a",
+	}
+}
+
+// PanelMessage builds the message posted for a panel, with a button or select menu option per ticket type
+func PanelMessage(panel *models.TicketPanel, types []*models.TicketType) *discordgo.MessageSend {
+	embed := &discordgo.MessageEmbed{
+		Title:       panel.Title,
+		Description: panel.Description,
+		Color:       0x42b9f4,
+	}
+
+	msg := &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{embed},
+	}
+
+	byID := make(map[int64]*models.TicketType)
+	for _, v := range types {
+		byID[v.ID] = v
+	}
+
+	ordered := make([]*models.TicketType, 0, len(panel.TicketTypes))
+	for _, id := range panel.TicketTypes {
+		if tt, ok := byID[id]; ok {
+			ordered = append(ordered, tt)
+		}
+	}
+
+	if len(ordered) < 1 {
+		return msg
+	}
+
+	if panel.UseSelectMenu {
+		options := make([]discordgo.SelectMenuOption, 0, len(ordered))
+		for _, tt := range ordered {
+			opt := discordgo.SelectMenuOption{
+				Label:       common.CutStringShort(tt.Name, 100),
+				Value:       strconv.FormatInt(tt.ID, 10),
+				Description: common.CutStringShort(tt.Description, 100),
+			}
+			if tt.Emoji != "" {
+				opt.Emoji = parseComponentEmoji(tt.Emoji)
+			}
+			options = append(options, opt)
+		}
+
+		msg.Components = []discordgo.MessageComponent{
+			discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    TicketPanelSelect,
+					Placeholder: "Select the kind of ticket to open",
+					Options:     options,
+				},
+			}},
+		}
+		return msg
+	}
+
+	// discord allows 5 buttons per row and 5 rows per message
+	var row discordgo.ActionsRow
+	for i, tt := range ordered {
+		if i >= 25 {
+			break
+		}
+
+		button := discordgo.Button{
+			Label:    common.CutStringShort(tt.Name, 80),
+			Style:    discordgo.PrimaryButton,
+			CustomID: TicketPanelButton + strconv.FormatInt(tt.ID, 10),
+		}
+		if tt.Emoji != "" {
+			button.Emoji = parseComponentEmoji(tt.Emoji)
+		}
+
+		row.Components = append(row.Components, button)
+		if len(row.Components) == 5 {
+			msg.Components = append(msg.Components, row)
+			row = discordgo.ActionsRow{}
+		}
+	}
+
+	if len(row.Components) > 0 {
+		msg.Components = append(msg.Components, row)
+	}
+
+	return msg
+}
+
+// handlePanelInteraction opens the modal for the ticket type picked on a panel
+func handlePanelInteraction(ctx context.Context, ic *discordgo.InteractionCreate, session *discordgo.Session, customID string) {
+	var rawID string
+	if customID == TicketPanelSelect {
+		values := ic.MessageComponentData().Values
+		if len(values) < 1 {
+			return
+		}
+		rawID = values[0]
+	} else {
+		rawID = strings.TrimPrefix(customID, TicketPanelButton)
+	}
+
+	typeID, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return
+	}
+
+	tt, err := models.TicketTypes(
+		models.TicketTypeWhere.ID.EQ(typeID),
+		models.TicketTypeWhere.GuildID.EQ(ic.GuildID)).OneG(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondEphemeral(ic, i18n.Translate(i18n.InteractionLanguage(&ic.Interaction), "tickets.type_not_found"))
+			return
+		}
+
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed retrieving ticket type")
+		return
+	}
+
+	startTicketTypeModal(ic, session, tt)
+}
+
+func startTicketTypeModal(ic *discordgo.InteractionCreate, session *discordgo.Session, tt *models.TicketType) {
+	questions := typeQuestions(tt)
+	if len(questions) < 1 {
+		questions = []string{"What is the subject of your question/request?", "What is your question/request?"}
+	}
+
+	components := make([]discordgo.MessageComponent, 0, len(questions))
+	for i, q := range questions {
+		components = append(components, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.TextInput{
+					CustomID: "q" + strconv.Itoa(i),
+					Label:    common.CutStringShort(q, 45),
+					Style:    discordgo.TextInputParagraph,
+					Required: true,
+				},
+			},
+		})
+	}
+
+	err := session.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID:   TicketTypeModal + strconv.FormatInt(tt.ID, 10),
+			Title:      common.CutStringShort(tt.Name, 45),
+			Components: components,
+		},
+	})
+	if err != nil {
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed sending ticket type modal")
+	}
+}
+
+// handleTicketTypeModalSubmit creates a ticket of the type the modal was opened for
+func handleTicketTypeModalSubmit(ctx context.Context, ic *discordgo.InteractionCreate) {
+	typeID, err := strconv.ParseInt(strings.TrimPrefix(ic.DataModal.CustomID, TicketTypeModal), 10, 64)
+	if err != nil {
+		return
+	}
+
+	tt, err := models.TicketTypes(
+		models.TicketTypeWhere.ID.EQ(typeID),
+		models.TicketTypeWhere.GuildID.EQ(ic.GuildID)).OneG(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondEphemeral(ic, i18n.Translate(i18n.InteractionLanguage(&ic.Interaction), "tickets.type_not_found"))
+			return
+		}
+
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed retrieving ticket type")
+		return
+	}
+
+	questions := typeQuestions(tt)
+	answers := make([]string, 0, len(ic.DataModal.Components))
+	for _, c := range ic.DataModal.Components {
+		row, ok := c.(*discordgo.ActionsRow)
+		if !ok || len(row.Components) < 1 {
+			continue
+		}
+
+		if input, ok := row.Components[0].(*discordgo.TextInput); ok {
+			answers = append(answers, input.Value)
+		}
+	}
+
+	if len(answers) < 1 {
+		return
+	}
+
+	config, err := models.FindTicketConfigG(ctx, ic.GuildID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed retrieving ticket config")
+		return
+	}
+
+	ms, err := bot.GetMember(ic.GuildID, ic.Member.User.ID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed retrieving member")
+		return
+	}
+
+	guild, err := botrest.GetGuild(ic.GuildID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed retrieving guild")
+		return
+	}
+
+	topic := tt.Name
+	var question string
+	if len(questions) < 1 {
+		// no questions set up, the default subject and question were asked
+		topic = tt.Name + ": " + answers[0]
+		if len(answers) > 1 {
+			question = answers[1]
+		}
+	} else {
+		var b strings.Builder
+		for i, a := range answers {
+			if i < len(questions) {
+				b.WriteString("**" + questions[i] + "**\n")
+			}
+			b.WriteString(a + "\n\n")
+		}
+		question = strings.TrimSpace(b.String())
+	}
+
+	_, ticket, err := CreateTicketOfType(ctx, guild, ms, config, tt, common.CutStringShort(topic, 100), question, answers)
+	if err != nil {
+		if userErr, ok := err.(TicketUserError); ok {
+			respondEphemeral(ic, userErr.Translate(i18n.InteractionLanguage(&ic.Interaction)))
+			return
+		}
+
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed creating ticket")
+		return
+	}
+
+	respondEphemeral(ic, i18n.Translate(i18n.InteractionLanguage(&ic.Interaction), "tickets.opened", ticket.GuildID, ticket.ChannelID))
+}
+
+func respondEphemeral(ic *discordgo.InteractionCreate, content string) {
+	err := common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Content: content, Flags: 64},
+	})
+	if err != nil {
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed responding to ticket interaction")
+	}
+}
+
+// PostPanel sends the panel message, or edits it if it was already posted in the same channel
+func PostPanel(ctx context.Context, panel *models.TicketPanel) error {
+	types, err := models.TicketTypes(models.TicketTypeWhere.GuildID.EQ(panel.GuildID), qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		return err
+	}
+
+	msg := PanelMessage(panel, types)
+
+	if panel.MessageID != 0 {
+		_, err = common.BotSession.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:         panel.MessageID,
+			Channel:    panel.ChannelID,
+			Embeds:     msg.Embeds,
+			Components: msg.Components,
+		})
+		if err == nil {
+			return nil
+		}
+
+		if !common.IsDiscordErr(err, discordgo.ErrCodeUnknownMessage, discordgo.ErrCodeUnknownChannel) {
+			return err
+		}
+	}
+
+	m, err := common.BotSession.ChannelMessageSendComplex(panel.ChannelID, msg)
+	if err != nil {
+		return err
+	}
+
+	panel.MessageID = m.ID
+	_, err = panel.UpdateG(ctx, boil.Whitelist(models.TicketPanelColumns.MessageID))
+	return err
+}
@@ -0,0 +1,206 @@
+package tickets
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cirelion/flint/bot"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate"
+	"github.com/cirelion/flint/tickets/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// ReportPeriods are the periods in days that can be picked for the staff report
+var ReportPeriods = []int{7, 30, 90, 365}
+
+// StaffStats is the performance of a staff member in the tickets closed during the report period
+type StaffStats struct {
+	UserID   int64
+	Username string
+
+	// tickets they sent a message in
+	Tickets int
+
+	// tickets where they were the first staff member to respond
+	FirstResponses      int
+	MedianFirstResponse time.Duration
+	MedianResolution    time.Duration
+
+	Ratings       int
+	AverageRating float64
+}
+
+func (s *StaffStats) FirstResponseString() string {
+	if s.FirstResponses < 1 {
+		return "-"
+	}
+
+	return common.HumanizeDuration(common.DurationPrecisionMinutes, s.MedianFirstResponse)
+}
+
+func (s *StaffStats) ResolutionString() string {
+	return common.HumanizeDuration(common.DurationPrecisionMinutes, s.MedianResolution)
+}
+
+// trackTicketParticipant records a message sent in the ticket for the staff report.
+// Whether the participants are staff is decided when the ticket is closed.
+func trackTicketParticipant(ctx context.Context, guildID, localID int64, author *discordgo.User) {
+	const q = `INSERT INTO ticket_participants (ticket_guild_id, ticket_local_id, user_id, username, discrim, is_staff, first_message_at, message_count)
+VALUES ($1, $2, $3, $4, $5, false, now(), 1)
+ON CONFLICT (ticket_guild_id, ticket_local_id, user_id) DO UPDATE SET
+	message_count = ticket_participants.message_count + 1,
+	first_message_at = COALESCE(ticket_participants.first_message_at, EXCLUDED.first_message_at)`
+
+	_, err := common.PQ.ExecContext(ctx, q, guildID, localID, author.ID, author.Username, author.Discriminator)
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed tracking ticket participant")
+	}
+}
+
+// markStaffParticipants sets which of the participants of the ticket are staff
+func markStaffParticipants(ctx context.Context, gs *dstate.GuildSet, conf *models.TicketConfig, ticket *models.Ticket) {
+	participants, err := models.TicketParticipants(
+		models.TicketParticipantWhere.TicketGuildID.EQ(ticket.GuildID),
+		models.TicketParticipantWhere.TicketLocalID.EQ(ticket.LocalID),
+		models.TicketParticipantWhere.UserID.NEQ(ticket.AuthorID)).AllG(ctx)
+	if err != nil {
+		logger.WithError(err).WithField("guild", gs.ID).Error("failed retrieving ticket participants")
+		return
+	}
+
+	for _, v := range participants {
+		ms, err := bot.GetMember(gs.ID, v.UserID)
+		if err != nil {
+			continue
+		}
+
+		isStaff, _ := isTicketStaff(gs, conf, ticket.ChannelID, ms)
+		if isStaff == v.IsStaff {
+			continue
+		}
+
+		v.IsStaff = isStaff
+		_, err = v.UpdateG(ctx, boil.Whitelist("is_staff"))
+		if err != nil {
+			logger.WithError(err).WithField("guild", gs.ID).Error("failed updating ticket participant")
+		}
+	}
+}
+
+// StaffReport builds the staff report for the tickets closed since the provided time
+func StaffReport(ctx context.Context, guildID int64, since time.Time) ([]*StaffStats, error) {
+	tickets, err := models.Tickets(
+		models.TicketWhere.GuildID.EQ(guildID),
+		models.TicketWhere.ClosedAt.GTE(null.TimeFrom(since))).AllG(ctx)
+	if err != nil || len(tickets) < 1 {
+		return nil, err
+	}
+
+	localIDs := make([]interface{}, len(tickets))
+	for i, v := range tickets {
+		localIDs[i] = v.LocalID
+	}
+
+	participants, err := models.TicketParticipants(
+		models.TicketParticipantWhere.TicketGuildID.EQ(guildID),
+		models.TicketParticipantWhere.IsStaff.EQ(true),
+		qm.WhereIn("ticket_local_id IN ?", localIDs...)).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildStaffReport(tickets, participants), nil
+}
+
+func buildStaffReport(tickets []*models.Ticket, participants []*models.TicketParticipant) []*StaffStats {
+	byLocalID := make(map[int64]*models.Ticket)
+	for _, v := range tickets {
+		byLocalID[v.LocalID] = v
+	}
+
+	// the first staff member to respond in each ticket
+	firstResponders := make(map[int64]*models.TicketParticipant)
+	for _, p := range participants {
+		if !p.FirstMessageAt.Valid {
+			continue
+		}
+
+		if cur, ok := firstResponders[p.TicketLocalID]; !ok || p.FirstMessageAt.Time.Before(cur.FirstMessageAt.Time) {
+			firstResponders[p.TicketLocalID] = p
+		}
+	}
+
+	stats := make(map[int64]*StaffStats)
+	firstResponseTimes := make(map[int64][]time.Duration)
+	resolutionTimes := make(map[int64][]time.Duration)
+	ratingSums := make(map[int64]int)
+
+	for _, p := range participants {
+		ticket, ok := byLocalID[p.TicketLocalID]
+		if !ok || !ticket.ClosedAt.Valid {
+			continue
+		}
+
+		s, ok := stats[p.UserID]
+		if !ok {
+			s = &StaffStats{UserID: p.UserID, Username: p.Username}
+			stats[p.UserID] = s
+		}
+
+		s.Tickets++
+		resolutionTimes[p.UserID] = append(resolutionTimes[p.UserID], ticket.ClosedAt.Time.Sub(ticket.CreatedAt))
+
+		if ticket.Rating.Valid {
+			s.Ratings++
+			ratingSums[p.UserID] += ticket.Rating.Int
+		}
+
+		if first := firstResponders[p.TicketLocalID]; first == p {
+			s.FirstResponses++
+			firstResponseTimes[p.UserID] = append(firstResponseTimes[p.UserID], p.FirstMessageAt.Time.Sub(ticket.CreatedAt))
+		}
+	}
+
+	result := make([]*StaffStats, 0, len(stats))
+	for userID, s := range stats {
+		s.MedianFirstResponse = medianDuration(firstResponseTimes[userID])
+		s.MedianResolution = medianDuration(resolutionTimes[userID])
+		if s.Ratings > 0 {
+			s.AverageRating = float64(ratingSums[userID]) / float64(s.Ratings)
+		}
+
+		result = append(result, s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Tickets != result[j].Tickets {
+			return result[i].Tickets > result[j].Tickets
+		}
+
+		return result[i].UserID < result[j].UserID
+	})
+
+	return result
+}
+
+func medianDuration(durations []time.Duration) time.Duration {
+	if len(durations) < 1 {
+		return 0
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	return sorted[mid]
+}
@@ -69,4 +69,68 @@ CREATE TABLE IF NOT EXISTS ticket_participants (
 `, `
 
 CREATE INDEX IF NOT EXISTS ticket_participants_ticket_local_id_idx ON ticket_participants(ticket_guild_id, ticket_local_id);
+`, `
+ALTER TABLE ticket_configs ADD COLUMN IF NOT EXISTS modmail_enabled BOOLEAN NOT NULL DEFAULT false;
+`, `
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS modmail BOOLEAN NOT NULL DEFAULT false;
+`, `
+CREATE INDEX IF NOT EXISTS tickets_modmail_author_id_idx ON tickets(author_id) WHERE modmail AND closed_at IS NULL;
+`, `
+CREATE TABLE IF NOT EXISTS ticket_types (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+
+	name TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	emoji TEXT NOT NULL DEFAULT '',
+
+	channel_category BIGINT NOT NULL DEFAULT 0,
+	transcripts_channel BIGINT NOT NULL DEFAULT 0,
+	staff_roles BIGINT[],
+
+	questions TEXT[],
+	open_msg TEXT NOT NULL DEFAULT ''
+);
+`, `
+CREATE INDEX IF NOT EXISTS ticket_types_guild_id_idx ON ticket_types(guild_id);
+`, `
+CREATE TABLE IF NOT EXISTS ticket_panels (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+
+	channel_id BIGINT NOT NULL,
+	message_id BIGINT NOT NULL DEFAULT 0,
+
+	title TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	use_select_menu BOOLEAN NOT NULL DEFAULT false,
+
+	ticket_types BIGINT[]
+);
+`, `
+CREATE INDEX IF NOT EXISTS ticket_panels_guild_id_idx ON ticket_panels(guild_id);
+`, `
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS ticket_type_id BIGINT;
+`, `
+ALTER TABLE ticket_configs ADD COLUMN IF NOT EXISTS inactivity_reminder_hours INT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE ticket_configs ADD COLUMN IF NOT EXISTS inactivity_close_hours INT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS last_activity_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();
+`, `
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS inactivity_reminded BOOLEAN NOT NULL DEFAULT false;
+`, `
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS pinned BOOLEAN NOT NULL DEFAULT false;
+`, `
+ALTER TABLE ticket_configs ADD COLUMN IF NOT EXISTS satisfaction_survey BOOLEAN NOT NULL DEFAULT false;
+`, `
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS rating INT;
+`, `
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS rating_comment TEXT NOT NULL DEFAULT '';
+`, `
+ALTER TABLE ticket_participants ADD COLUMN IF NOT EXISTS first_message_at TIMESTAMP WITH TIME ZONE;
+`, `
+ALTER TABLE ticket_participants ADD COLUMN IF NOT EXISTS message_count INT NOT NULL DEFAULT 0;
+`, `
+CREATE INDEX IF NOT EXISTS tickets_guild_id_closed_at_idx ON tickets(guild_id, closed_at);
 `}
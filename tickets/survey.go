@@ -0,0 +1,208 @@
+package tickets
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/cirelion/flint/bot"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/i18n"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate"
+	"github.com/cirelion/flint/tickets/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const (
+	SurveyRating        = "tickets_rate:"
+	SurveyCommentButton = "tickets_rate_comment:"
+	SurveyCommentModal  = "tickets_rate_modal:"
+
+	MaxRating = 5
+)
+
+// surveyCustomID encodes the ticket, and optionally the rating, into a component custom id
+func surveyCustomID(prefix string, guildID, localID int64, rating int) string {
+	id := prefix + strconv.FormatInt(guildID, 10) + ":" + strconv.FormatInt(localID, 10)
+	if rating > 0 {
+		id += ":" + strconv.Itoa(rating)
+	}
+
+	return id
+}
+
+// parseSurveyCustomID is the reverse of surveyCustomID, rating is 0 if not present
+func parseSurveyCustomID(prefix string, customID string) (guildID, localID int64, rating int, ok bool) {
+	split := strings.Split(strings.TrimPrefix(customID, prefix), ":")
+	if len(split) < 2 || len(split) > 3 {
+		return 0, 0, 0, false
+	}
+
+	var err error
+	if guildID, err = strconv.ParseInt(split[0], 10, 64); err != nil {
+		return 0, 0, 0, false
+	}
+
+	if localID, err = strconv.ParseInt(split[1], 10, 64); err != nil {
+		return 0, 0, 0, false
+	}
+
+	if len(split) == 3 {
+		rating, err = strconv.Atoi(split[2])
+		if err != nil || rating < 1 || rating > MaxRating {
+			return 0, 0, 0, false
+		}
+	}
+
+	return guildID, localID, rating, true
+}
+
+// sendSatisfactionSurvey asks the ticket author to rate the support they received
+func sendSatisfactionSurvey(gs *dstate.GuildSet, ticket *models.Ticket) {
+	buttons := make([]discordgo.MessageComponent, 0, MaxRating)
+	for i := 1; i <= MaxRating; i++ {
+		buttons = append(buttons, discordgo.Button{
+			Label:    strings.Repeat("⭐", i),
+			Style:    discordgo.SecondaryButton,
+			CustomID: surveyCustomID(SurveyRating, ticket.GuildID, ticket.LocalID, i),
+		})
+	}
+
+	channel, err := common.BotSession.UserChannelCreate(ticket.AuthorID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", gs.ID).Error("failed creating dm channel for ticket survey")
+		return
+	}
+
+	_, err = common.BotSession.ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
+		Content:    i18n.Translate(i18n.GuildLanguage(gs.ID), "tickets.survey", ticket.LocalID, ticket.Title, gs.Name),
+		Components: []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}},
+	})
+	if err != nil && !common.IsDiscordErr(err, discordgo.ErrCodeCannotSendMessagesToThisUser) {
+		logger.WithError(err).WithField("guild", gs.ID).Error("failed sending ticket survey")
+	}
+}
+
+// findSurveyTicket returns the closed ticket the survey was for, if the interaction is from its author
+func findSurveyTicket(ctx context.Context, ic *discordgo.InteractionCreate, guildID, localID int64) *models.Ticket {
+	ticket, err := models.FindTicketG(ctx, guildID, localID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed retrieving ticket for survey")
+		return nil
+	}
+
+	if ticket.AuthorID != ic.User.ID || !ticket.ClosedAt.Valid {
+		return nil
+	}
+
+	return ticket
+}
+
+func handleSurveyRating(ic *discordgo.InteractionCreate) {
+	guildID, localID, rating, ok := parseSurveyCustomID(SurveyRating, ic.MessageComponentData().CustomID)
+	if !ok || rating == 0 || !bot.ReadyTracker.IsGuildOnProcess(guildID) {
+		return
+	}
+
+	ctx := context.Background()
+	ticket := findSurveyTicket(ctx, ic, guildID, localID)
+	if ticket == nil {
+		return
+	}
+
+	ticket.Rating = null.IntFrom(rating)
+	_, err := ticket.UpdateG(ctx, boil.Whitelist(models.TicketColumns.Rating))
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed saving ticket rating")
+		return
+	}
+
+	lang := i18n.InteractionLanguage(&ic.Interaction)
+	err = common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.Translate(lang, "tickets.survey_rated", ticket.LocalID, ticket.Title, rating, MaxRating),
+			Components: []discordgo.MessageComponent{discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    i18n.Translate(lang, "tickets.survey_add_comment"),
+					Style:    discordgo.PrimaryButton,
+					CustomID: surveyCustomID(SurveyCommentButton, guildID, localID, 0),
+				},
+			}}},
+		},
+	})
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed responding to ticket rating")
+	}
+}
+
+func handleSurveyCommentButton(ic *discordgo.InteractionCreate) {
+	guildID, localID, _, ok := parseSurveyCustomID(SurveyCommentButton, ic.MessageComponentData().CustomID)
+	if !ok || !bot.ReadyTracker.IsGuildOnProcess(guildID) {
+		return
+	}
+
+	lang := i18n.InteractionLanguage(&ic.Interaction)
+	err := common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: surveyCustomID(SurveyCommentModal, guildID, localID, 0),
+			Title:    i18n.Translate(lang, "tickets.survey_modal_title"),
+			Components: []discordgo.MessageComponent{discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				discordgo.TextInput{
+					CustomID:  "comment",
+					Label:     i18n.Translate(lang, "tickets.survey_comment_label"),
+					Style:     discordgo.TextInputParagraph,
+					Required:  true,
+					MaxLength: 1000,
+				},
+			}}},
+		},
+	})
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed sending ticket comment modal")
+	}
+}
+
+func handleSurveyCommentModal(ic *discordgo.InteractionCreate) {
+	guildID, localID, _, ok := parseSurveyCustomID(SurveyCommentModal, ic.DataModal.CustomID)
+	if !ok || !bot.ReadyTracker.IsGuildOnProcess(guildID) || len(ic.DataModal.Components) < 1 {
+		return
+	}
+
+	row, ok := ic.DataModal.Components[0].(*discordgo.ActionsRow)
+	if !ok || len(row.Components) < 1 {
+		return
+	}
+
+	input, ok := row.Components[0].(*discordgo.TextInput)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	ticket := findSurveyTicket(ctx, ic, guildID, localID)
+	if ticket == nil {
+		return
+	}
+
+	ticket.RatingComment = common.CutStringShort(input.Value, 1000)
+	_, err := ticket.UpdateG(ctx, boil.Whitelist(models.TicketColumns.RatingComment))
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed saving ticket rating comment")
+		return
+	}
+
+	err = common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    i18n.Translate(i18n.InteractionLanguage(&ic.Interaction), "tickets.survey_commented", ticket.LocalID, ticket.Title),
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed responding to ticket rating comment")
+	}
+}
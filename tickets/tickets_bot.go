@@ -9,11 +9,17 @@ import (
 	"github.com/cirelion/flint/bot/botrest"
 	"github.com/cirelion/flint/bot/eventsystem"
 	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/i18n"
+	"github.com/cirelion/flint/common/pubsub"
+	"github.com/cirelion/flint/common/scheduledevents2"
+	"github.com/cirelion/flint/common/templates"
 	"github.com/cirelion/flint/lib/discordgo"
 	"github.com/cirelion/flint/lib/dstate"
 	"github.com/cirelion/flint/tickets/models"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"strings"
 	"time"
 )
 
@@ -22,6 +28,44 @@ var _ bot.BotInitHandler = (*Plugin)(nil)
 func (p *Plugin) BotInit() {
 	//eventsystem.AddHandlerAsyncLast(p, p.handleChannelRemoved, eventsystem.EventChannelDelete)
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleInteractionCreate, eventsystem.EventInteractionCreate)
+	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleMessageCreate, eventsystem.EventMessageCreate)
+
+	pubsub.AddHandler("dm_interaction", handleDMInteraction, discordgo.InteractionCreate{})
+	scheduledevents2.RegisterHandler(evtInactivityCheck, inactivityCheckData{}, handleInactivityCheck)
+}
+
+// openTicket is the part of an open ticket needed to handle the messages sent in its channel
+type openTicket struct {
+	LocalID int64
+	Modmail bool
+}
+
+// channel id -> open ticket, per guild
+var cachedOpenTickets = common.CacheSet.RegisterSlot("tickets_open_channels", nil, int64(0))
+
+// openTicketInChannel returns the open ticket in the channel, or nil if it's not a ticket channel
+func openTicketInChannel(guildID, channelID int64) (*openTicket, error) {
+	v, err := cachedOpenTickets.GetCustomFetch(guildID, func(key interface{}) (interface{}, error) {
+		tickets, err := models.Tickets(
+			qm.Select(models.TicketColumns.ChannelID, models.TicketColumns.LocalID, models.TicketColumns.Modmail),
+			models.TicketWhere.GuildID.EQ(guildID),
+			models.TicketWhere.ClosedAt.IsNull()).AllG(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		channels := make(map[int64]*openTicket, len(tickets))
+		for _, t := range tickets {
+			channels[t.ChannelID] = &openTicket{LocalID: t.LocalID, Modmail: t.Modmail}
+		}
+
+		return channels, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(map[int64]*openTicket)[channelID], nil
 }
 
 func (p *Plugin) handleInteractionCreate(evt *eventsystem.EventData) {
@@ -35,6 +79,8 @@ func (p *Plugin) handleInteractionCreate(evt *eventsystem.EventData) {
 		customID := ic.MessageComponentData().CustomID
 		if customID == applications.TicketSubmit {
 			startTicketModal(ic, evt.Session)
+		} else if customID == TicketPanelSelect || strings.HasPrefix(customID, TicketPanelButton) {
+			handlePanelInteraction(evt.Context(), ic, evt.Session, customID)
 		}
 		return
 	}
@@ -48,6 +94,11 @@ func (p *Plugin) handleInteractionCreate(evt *eventsystem.EventData) {
 		return
 	}
 
+	if strings.HasPrefix(ic.DataModal.CustomID, TicketTypeModal) {
+		handleTicketTypeModalSubmit(evt.Context(), ic)
+		return
+	}
+
 	if ic.Type == discordgo.InteractionModalSubmit && ic.DataModal.CustomID == TicketModal {
 		subject := ic.DataModal.Components[0].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput)
 		question := ic.DataModal.Components[1].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput)
@@ -78,7 +129,7 @@ func (p *Plugin) handleInteractionCreate(evt *eventsystem.EventData) {
 
 		err = common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{Content: fmt.Sprintf("Support ticket opened successfully! Click [here](https://discord.com/channels/%d/%d) to go to your ticket.", ticket.GuildID, ticket.ChannelID), Flags: 64},
+			Data: &discordgo.InteractionResponseData{Content: i18n.Translate(i18n.InteractionLanguage(&ic.Interaction), "tickets.opened", ticket.GuildID, ticket.ChannelID), Flags: 64},
 		})
 		if err != nil {
 			logger.WithError(err).Error("Failed sending ticket confirm message")
@@ -86,6 +137,33 @@ func (p *Plugin) handleInteractionCreate(evt *eventsystem.EventData) {
 		}
 	}
 }
+
+// handleDMInteraction handles the modmail server picker and the satisfaction survey,
+// the handlers check if the server is on this process themselves
+func handleDMInteraction(evt *pubsub.Event) {
+	ic := evt.Data.(*discordgo.InteractionCreate)
+	if ic.User == nil {
+		return
+	}
+
+	switch ic.Type {
+	case discordgo.InteractionMessageComponent:
+		customID := ic.MessageComponentData().CustomID
+		switch {
+		case customID == ModmailPickGuild:
+			go handleModmailPickGuild(ic)
+		case strings.HasPrefix(customID, SurveyRating):
+			go handleSurveyRating(ic)
+		case strings.HasPrefix(customID, SurveyCommentButton):
+			go handleSurveyCommentButton(ic)
+		}
+	case discordgo.InteractionModalSubmit:
+		if ic.DataModal != nil && strings.HasPrefix(ic.DataModal.CustomID, SurveyCommentModal) {
+			go handleSurveyCommentModal(ic)
+		}
+	}
+}
+
 func (p *Plugin) handleChannelRemoved(evt *eventsystem.EventData) (retry bool, err error) {
 	del := evt.ChannelDelete()
 
@@ -106,12 +184,44 @@ func (t TicketUserError) Error() string {
 	return string(t)
 }
 
+// ticketUserErrorIDs maps the fixed user errors to their message in the catalogs
+var ticketUserErrorIDs = map[TicketUserError]string{
+	ErrNoTicketCateogry: "tickets.no_category",
+	ErrMaxOpenTickets:   "tickets.max_open_tickets",
+}
+
+// Translate returns the error in the language, errors without a catalog message are returned as is
+func (t TicketUserError) Translate(lang string) string {
+	if id, ok := ticketUserErrorIDs[t]; ok {
+		return i18n.Translate(lang, id)
+	}
+
+	return string(t)
+}
+
 const (
 	ErrNoTicketCateogry TicketUserError = "No category for ticket channels set"
 	ErrMaxOpenTickets   TicketUserError = "You're currently in over 3 open tickets on this server, please close some of the ones you're in."
 )
 
 func CreateTicket(ctx context.Context, gs *dstate.GuildSet, ms *dstate.MemberState, conf *models.TicketConfig, topic string, question string, checkMaxTickets bool) (*dstate.GuildSet, *models.Ticket, error) {
+	return createTicket(ctx, gs, ms, conf, nil, topic, question, nil, checkMaxTickets, false)
+}
+
+// CreateTicketOfType creates a ticket using the category, staff roles and opening message of the ticket type,
+// answers are the answers to the questions of the type in order
+func CreateTicketOfType(ctx context.Context, gs *dstate.GuildSet, ms *dstate.MemberState, conf *models.TicketConfig, tt *models.TicketType, topic string, question string, answers []string) (*dstate.GuildSet, *models.Ticket, error) {
+	return createTicket(ctx, gs, ms, conf, tt, topic, question, answers, true, false)
+}
+
+// CreateModmailTicket creates a ticket for a conversation relayed through DMs, the author is not added to the channel
+func CreateModmailTicket(ctx context.Context, gs *dstate.GuildSet, ms *dstate.MemberState, conf *models.TicketConfig, topic string, question string) (*dstate.GuildSet, *models.Ticket, error) {
+	return createTicket(ctx, gs, ms, conf, nil, topic, question, nil, true, true)
+}
+
+func createTicket(ctx context.Context, gs *dstate.GuildSet, ms *dstate.MemberState, conf *models.TicketConfig, tt *models.TicketType, topic string, question string, answers []string, checkMaxTickets bool, modmail bool) (*dstate.GuildSet, *models.Ticket, error) {
+	conf = configForTicketType(conf, tt)
+
 	if gs.GetChannel(conf.TicketsChannelCategory) == nil {
 		return gs, nil, ErrNoTicketCateogry
 	}
@@ -146,7 +256,12 @@ func CreateTicket(ctx context.Context, gs *dstate.GuildSet, ms *dstate.MemberSta
 	gsCop.Channels = make([]dstate.ChannelState, len(gs.Channels), len(gs.Channels)+1)
 	copy(gsCop.Channels, gs.Channels)
 
-	id, channel, err := createTicketChannel(conf, gs, ms.User.ID)
+	memberID := ms.User.ID
+	if modmail {
+		memberID = 0
+	}
+
+	id, channel, err := createTicketChannel(conf, gs, memberID)
 	if err != nil {
 		return gs, nil, err
 	}
@@ -159,8 +274,14 @@ func CreateTicket(ctx context.Context, gs *dstate.GuildSet, ms *dstate.MemberSta
 		Title:                 topic,
 		Question:              question,
 		CreatedAt:             time.Now(),
+		LastActivityAt:        time.Now(),
 		AuthorID:              ms.User.ID,
 		AuthorUsernameDiscrim: ms.User.String(),
+		Modmail:               modmail,
+	}
+
+	if tt != nil {
+		dbModel.TicketTypeID = null.Int64From(tt.ID)
 	}
 
 	err = dbModel.InsertG(ctx, boil.Infer())
@@ -168,6 +289,13 @@ func CreateTicket(ctx context.Context, gs *dstate.GuildSet, ms *dstate.MemberSta
 		return gs, nil, err
 	}
 
+	pubsub.EvictCacheSet(cachedOpenTickets, gs.ID)
+
+	err = scheduleInactivityCheck(conf, dbModel)
+	if err != nil {
+		logger.WithError(err).WithField("guild", gs.ID).Error("failed scheduling ticket inactivity check")
+	}
+
 	// send the first ticket message
 	cs := dstate.ChannelStateFromDgo(channel)
 
@@ -175,8 +303,19 @@ func CreateTicket(ctx context.Context, gs *dstate.GuildSet, ms *dstate.MemberSta
 	gs = &gsCop
 	gs.Channels = append(gs.Channels, cs)
 
+	// a custom opening message replaces the greeting, the ticket details with the answers are always sent
+	customOpenMsg := tt != nil && strings.TrimSpace(tt.OpenMsg) != ""
+
+	lang := i18n.GuildLanguage(gs.ID)
+	content := i18n.Translate(lang, "tickets.greeting", ms.User.Mention())
+	if modmail {
+		content = i18n.Translate(lang, "tickets.modmail_greeting", ms.User.String(), ms.User.ID)
+	} else if customOpenMsg {
+		content = ""
+	}
+
 	_, err = common.BotSession.ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
-		Content: fmt.Sprintf("Hello %s! A moderator will respond to your inquiry shortly!", ms.User.Mention()),
+		Content: content,
 		Embeds: []*discordgo.MessageEmbed{{
 			Title:       topic,
 			Description: question,
@@ -187,5 +326,19 @@ func CreateTicket(ctx context.Context, gs *dstate.GuildSet, ms *dstate.MemberSta
 		logger.WithError(err).WithField("guild", gs.ID).Error("failed sending ticket open message")
 	}
 
+	if customOpenMsg {
+		tmplCtx := templates.NewContext(gs, &cs, ms)
+		tmplCtx.Name = "ticket_type_open_msg"
+		tmplCtx.Data["Reason"] = topic
+		tmplCtx.Data["TicketType"] = tt.Name
+		tmplCtx.Data["Questions"] = typeQuestions(tt)
+		tmplCtx.Data["Answers"] = answers
+
+		err = tmplCtx.ExecuteAndSendWithErrors(tt.OpenMsg, channel.ID)
+		if err != nil {
+			logger.WithError(err).WithField("guild", gs.ID).Error("failed sending ticket type open message")
+		}
+	}
+
 	return gs, dbModel, nil
 }
@@ -5,8 +5,10 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"emperror.dev/errors"
 	"encoding/json"
 	"fmt"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"io"
 	"net/http"
@@ -14,8 +16,11 @@ import (
 	"time"
 
 	"github.com/cirelion/flint/analytics"
+	"github.com/cirelion/flint/bot"
 	"github.com/cirelion/flint/commands"
 	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/i18n"
+	"github.com/cirelion/flint/common/pubsub"
 	"github.com/cirelion/flint/lib/dcmd"
 	"github.com/cirelion/flint/lib/discordgo"
 	"github.com/cirelion/flint/lib/dstate"
@@ -28,8 +33,8 @@ const InTicketPerms = discordgo.PermissionReadMessageHistory | discordgo.Permiss
 
 var _ commands.CommandProvider = (*Plugin)(nil)
 
-func createTicketsDisabledError(guild *dcmd.GuildContextData) string {
-	return fmt.Sprintf("**The tickets system is disabled for this server.** Enable it at: <%s/tickets/settings>.", web.ManageServerURL(guild))
+func createTicketsDisabledError(data *dcmd.Data) string {
+	return i18n.T(data, "tickets.disabled", web.ManageServerURL(data.GuildData))
 }
 
 func (p *Plugin) AddCommands() {
@@ -59,7 +64,7 @@ func (p *Plugin) AddCommands() {
 			for _, v := range parsed.GuildData.CS.PermissionOverwrites {
 				if v.Type == discordgo.PermissionOverwriteTypeMember && v.ID == target.User.ID {
 					if (v.Allow & InTicketPerms) == InTicketPerms {
-						return "User is already part of the ticket", nil
+						return i18n.T(parsed, "tickets.already_participant"), nil
 					}
 
 					break OUTER
@@ -71,7 +76,7 @@ func (p *Plugin) AddCommands() {
 				return nil, err
 			}
 
-			return fmt.Sprintf("Added %s to the ticket", target.User.String()), nil
+			return i18n.T(parsed, "tickets.participant_added", target.User.String()), nil
 		},
 	}
 
@@ -115,63 +120,132 @@ func (p *Plugin) AddCommands() {
 	//	},
 	//}
 
-	closingTickets := make(map[int64]bool)
-	var closingTicketsLock sync.Mutex
+	cmdNote := &commands.YAGCommand{
+		CmdCategory:  categoryTickets,
+		Name:         "Note",
+		Description:  "Adds an internal note to the ticket, notes are not sent to the user in modmail tickets",
+		RequiredArgs: 1,
+		Arguments: []*dcmd.ArgDef{
+			{Name: "note", Type: dcmd.String},
+		},
 
-	cmdCloseTicket := &commands.YAGCommand{
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			conf := parsed.Context().Value(CtxKeyConfig).(*models.TicketConfig)
+			if ok, err := isTicketStaff(parsed.GuildData.GS, conf, parsed.GuildData.CS.ID, parsed.GuildData.MS); err != nil || !ok {
+				return i18n.T(parsed, "tickets.staff_only"), err
+			}
+
+			return &discordgo.MessageEmbed{
+				Author: &discordgo.MessageEmbedAuthor{
+					Name:    parsed.Author.String(),
+					IconURL: parsed.Author.AvatarURL("64"),
+				},
+				Title:       "Internal note",
+				Description: parsed.Args[0].Str(),
+				Color:       0xf2c94c,
+			}, nil
+		},
+	}
+
+	cmdPin := &commands.YAGCommand{
 		CmdCategory: categoryTickets,
-		Name:        "Close",
-		Aliases:     []string{"end", "delete"},
-		Description: "Closes the ticket",
+		Name:        "Pin",
+		Aliases:     []string{"unpin"},
+		Description: "Toggles whether the ticket is pinned, pinned tickets are never reminded about or closed for inactivity",
+
 		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
 			conf := parsed.Context().Value(CtxKeyConfig).(*models.TicketConfig)
 			currentTicket := parsed.Context().Value(CtxKeyCurrentTicket).(*Ticket)
 
-			// protect against calling close multiple times at the same time
-			closingTicketsLock.Lock()
-			if _, ok := closingTickets[currentTicket.Ticket.ChannelID]; ok {
-				closingTicketsLock.Unlock()
-				return "Already working on closing this ticket, please wait...", nil
+			if ok, err := isTicketStaff(parsed.GuildData.GS, conf, parsed.GuildData.CS.ID, parsed.GuildData.MS); err != nil || !ok {
+				return i18n.T(parsed, "tickets.pin_staff_only"), err
 			}
-			closingTickets[currentTicket.Ticket.ChannelID] = true
-			closingTicketsLock.Unlock()
-			defer func() {
-				closingTicketsLock.Lock()
-				delete(closingTickets, currentTicket.Ticket.ChannelID)
-				closingTicketsLock.Unlock()
-			}()
-
-			// send a heads up that this can take a while
-			common.BotSession.ChannelMessageSend(parsed.ChannelID, "Closing ticket, creating logs, downloading attachments and so on.\nThis may take a while if the ticket is big.")
-
-			currentTicket.Ticket.ClosedAt.Time = time.Now()
-			currentTicket.Ticket.ClosedAt.Valid = true
-
-			isAdminsOnly := ticketIsAdminOnly(conf, parsed.GuildData.CS)
-
-			// create the logs, download the attachments
-			err := createLogs(parsed, conf, currentTicket.Ticket, isAdminsOnly, &discordgo.MessageEmbed{
-				URL:         fmt.Sprintf("%s/manage/%d/tickets/%d", web.BaseURL(), parsed.GuildData.GS.ID, currentTicket.Ticket.LocalID),
-				Title:       fmt.Sprintf("Ticket #%d - '%s' closed", currentTicket.Ticket.LocalID, currentTicket.Ticket.Title),
-				Description: fmt.Sprintf("Author: %s", currentTicket.Ticket.AuthorUsernameDiscrim),
-				Color:       0xf23c3c,
-			})
+
+			ticket := currentTicket.Ticket
+			ticket.Pinned = !ticket.Pinned
+			ticket.LastActivityAt = time.Now()
+			ticket.InactivityReminded = false
+			_, err := ticket.UpdateG(parsed.Context(), boil.Whitelist(models.TicketColumns.Pinned, models.TicketColumns.LastActivityAt, models.TicketColumns.InactivityReminded))
 			if err != nil {
 				return nil, err
 			}
 
-			// if everything went well, delete the channel
-			_, err = common.BotSession.ChannelDelete(currentTicket.Ticket.ChannelID)
+			err = clearInactivityChecks(parsed.Context(), ticket.GuildID, ticket.LocalID)
 			if err != nil {
 				return nil, err
 			}
 
-			_, err = currentTicket.Ticket.UpdateG(parsed.Context(), boil.Whitelist("closed_at"))
+			if ticket.Pinned {
+				return i18n.T(parsed, "tickets.pinned"), nil
+			}
+
+			err = scheduleInactivityCheck(conf, ticket)
 			if err != nil {
 				return nil, err
 			}
 
-			return "", nil
+			return i18n.T(parsed, "tickets.unpinned"), nil
+		},
+	}
+
+	cmdAnonymousReply := &commands.YAGCommand{
+		CmdCategory:  categoryTickets,
+		Name:         "AReply",
+		Aliases:      []string{"anonreply", "ar"},
+		Description:  "Replies to the user of a modmail ticket without showing who sent the reply",
+		RequiredArgs: 1,
+		Arguments: []*dcmd.ArgDef{
+			{Name: "message", Type: dcmd.String},
+		},
+
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			conf := parsed.Context().Value(CtxKeyConfig).(*models.TicketConfig)
+			currentTicket := parsed.Context().Value(CtxKeyCurrentTicket).(*Ticket)
+			if !currentTicket.Ticket.Modmail {
+				return i18n.T(parsed, "tickets.modmail_only"), nil
+			}
+
+			if ok, err := isTicketStaff(parsed.GuildData.GS, conf, parsed.GuildData.CS.ID, parsed.GuildData.MS); err != nil || !ok {
+				return i18n.T(parsed, "tickets.staff_only"), err
+			}
+
+			var attachments []*discordgo.MessageAttachment
+			if parsed.TraditionalTriggerData != nil {
+				attachments = parsed.TraditionalTriggerData.Message.Attachments
+			}
+
+			err := relayToUser(parsed.GuildData.GS, currentTicket.Ticket, parsed.Author, true, parsed.Args[0].Str(), attachments)
+			if err != nil {
+				return i18n.T(parsed, "tickets.relay_failed"), nil
+			}
+
+			return &discordgo.MessageEmbed{
+				Author: &discordgo.MessageEmbedAuthor{
+					Name:    parsed.Author.String(),
+					IconURL: parsed.Author.AvatarURL("64"),
+				},
+				Title:       i18n.T(parsed, "tickets.anonymous_reply"),
+				Description: parsed.Args[0].Str(),
+				Color:       0x42b9f4,
+			}, nil
+		},
+	}
+
+	cmdCloseTicket := &commands.YAGCommand{
+		CmdCategory: categoryTickets,
+		Name:        "Close",
+		Aliases:     []string{"end", "delete"},
+		Description: "Closes the ticket",
+		RunFunc: func(parsed *dcmd.Data) (interface{}, error) {
+			conf := parsed.Context().Value(CtxKeyConfig).(*models.TicketConfig)
+			currentTicket := parsed.Context().Value(CtxKeyCurrentTicket).(*Ticket)
+
+			err := closeTicket(parsed.Context(), parsed.GuildData.GS, conf, currentTicket.Ticket, "")
+			if err == errAlreadyClosing {
+				return i18n.T(parsed, "tickets.already_closing"), nil
+			}
+
+			return "", err
 		},
 	}
 
@@ -278,7 +352,16 @@ func (p *Plugin) AddCommands() {
 
 				// no ticket commands have any effect then
 				if activeTicket == nil && !conf.Enabled {
-					return createTicketsDisabledError(data.GuildData), nil
+					return createTicketsDisabledError(data), nil
+				}
+
+				if activeTicket != nil {
+					tt, err := findTicketType(data.Context(), activeTicket)
+					if err != nil {
+						return nil, err
+					}
+
+					conf = configForTicketType(conf, tt)
 				}
 
 				ctx := context.WithValue(data.Context(), CtxKeyConfig, conf)
@@ -298,6 +381,9 @@ func (p *Plugin) AddCommands() {
 	container.AddCommand(cmdAddParticipant, cmdAddParticipant.GetTrigger().SetMiddlewares(RequireActiveTicketMW))
 	//container.AddCommand(cmdRemoveParticipant, cmdRemoveParticipant.GetTrigger().SetMiddlewares(RequireActiveTicketMW))
 	container.AddCommand(cmdCloseTicket, cmdCloseTicket.GetTrigger().SetMiddlewares(RequireActiveTicketMW))
+	container.AddCommand(cmdNote, cmdNote.GetTrigger().SetMiddlewares(RequireActiveTicketMW))
+	container.AddCommand(cmdPin, cmdPin.GetTrigger().SetMiddlewares(RequireActiveTicketMW))
+	container.AddCommand(cmdAnonymousReply, cmdAnonymousReply.GetTrigger().SetMiddlewares(RequireActiveTicketMW))
 	//container.AddCommand(cmdAdminsOnly, cmdAdminsOnly.GetTrigger().SetMiddlewares(RequireActiveTicketMW))
 
 	commands.RegisterSlashCommandsContainer(container, false, TicketCommandsRolesRunFuncfunc)
@@ -324,7 +410,7 @@ func TicketCommandsRolesRunFuncfunc(gs *dstate.GuildSet) ([]int64, error) {
 func RequireActiveTicketMW(inner dcmd.RunFunc) dcmd.RunFunc {
 	return func(data *dcmd.Data) (interface{}, error) {
 		if data.Context().Value(CtxKeyCurrentTicket) == nil {
-			return "This command can only be ran in a active ticket", nil
+			return i18n.T(data, "tickets.not_in_ticket"), nil
 		}
 
 		return inner(data)
@@ -343,7 +429,132 @@ type Ticket struct {
 	Participants []*models.TicketParticipant
 }
 
-func createLogs(parsed *dcmd.Data, conf *models.TicketConfig, ticket *models.Ticket, adminOnly bool, embed *discordgo.MessageEmbed) error {
+var (
+	closingTickets     = make(map[int64]bool)
+	closingTicketsLock sync.Mutex
+
+	errAlreadyClosing = errors.New("already closing ticket")
+)
+
+// closeTicket creates the logs for the ticket, deletes its channel and marks it as closed,
+// reason is shown in the transcript embed if set
+func closeTicket(ctx context.Context, gs *dstate.GuildSet, conf *models.TicketConfig, ticket *models.Ticket, reason string) error {
+	// protect against calling close multiple times at the same time
+	closingTicketsLock.Lock()
+	if _, ok := closingTickets[ticket.ChannelID]; ok {
+		closingTicketsLock.Unlock()
+		return errAlreadyClosing
+	}
+	closingTickets[ticket.ChannelID] = true
+	closingTicketsLock.Unlock()
+	defer func() {
+		closingTicketsLock.Lock()
+		delete(closingTickets, ticket.ChannelID)
+		closingTicketsLock.Unlock()
+	}()
+
+	lang := i18n.GuildLanguage(gs.ID)
+
+	// send a heads up that this can take a while
+	common.BotSession.ChannelMessageSend(ticket.ChannelID, i18n.Translate(lang, "tickets.closing"))
+
+	ticket.ClosedAt.Time = time.Now()
+	ticket.ClosedAt.Valid = true
+
+	isAdminsOnly := false
+	if cs := gs.GetChannel(ticket.ChannelID); cs != nil {
+		isAdminsOnly = ticketIsAdminOnly(conf, cs)
+	}
+
+	description := i18n.Translate(lang, "tickets.closed_author", ticket.AuthorUsernameDiscrim)
+	if reason != "" {
+		description += "\n" + i18n.Translate(lang, "tickets.closed_reason", reason)
+	}
+
+	// create the logs, download the attachments
+	err := createLogs(ctx, gs, conf, ticket, isAdminsOnly, &discordgo.MessageEmbed{
+		URL:         fmt.Sprintf("%s/manage/%d/tickets/%d", web.BaseURL(), gs.ID, ticket.LocalID),
+		Title:       i18n.Translate(lang, "tickets.closed_title", ticket.LocalID, ticket.Title),
+		Description: description,
+		Color:       0xf23c3c,
+	})
+	if err != nil {
+		return err
+	}
+
+	markStaffParticipants(ctx, gs, conf, ticket)
+
+	// if everything went well, delete the channel
+	_, err = common.BotSession.ChannelDelete(ticket.ChannelID)
+	if err != nil {
+		return err
+	}
+
+	_, err = ticket.UpdateG(ctx, boil.Whitelist("closed_at"))
+	if err != nil {
+		return err
+	}
+
+	pubsub.EvictCacheSet(cachedOpenTickets, ticket.GuildID)
+
+	if ticket.Modmail {
+		bot.SendDM(ticket.AuthorID, i18n.Translate(lang, "tickets.modmail_closed", gs.Name))
+	}
+
+	if conf.SatisfactionSurvey {
+		go sendSatisfactionSurvey(gs, ticket)
+	}
+
+	return nil
+}
+
+// backfillParticipants stores who sent messages in the ticket and when they first did from the fetched messages,
+// used for tickets opened before participants were tracked as messages are sent
+func backfillParticipants(ctx context.Context, ticket *models.Ticket, msgs []*discordgo.Message) {
+	count, err := models.TicketParticipants(
+		models.TicketParticipantWhere.TicketGuildID.EQ(ticket.GuildID),
+		models.TicketParticipantWhere.TicketLocalID.EQ(ticket.LocalID),
+		models.TicketParticipantWhere.MessageCount.GT(0)).CountG(ctx)
+	if err != nil || count > 0 {
+		return
+	}
+
+	participants := make(map[int64]*models.TicketParticipant)
+
+	// traverse reverse for old-new order, so the first message seen is the first one sent
+	for i := len(msgs) - 1; i >= 0; i-- {
+		m := msgs[i]
+		if m.Author == nil || m.Author.Bot {
+			continue
+		}
+
+		participant, ok := participants[m.Author.ID]
+		if !ok {
+			ts, _ := m.Timestamp.Parse()
+			participant = &models.TicketParticipant{
+				TicketGuildID:  ticket.GuildID,
+				TicketLocalID:  ticket.LocalID,
+				UserID:         m.Author.ID,
+				Username:       m.Author.Username,
+				Discrim:        m.Author.Discriminator,
+				FirstMessageAt: null.TimeFrom(ts),
+			}
+
+			participants[m.Author.ID] = participant
+		}
+
+		participant.MessageCount++
+	}
+
+	for _, v := range participants {
+		err := v.UpsertG(ctx, true, []string{"ticket_guild_id", "ticket_local_id", "user_id"}, boil.Infer(), boil.Infer())
+		if err != nil {
+			logger.WithError(err).WithField("guild", ticket.GuildID).Error("failed saving ticket participant")
+		}
+	}
+}
+
+func createLogs(ctx context.Context, gs *dstate.GuildSet, conf *models.TicketConfig, ticket *models.Ticket, adminOnly bool, embed *discordgo.MessageEmbed) error {
 
 	if !conf.TicketsUseTXTTranscripts && !conf.DownloadAttachments {
 		return nil // nothing to do here
@@ -413,7 +624,11 @@ func createLogs(parsed *dcmd.Data, conf *models.TicketConfig, ticket *models.Tic
 		}
 	}
 
-	if conf.TicketsUseTXTTranscripts && parsed.GuildData.GS.GetChannel(transcriptChannel(conf, adminOnly)) != nil {
+	if len(msgs) > 0 {
+		backfillParticipants(ctx, ticket, msgs)
+	}
+
+	if conf.TicketsUseTXTTranscripts && gs.GetChannel(transcriptChannel(conf, adminOnly)) != nil {
 		formattedTranscript, textTranscript := createTXTTranscript(ticket, msgs)
 
 		channel := transcriptChannel(conf, adminOnly)
@@ -429,10 +644,10 @@ func createLogs(parsed *dcmd.Data, conf *models.TicketConfig, ticket *models.Tic
 	}
 
 	// compress and send the attachments
-	if conf.DownloadAttachments && parsed.GuildData.GS.GetChannel(transcriptChannel(conf, adminOnly)) != nil {
+	if conf.DownloadAttachments && gs.GetChannel(transcriptChannel(conf, adminOnly)) != nil {
 		archiveAttachments(conf, ticket, attachments, adminOnly)
 	}
-	_, _ = ticket.UpdateG(parsed.Context(), boil.Whitelist("logs"))
+	_, _ = ticket.UpdateG(ctx, boil.Whitelist("logs"))
 
 	return nil
 }
@@ -539,6 +754,19 @@ func createTXTTranscript(ticket *models.Ticket, msgs []*discordgo.Message) (*byt
 	return &buf, text
 }
 
+// isTicketStaff returns true if the member has one of the ticket mod or admin roles, or manage channels permissions
+func isTicketStaff(gs *dstate.GuildSet, conf *models.TicketConfig, channelID int64, ms *dstate.MemberState) (bool, error) {
+	if ms.Member != nil {
+		for _, r := range ms.Member.Roles {
+			if common.ContainsInt64Slice(conf.ModRoles, r) || common.ContainsInt64Slice(conf.AdminRoles, r) {
+				return true, nil
+			}
+		}
+	}
+
+	return bot.AdminOrPermMS(gs.ID, channelID, ms, discordgo.PermissionManageChannels)
+}
+
 func ticketIsAdminOnly(conf *models.TicketConfig, cs *dstate.ChannelState) bool {
 
 	isAdminsOnlyCurrently := true
@@ -563,14 +791,10 @@ func transcriptChannel(conf *models.TicketConfig, adminOnly bool) int64 {
 	return conf.TicketsTranscriptsChannel
 }
 
+// createTicketChannel creates the channel for a new ticket, authorID can be 0 to not add the author to the channel
 func createTicketChannel(conf *models.TicketConfig, gs *dstate.GuildSet, authorID int64) (int64, *discordgo.Channel, error) {
 	// assemble the permission overwrites for the channel were about to create
 	overwrites := []*discordgo.PermissionOverwrite{
-		{
-			Type:  discordgo.PermissionOverwriteTypeMember,
-			ID:    authorID,
-			Allow: InTicketPerms,
-		},
 		{
 			Type: discordgo.PermissionOverwriteTypeRole,
 			ID:   gs.ID,
@@ -583,6 +807,14 @@ func createTicketChannel(conf *models.TicketConfig, gs *dstate.GuildSet, authorI
 		},
 	}
 
+	if authorID != 0 {
+		overwrites = append(overwrites, &discordgo.PermissionOverwrite{
+			Type:  discordgo.PermissionOverwriteTypeMember,
+			ID:    authorID,
+			Allow: InTicketPerms,
+		})
+	}
+
 	// add all the mod and admin roles
 OUTER:
 	for _, v := range conf.ModRoles {
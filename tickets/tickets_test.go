@@ -3,8 +3,11 @@ package tickets
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/tickets/models"
+	"github.com/volatiletech/null/v8"
 )
 
 func TestInheritPermissionsFromCategory(t *testing.T) {
@@ -199,3 +202,126 @@ func TestInheritPermissionsFromCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestPanelMessage(t *testing.T) {
+	types := make([]*models.TicketType, 0, 7)
+	ids := make([]int64, 0, 7)
+	for i := int64(1); i <= 7; i++ {
+		types = append(types, &models.TicketType{ID: i, Name: "type"})
+		ids = append(ids, i)
+	}
+
+	// unknown types are skipped
+	panel := &models.TicketPanel{Title: "Support", TicketTypes: append(ids, 100)}
+
+	msg := PanelMessage(panel, types)
+	if len(msg.Components) != 2 {
+		t.Fatalf("expected 2 rows of buttons, got %d", len(msg.Components))
+	}
+
+	if n := len(msg.Components[1].(discordgo.ActionsRow).Components); n != 2 {
+		t.Errorf("expected 2 buttons in the second row, got %d", n)
+	}
+
+	panel.UseSelectMenu = true
+	msg = PanelMessage(panel, types)
+	if len(msg.Components) != 1 {
+		t.Fatalf("expected a single row with the select menu, got %d", len(msg.Components))
+	}
+
+	menu := msg.Components[0].(discordgo.ActionsRow).Components[0].(discordgo.SelectMenu)
+	if len(menu.Options) != 7 || menu.Options[6].Value != "7" {
+		t.Errorf("unexpected select menu options: %#v", menu.Options)
+	}
+}
+
+func TestParseComponentEmoji(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected discordgo.ComponentEmoji
+	}{
+		{input: "🎫", expected: discordgo.ComponentEmoji{Name: "🎫"}},
+		{input: "<:ticket:123456789012345678>", expected: discordgo.ComponentEmoji{Name: "ticket", ID: 123456789012345678}},
+		{input: " <a:spin:123456789012345678> ", expected: discordgo.ComponentEmoji{Name: "spin", ID: 123456789012345678, Animated: true}},
+	}
+
+	for _, c := range cases {
+		if got := parseComponentEmoji(c.input); got != c.expected {
+			t.Errorf("%q: got %+v, expected %+v", c.input, got, c.expected)
+		}
+	}
+}
+
+func TestNextInactivityCheck(t *testing.T) {
+	last := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		conf     *models.TicketConfig
+		reminded bool
+		expected time.Time
+		ok       bool
+	}{
+		{&models.TicketConfig{}, false, time.Time{}, false},
+		{&models.TicketConfig{InactivityReminderHours: 2, InactivityCloseHours: 5}, false, last.Add(2 * time.Hour), true},
+		{&models.TicketConfig{InactivityReminderHours: 2, InactivityCloseHours: 5}, true, last.Add(5 * time.Hour), true},
+		{&models.TicketConfig{InactivityReminderHours: 2}, true, time.Time{}, false},
+		{&models.TicketConfig{InactivityReminderHours: 10, InactivityCloseHours: 5}, false, last.Add(5 * time.Hour), true},
+	}
+
+	for i, c := range cases {
+		next, ok := nextInactivityCheck(c.conf, &models.Ticket{LastActivityAt: last, InactivityReminded: c.reminded})
+		if ok != c.ok || !next.Equal(c.expected) {
+			t.Errorf("case %d: expected %v (%t), got %v (%t)", i, c.expected, c.ok, next, ok)
+		}
+	}
+}
+
+func TestBuildStaffReport(t *testing.T) {
+	opened := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tickets := []*models.Ticket{
+		{LocalID: 1, CreatedAt: opened, ClosedAt: null.TimeFrom(opened.Add(4 * time.Hour)), Rating: null.IntFrom(5)},
+		{LocalID: 2, CreatedAt: opened, ClosedAt: null.TimeFrom(opened.Add(2 * time.Hour)), Rating: null.IntFrom(2)},
+		{LocalID: 3, CreatedAt: opened, ClosedAt: null.TimeFrom(opened.Add(6 * time.Hour))},
+	}
+
+	participants := []*models.TicketParticipant{
+		{TicketLocalID: 1, UserID: 10, FirstMessageAt: null.TimeFrom(opened.Add(10 * time.Minute))},
+		{TicketLocalID: 1, UserID: 20, FirstMessageAt: null.TimeFrom(opened.Add(5 * time.Minute))},
+		{TicketLocalID: 2, UserID: 10, FirstMessageAt: null.TimeFrom(opened.Add(20 * time.Minute))},
+		{TicketLocalID: 3, UserID: 10, FirstMessageAt: null.TimeFrom(opened.Add(30 * time.Minute))},
+	}
+
+	report := buildStaffReport(tickets, participants)
+	if len(report) != 2 {
+		t.Fatalf("expected 2 staff members, got %d", len(report))
+	}
+
+	first := report[0]
+	if first.UserID != 10 || first.Tickets != 3 || first.FirstResponses != 2 {
+		t.Errorf("unexpected stats: %#v", first)
+	}
+
+	if first.MedianFirstResponse != 25*time.Minute || first.MedianResolution != 4*time.Hour {
+		t.Errorf("unexpected medians: %s, %s", first.MedianFirstResponse, first.MedianResolution)
+	}
+
+	if first.Ratings != 2 || first.AverageRating != 3.5 {
+		t.Errorf("unexpected rating: %d, %f", first.Ratings, first.AverageRating)
+	}
+
+	if report[1].UserID != 20 || report[1].FirstResponses != 1 || report[1].MedianFirstResponse != 5*time.Minute {
+		t.Errorf("unexpected stats: %#v", report[1])
+	}
+}
+
+func TestParseSurveyCustomID(t *testing.T) {
+	id := surveyCustomID(SurveyRating, 1, 2, 4)
+	guildID, localID, rating, ok := parseSurveyCustomID(SurveyRating, id)
+	if !ok || guildID != 1 || localID != 2 || rating != 4 {
+		t.Errorf("unexpected result parsing %q: %d %d %d %t", id, guildID, localID, rating, ok)
+	}
+
+	if _, _, _, ok := parseSurveyCustomID(SurveyRating, SurveyRating+"1:2:9"); ok {
+		t.Error("expected out of range rating to fail")
+	}
+}
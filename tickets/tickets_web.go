@@ -1,17 +1,22 @@
 package tickets
 
 import (
+	"context"
 	"database/sql"
 	_ "embed"
 	"fmt"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"html/template"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cirelion/flint/commands"
 	"github.com/cirelion/flint/common"
 	"github.com/cirelion/flint/common/cplogs"
+	"github.com/cirelion/flint/common/featureflags"
 	"github.com/cirelion/flint/tickets/models"
 	"github.com/cirelion/flint/web"
 	"goji.io/pat"
@@ -23,6 +28,9 @@ var PageHTML string
 //go:embed assets/ticket_view.html
 var TicketHTML string
 
+//go:embed assets/tickets_report.html
+var ReportHTML string
+
 type FormData struct {
 	GuildID                            int64
 	Enabled                            bool
@@ -32,16 +40,46 @@ type FormData struct {
 	StatusChannel                      int64 `valid:"channel,true"`
 	TicketsUseTXTTranscripts           bool
 	DownloadAttachments                bool
+	ModmailEnabled                     bool
+	InactivityReminderHours            int `valid:"0,8760"`
+	InactivityCloseHours               int `valid:"0,8760"`
+	SatisfactionSurvey                 bool
 	ModRoles                           []int64 `valid:"role"`
 	AdminRoles                         []int64 `valid:"role"`
 	TicketOpenMSG                      string  `valid:"template,10000"`
 }
 
-var panelLogKey = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "tickets_updated_settings", FormatString: "Updated ticket settings"})
+type TicketTypeForm struct {
+	Name               string  `valid:",1,100,trimspace"`
+	Description        string  `valid:",100"`
+	Emoji              string  `valid:",100,trimspace"`
+	ChannelCategory    int64   `valid:"channel,true"`
+	TranscriptsChannel int64   `valid:"channel,true"`
+	StaffRoles         []int64 `valid:"role"`
+	Questions          string  `valid:",1000"`
+	OpenMsg            string  `valid:"template,10000"`
+}
+
+type TicketPanelForm struct {
+	ChannelID     int64  `valid:"channel,false"`
+	Title         string `valid:",1,256,trimspace"`
+	Description   string `valid:",2000"`
+	UseSelectMenu bool
+	TicketTypes   []int64
+}
+
+var (
+	panelLogKey             = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "tickets_updated_settings", FormatString: "Updated ticket settings"})
+	logKeyTicketTypeSaved   = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "tickets_saved_type", FormatString: "Saved ticket type #%d"})
+	logKeyTicketTypeDeleted = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "tickets_deleted_type", FormatString: "Deleted ticket type #%d"})
+	logKeyPanelSaved        = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "tickets_saved_panel", FormatString: "Saved and posted ticket panel #%d"})
+	logKeyPanelDeleted      = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "tickets_deleted_panel", FormatString: "Deleted ticket panel #%d"})
+)
 
 func (p *Plugin) InitWeb() {
 	web.AddHTMLTemplate("tickets_control_panel.html", PageHTML)
 	web.AddHTMLTemplate("ticket_view.html", TicketHTML)
+	web.AddHTMLTemplate("tickets_report.html", ReportHTML)
 
 	web.AddSidebarItem(web.SidebarCategoryTools, &web.SidebarItem{
 		Name: "Ticket System",
@@ -56,10 +94,22 @@ func (p *Plugin) InitWeb() {
 	web.CPMux.Handle(pat.Get("/tickets/settings"), getHandler)
 	web.CPMux.Handle(pat.Get("/tickets/settings/"), getHandler)
 
+	reportHandler := web.ControllerHandler(p.handleGetReport, "cp_tickets_report")
+	web.CPMux.Handle(pat.Get("/tickets/report"), reportHandler)
+	web.CPMux.Handle(pat.Get("/tickets/report/"), reportHandler)
+
 	web.CPMux.Handle(pat.Get("/tickets/:ticket"), getTicketHandler)
 	web.CPMux.Handle(pat.Get("/tickets/:ticket/"), getTicketHandler)
 
 	web.CPMux.Handle(pat.Post("/tickets/settings"), postHandler)
+
+	web.CPMux.Handle(pat.Post("/tickets/settings/types/new"), web.ControllerPostHandler(p.handleNewTicketType, getHandler, TicketTypeForm{}))
+	web.CPMux.Handle(pat.Post("/tickets/settings/types/:type/update"), web.ControllerPostHandler(p.handleUpdateTicketType, getHandler, TicketTypeForm{}))
+	web.CPMux.Handle(pat.Post("/tickets/settings/types/:type/delete"), web.ControllerPostHandler(p.handleDeleteTicketType, getHandler, nil))
+
+	web.CPMux.Handle(pat.Post("/tickets/settings/panels/new"), web.ControllerPostHandler(p.handleNewPanel, getHandler, TicketPanelForm{}))
+	web.CPMux.Handle(pat.Post("/tickets/settings/panels/:panel/update"), web.ControllerPostHandler(p.handleUpdatePanel, getHandler, TicketPanelForm{}))
+	web.CPMux.Handle(pat.Post("/tickets/settings/panels/:panel/delete"), web.ControllerPostHandler(p.handleDeletePanel, getHandler, nil))
 }
 
 func (p *Plugin) handleGetTicket(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
@@ -76,6 +126,31 @@ func (p *Plugin) handleGetTicket(w http.ResponseWriter, r *http.Request) (web.Te
 	return templateData, nil
 }
 
+func (p *Plugin) handleGetReport(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	days := 30
+	if parsed, err := strconv.Atoi(r.FormValue("days")); err == nil {
+		for _, v := range ReportPeriods {
+			if v == parsed {
+				days = parsed
+			}
+		}
+	}
+
+	report, err := StaffReport(ctx, activeGuild.ID, time.Now().Add(-time.Hour*24*time.Duration(days)))
+	if err != nil {
+		return templateData, err
+	}
+
+	templateData["Days"] = days
+	templateData["Periods"] = ReportPeriods
+	templateData["Report"] = report
+
+	return templateData, nil
+}
+
 func (p *Plugin) handleGetSettings(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
 	ctx := r.Context()
 	activeGuild, templateData := web.GetBaseCPContextData(ctx)
@@ -90,8 +165,20 @@ func (p *Plugin) handleGetSettings(w http.ResponseWriter, r *http.Request) (web.
 		settings = &models.TicketConfig{}
 	}
 
+	types, err := models.TicketTypes(models.TicketTypeWhere.GuildID.EQ(activeGuild.ID), qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
+	panels, err := models.TicketPanels(models.TicketPanelWhere.GuildID.EQ(activeGuild.ID), qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
 	templateData["DefaultTicketMessage"] = DefaultTicketMsg
 	templateData["PluginSettings"] = settings
+	templateData["TicketTypes"] = types
+	templateData["TicketPanels"] = panels
 
 	return templateData, nil
 }
@@ -111,17 +198,268 @@ func (p *Plugin) handlePostSettings(w http.ResponseWriter, r *http.Request) (web
 		StatusChannel:                      formConfig.StatusChannel,
 		TicketsUseTXTTranscripts:           formConfig.TicketsUseTXTTranscripts,
 		DownloadAttachments:                formConfig.DownloadAttachments,
+		ModmailEnabled:                     formConfig.ModmailEnabled,
+		InactivityReminderHours:            formConfig.InactivityReminderHours,
+		InactivityCloseHours:               formConfig.InactivityCloseHours,
+		SatisfactionSurvey:                 formConfig.SatisfactionSurvey,
 		ModRoles:                           formConfig.ModRoles,
 		AdminRoles:                         formConfig.AdminRoles,
 		TicketOpenMSG:                      formConfig.TicketOpenMSG,
 	}
 
-	err := model.UpsertG(ctx, true, []string{"guild_id"}, boil.Infer(), boil.Infer())
+	old, err := models.FindTicketConfigG(ctx, activeGuild.ID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return templateData, err
+		}
+
+		old = &models.TicketConfig{}
+	}
+
+	err = model.UpsertG(ctx, true, []string{"guild_id"}, boil.Infer(), boil.Infer())
 	if err == nil {
 		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKey))
 	}
 
+	if err == nil && (inactivityEnabled(old) != inactivityEnabled(model) || old.InactivityReminderHours != model.InactivityReminderHours || old.InactivityCloseHours != model.InactivityCloseHours) {
+		err = RescheduleInactivityChecks(ctx, model)
+	}
+
 	commands.PubsubSendUpdateSlashCommandsPermissions(activeGuild.ID)
+	featureflags.MarkGuildDirty(activeGuild.ID)
+
+	return templateData, err
+}
+
+// parseQuestions splits the questions textarea into at most MaxTicketTypeQuestion questions, one per line
+func parseQuestions(raw string) ([]string, error) {
+	var questions []string
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if len(line) > 45 {
+			return nil, web.NewPublicError("Questions can be at most 45 characters long: " + line)
+		}
+
+		questions = append(questions, line)
+	}
+
+	if len(questions) > MaxTicketTypeQuestion {
+		return nil, web.NewPublicError(fmt.Sprintf("Ticket types can have at most %d questions", MaxTicketTypeQuestion))
+	}
+
+	return questions, nil
+}
+
+func (f *TicketTypeForm) applyTo(tt *models.TicketType) error {
+	questions, err := parseQuestions(f.Questions)
+	if err != nil {
+		return err
+	}
+
+	tt.Name = f.Name
+	tt.Description = f.Description
+	tt.Emoji = f.Emoji
+	tt.ChannelCategory = f.ChannelCategory
+	tt.TranscriptsChannel = f.TranscriptsChannel
+	tt.StaffRoles = f.StaffRoles
+	tt.Questions = questions
+	tt.OpenMsg = f.OpenMsg
+	return nil
+}
+
+func (p *Plugin) handleNewTicketType(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	form := ctx.Value(common.ContextKeyParsedForm).(*TicketTypeForm)
+
+	count, err := models.TicketTypes(models.TicketTypeWhere.GuildID.EQ(activeGuild.ID)).CountG(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
+	if count >= MaxTicketTypes {
+		return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d ticket types allowed", MaxTicketTypes))), nil
+	}
+
+	tt := &models.TicketType{GuildID: activeGuild.ID}
+	if err = form.applyTo(tt); err != nil {
+		return templateData, err
+	}
+
+	err = tt.InsertG(ctx, boil.Infer())
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, logKeyTicketTypeSaved, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: tt.ID}))
+	}
+
+	return templateData, err
+}
+
+func (p *Plugin) handleUpdateTicketType(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	form := ctx.Value(common.ContextKeyParsedForm).(*TicketTypeForm)
+
+	id, _ := strconv.ParseInt(pat.Param(r, "type"), 10, 64)
+	tt, err := models.TicketTypes(models.TicketTypeWhere.ID.EQ(id), models.TicketTypeWhere.GuildID.EQ(activeGuild.ID)).OneG(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
+	if err = form.applyTo(tt); err != nil {
+		return templateData, err
+	}
+
+	_, err = tt.UpdateG(ctx, boil.Infer())
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, logKeyTicketTypeSaved, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: tt.ID}))
+		p.updatePanelsWithType(ctx, activeGuild.ID, tt.ID)
+	}
+
+	return templateData, err
+}
+
+func (p *Plugin) handleDeleteTicketType(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	id, _ := strconv.ParseInt(pat.Param(r, "type"), 10, 64)
+	_, err := models.TicketTypes(models.TicketTypeWhere.ID.EQ(id), models.TicketTypeWhere.GuildID.EQ(activeGuild.ID)).DeleteAllG(ctx)
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, logKeyTicketTypeDeleted, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: id}))
+		p.updatePanelsWithType(ctx, activeGuild.ID, id)
+	}
+
+	return templateData, err
+}
+
+// updatePanelsWithType edits the posted panels that include the ticket type so they reflect the changes
+func (p *Plugin) updatePanelsWithType(ctx context.Context, guildID int64, typeID int64) {
+	panels, err := models.TicketPanels(models.TicketPanelWhere.GuildID.EQ(guildID)).AllG(ctx)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed retrieving ticket panels")
+		return
+	}
+
+	for _, panel := range panels {
+		if panel.MessageID == 0 || !common.ContainsInt64Slice(panel.TicketTypes, typeID) {
+			continue
+		}
+
+		if err := PostPanel(ctx, panel); err != nil {
+			web.CtxLogger(ctx).WithError(err).WithField("panel", panel.ID).Error("failed updating ticket panel")
+		}
+	}
+}
+
+func (f *TicketPanelForm) applyTo(panel *models.TicketPanel, types []*models.TicketType) {
+	panel.ChannelID = f.ChannelID
+	panel.Title = f.Title
+	panel.Description = f.Description
+	panel.UseSelectMenu = f.UseSelectMenu
+
+	// only keep the types that belong to this server
+	panel.TicketTypes = panel.TicketTypes[:0]
+	for _, id := range f.TicketTypes {
+		for _, tt := range types {
+			if tt.ID == id && !common.ContainsInt64Slice(panel.TicketTypes, id) {
+				panel.TicketTypes = append(panel.TicketTypes, id)
+				break
+			}
+		}
+	}
+}
+
+func (p *Plugin) savePanel(ctx context.Context, templateData web.TemplateData, panel *models.TicketPanel, form *TicketPanelForm, insert bool) (web.TemplateData, error) {
+	types, err := models.TicketTypes(models.TicketTypeWhere.GuildID.EQ(panel.GuildID)).AllG(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
+	oldChannel := panel.ChannelID
+	form.applyTo(panel, types)
+	if len(panel.TicketTypes) < 1 {
+		return templateData.AddAlerts(web.ErrorAlert("A panel needs at least one ticket type")), nil
+	}
+
+	if oldChannel != panel.ChannelID && panel.MessageID != 0 {
+		// moved to another channel, remove the old message
+		common.BotSession.ChannelMessageDelete(oldChannel, panel.MessageID)
+		panel.MessageID = 0
+	}
+
+	if insert {
+		err = panel.InsertG(ctx, boil.Infer())
+	} else {
+		_, err = panel.UpdateG(ctx, boil.Infer())
+	}
+	if err != nil {
+		return templateData, err
+	}
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, logKeyPanelSaved, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: panel.ID}))
+
+	err = PostPanel(ctx, panel)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed posting ticket panel")
+		return templateData.AddAlerts(web.ErrorAlert("Failed posting the panel, make sure the bot has permissions to send messages and embeds in the channel")), nil
+	}
+
+	return templateData, nil
+}
+
+func (p *Plugin) handleNewPanel(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	form := ctx.Value(common.ContextKeyParsedForm).(*TicketPanelForm)
+
+	count, err := models.TicketPanels(models.TicketPanelWhere.GuildID.EQ(activeGuild.ID)).CountG(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
+	if count >= MaxTicketPanels {
+		return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d ticket panels allowed", MaxTicketPanels))), nil
+	}
+
+	return p.savePanel(ctx, templateData, &models.TicketPanel{GuildID: activeGuild.ID}, form, true)
+}
+
+func (p *Plugin) handleUpdatePanel(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+	form := ctx.Value(common.ContextKeyParsedForm).(*TicketPanelForm)
+
+	id, _ := strconv.ParseInt(pat.Param(r, "panel"), 10, 64)
+	panel, err := models.TicketPanels(models.TicketPanelWhere.ID.EQ(id), models.TicketPanelWhere.GuildID.EQ(activeGuild.ID)).OneG(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
+	return p.savePanel(ctx, templateData, panel, form, false)
+}
+
+func (p *Plugin) handleDeletePanel(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	id, _ := strconv.ParseInt(pat.Param(r, "panel"), 10, 64)
+	panel, err := models.TicketPanels(models.TicketPanelWhere.ID.EQ(id), models.TicketPanelWhere.GuildID.EQ(activeGuild.ID)).OneG(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
+	if panel.MessageID != 0 {
+		common.BotSession.ChannelMessageDelete(panel.ChannelID, panel.MessageID)
+	}
+
+	_, err = panel.DeleteG(ctx)
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(ctx, logKeyPanelDeleted, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: id}))
+	}
 
 	return templateData, err
 }
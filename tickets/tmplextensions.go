@@ -19,6 +19,8 @@ func init() {
 	templates.RegisterSetupFunc(func(ctx *templates.Context) {
 		ctx.ContextFuncs["createTicket"] = tmplCreateTicket(ctx)
 	})
+
+	templates.RegisterSideEffectFuncs("createTicket")
 }
 
 // tmplRunCC either run another custom command immeditely with a max stack depth of 2
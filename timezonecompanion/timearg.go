@@ -0,0 +1,235 @@
+package timezonecompanion
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/commands"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/lib/dcmd"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/when"
+	"github.com/cirelion/flint/lib/when/rules"
+	"github.com/cirelion/flint/lib/when/rules/br"
+	wcommon "github.com/cirelion/flint/lib/when/rules/common"
+	"github.com/cirelion/flint/lib/when/rules/de"
+	"github.com/cirelion/flint/lib/when/rules/en"
+	"github.com/cirelion/flint/lib/when/rules/es"
+	"github.com/cirelion/flint/lib/when/rules/fr"
+	"github.com/cirelion/flint/lib/when/rules/nl"
+	"github.com/cirelion/flint/lib/when/rules/ru"
+	"github.com/cirelion/flint/timezonecompanion/trules"
+)
+
+var (
+	// TimeParser parses natural-language times such as "tomorrow at 5pm" or "next friday 19:00"
+	TimeParser *when.Parser
+
+	// localeParsers holds a parser per language, on top of the english rules, keyed by the language part of a discord locale
+	localeParsers = make(map[string]*when.Parser)
+
+	// UTCRegex matches inputs that explicitly ask for UTC instead of the registered time zone
+	UTCRegex = regexp.MustCompile(`(?i)\butc\b`)
+
+	durationRegex = regexp.MustCompile(`(?i)^(\d+\s*(s|secs?|seconds?|m|mins?|minutes?|h|hrs?|hours?|d|days?|w|wks?|weeks?|mo|mos|months?|y|yrs?|years?)?\s*)+$`)
+)
+
+func init() {
+	TimeParser = newTimeParser()
+
+	localeParsers["de"] = newTimeParser(de.All...)
+	localeParsers["es"] = newTimeParser(es.All...)
+	localeParsers["fr"] = newTimeParser(fr.All...)
+	localeParsers["nl"] = newTimeParser(nl.All...)
+	localeParsers["pt"] = newTimeParser(br.All...)
+	localeParsers["ru"] = newTimeParser(ru.All...)
+}
+
+func newTimeParser(localeRules ...rules.Rule) *when.Parser {
+	p := when.New(&rules.Options{
+		Distance:     10,
+		MatchByOrder: true})
+
+	p.Add(
+		en.Weekday(rules.Override),
+		en.CasualDate(rules.Override),
+		en.CasualTime(rules.Override),
+		trules.Hour(rules.Override),
+		trules.HourMinute(rules.Override),
+		en.Deadline(rules.Override),
+		en.ExactMonthDate(rules.Override),
+	)
+
+	// added after the english rules so they take precedence when both match
+	p.Add(localeRules...)
+	p.Add(wcommon.All...)
+	return p
+}
+
+// LocaleTimeParser returns the parser for the language of the locale (e.g "de" or "es-ES"),
+// or the english TimeParser if there are no rules for that language
+func LocaleTimeParser(locale string) *when.Parser {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexByte(lang, '-'); i != -1 {
+		lang = lang[:i]
+	}
+
+	if p, ok := localeParsers[lang]; ok {
+		return p
+	}
+
+	return TimeParser
+}
+
+// CommandLocale returns the locale times given to the command should be parsed in,
+// the user's locale for slash commands and otherwise the guild's preferred locale
+func CommandLocale(data *dcmd.Data) string {
+	if data.SlashCommandTriggerData != nil {
+		interaction := data.SlashCommandTriggerData.Interaction
+		if interaction.Locale != "" {
+			return string(interaction.Locale)
+		}
+
+		if interaction.GuildLocale != nil {
+			return string(*interaction.GuildLocale)
+		}
+	}
+
+	if data.GuildData != nil {
+		return data.GuildData.GS.PreferredLocale
+	}
+
+	return ""
+}
+
+// UserLocation returns the registered time zone of the user, or UTC if they haven't registered one or the input mentions UTC
+func UserLocation(userID int64, input string) *time.Location {
+	loc := GetUserTimezone(userID)
+	if loc == nil || UTCRegex.MatchString(input) {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// ParseTime parses the input as either a duration from now ("1h30m") or a natural-language time in the given location,
+// using the rules for the language of the locale in addition to the english ones.
+// A time of day that has already passed today refers to the same time tomorrow.
+func ParseTime(input string, now time.Time, loc *time.Location, locale string) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if durationRegex.MatchString(input) {
+		dur, err := common.ParseDuration(input)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return now.Add(dur), nil
+	}
+
+	now = now.In(loc)
+	r, err := LocaleTimeParser(locale).Parse(input, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if r == nil {
+		return time.Time{}, commands.NewUserError("Couldn't understand that time, try something like `1h30m`, `tomorrow at 5pm` or `next friday 19:00`")
+	}
+
+	t := r.Time
+	if t.Before(now) && now.Sub(t) < time.Hour*24 {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return t, nil
+}
+
+// TimeArg accepts either a duration or a natural-language time, resolved in the caller's registered time zone.
+// The parsed value is the duration from now until that time, so it can be used in place of commands.DurationArg.
+//
+// With Strict set, message arguments only accept durations, for arguments such as moderation durations where a word
+// like "now" is more likely the start of the reason. Slash command options still accept natural-language times.
+type TimeArg struct {
+	Min, Max time.Duration
+	Strict   bool
+}
+
+var _ dcmd.ArgType = (*TimeArg)(nil)
+
+func (t *TimeArg) CheckCompatibility(def *dcmd.ArgDef, part string) dcmd.CompatibilityResult {
+	if len(part) < 1 {
+		return dcmd.Incompatible
+	}
+
+	if durationRegex.MatchString(part) {
+		return dcmd.CompatibilityGood
+	}
+
+	if t.Strict {
+		return dcmd.Incompatible
+	}
+
+	// the locale of the caller isn't known here, so accept anything one of the languages understands
+	now := time.Now()
+	if r, err := TimeParser.Parse(part, now); err == nil && r != nil {
+		return dcmd.CompatibilityPoor
+	}
+
+	for _, p := range localeParsers {
+		if r, err := p.Parse(part, now); err == nil && r != nil {
+			return dcmd.CompatibilityPoor
+		}
+	}
+
+	return dcmd.Incompatible
+}
+
+func (t *TimeArg) ParseFromMessage(def *dcmd.ArgDef, part string, data *dcmd.Data) (interface{}, error) {
+	if t.Strict && !durationRegex.MatchString(strings.TrimSpace(part)) {
+		return nil, commands.NewUserError(fmt.Sprintf("%s has to be a duration, such as `1h30m` or `2 days`", def.Name))
+	}
+
+	return t.parse(def, part, data)
+}
+
+func (t *TimeArg) ParseFromInteraction(def *dcmd.ArgDef, data *dcmd.Data, options *dcmd.SlashCommandsParseOptions) (val interface{}, err error) {
+	s, err := options.ExpectString(def.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.parse(def, s, data)
+}
+
+func (t *TimeArg) parse(def *dcmd.ArgDef, input string, data *dcmd.Data) (time.Duration, error) {
+	now := time.Now()
+	parsed, err := ParseTime(input, now, UserLocation(data.Author.ID, input), CommandLocale(data))
+	if err != nil {
+		return 0, err
+	}
+
+	dur := parsed.Sub(now)
+	if dur < 0 {
+		return 0, commands.NewUserError(fmt.Sprintf("%s is in the past (<t:%d:f>)", def.Name, parsed.Unix()))
+	}
+
+	if t.Min != 0 && t.Min > dur {
+		return 0, &commands.DurationOutOfRangeError{ArgName: def.Name, Got: dur, Max: t.Max, Min: t.Min}
+	}
+
+	if t.Max != 0 && t.Max < dur {
+		return 0, &commands.DurationOutOfRangeError{ArgName: def.Name, Got: dur, Max: t.Max, Min: t.Min}
+	}
+
+	return dur, nil
+}
+
+func (t *TimeArg) HelpName() string {
+	return "Duration/Time"
+}
+
+func (t *TimeArg) SlashCommandOptions(def *dcmd.ArgDef) []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{def.StandardSlashCommandOption(discordgo.ApplicationCommandOptionString)}
+}
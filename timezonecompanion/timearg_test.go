@@ -0,0 +1,73 @@
+package timezonecompanion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/dcmd"
+)
+
+func TestParseTime(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	// wednesday
+	now := time.Date(2024, time.March, 13, 14, 0, 0, 0, loc)
+
+	cases := []struct {
+		input    string
+		locale   string
+		expected time.Time
+	}{
+		{"1h30m", "", now.Add(90 * time.Minute)},
+		{"2 days", "", now.Add(48 * time.Hour)},
+		{"10", "", now.Add(10 * time.Minute)},
+		{"tomorrow at 5pm", "", time.Date(2024, time.March, 14, 17, 0, 0, 0, loc)},
+		{"next friday 19:00", "", time.Date(2024, time.March, 15, 19, 0, 0, 0, loc)},
+		// already passed today
+		{"9am", "", time.Date(2024, time.March, 14, 9, 0, 0, 0, loc)},
+
+		// locale rules, with the english ones still working
+		{"morgen um 17 Uhr", "de", time.Date(2024, time.March, 14, 17, 0, 0, 0, loc)},
+		{"vendredi prochain à 19h", "fr", time.Date(2024, time.March, 15, 19, 0, 0, 0, loc)},
+		{"mañana a las 5 de la tarde", "es-ES", time.Date(2024, time.March, 14, 17, 0, 0, 0, loc)},
+		{"overmorgen om 9 uur", "nl", time.Date(2024, time.March, 15, 9, 0, 0, 0, loc)},
+		{"tomorrow at 5pm", "de", time.Date(2024, time.March, 14, 17, 0, 0, 0, loc)},
+	}
+
+	for _, c := range cases {
+		got, err := ParseTime(c.input, now, loc, c.locale)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", c.input, err)
+			continue
+		}
+
+		if !got.Equal(c.expected) {
+			t.Errorf("%q: expected %s, got %s", c.input, c.expected, got)
+		}
+	}
+
+	if _, err := ParseTime("whenever you feel like it", now, loc, ""); err == nil {
+		t.Error("expected an error for an input without a time")
+	}
+}
+
+func TestTimeArgCompatibility(t *testing.T) {
+	cases := []struct {
+		input    string
+		strict   bool
+		expected dcmd.CompatibilityResult
+	}{
+		{"1h30m", false, dcmd.CompatibilityGood},
+		{"1h30m", true, dcmd.CompatibilityGood},
+		{"tomorrow at 5pm", false, dcmd.CompatibilityPoor},
+		{"tomorrow at 5pm", true, dcmd.Incompatible},
+		{"now", true, dcmd.Incompatible},
+		{"spamming", false, dcmd.Incompatible},
+	}
+
+	for _, c := range cases {
+		arg := &TimeArg{Strict: c.strict}
+		if got := arg.CheckCompatibility(nil, c.input); got != c.expected {
+			t.Errorf("%q (strict %t): expected %s, got %s", c.input, c.strict, c.expected, got)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package verification
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/cirelion/flint/backup"
+	"github.com/cirelion/flint/verification/models"
+	"github.com/volatiletech/sqlboiler/boil"
+)
+
+var _ backup.PluginWithBackup = (*Plugin)(nil)
+
+func (p *Plugin) ExportBackup(ctx context.Context, guildID int64) (interface{}, error) {
+	conf, err := models.FindVerificationConfigG(ctx, guildID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return conf, err
+}
+
+func (p *Plugin) ImportBackup(ctx context.Context, ic *backup.ImportContext, data json.RawMessage) error {
+	var conf models.VerificationConfig
+	err := json.Unmarshal(data, &conf)
+	if err != nil {
+		return err
+	}
+
+	current, err := models.FindVerificationConfigG(ctx, ic.GS.ID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	conf.GuildID = ic.GS.ID
+	conf.VerifiedRole = ic.Remap.Role(conf.VerifiedRole)
+	conf.LogChannel = ic.Remap.Channel(conf.LogChannel)
+	conf.VerifyChannel = ic.Remap.Channel(conf.VerifyChannel)
+
+	// the verify message belongs to the server the backup was made on
+	conf.VerifyMessageID = 0
+	if current != nil && current.VerifyChannel == conf.VerifyChannel {
+		conf.VerifyMessageID = current.VerifyMessageID
+	}
+
+	err = conf.UpsertG(ctx, true, []string{"guild_id"}, boil.Infer(), boil.Infer())
+	if err != nil {
+		return err
+	}
+
+	if conf.Enabled && conf.Mode != VerificationModeWeb && conf.VerifyChannel != 0 {
+		err = PostVerifyPanel(ctx, &conf)
+		if err != nil {
+			ic.Warn("Verification: failed posting the verify button in the verify channel, make sure the bot has permissions to send messages there")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package verification
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"math/rand"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	CaptchaLength = 6
+	captchaWidth  = 320
+	captchaHeight = 110
+)
+
+// characters that are easy to tell apart, so no 0/O, 1/I/l and so on
+var captchaRunes = []rune("ABCDEFGHJKLMNPRSTUVWXYZ23456789")
+
+var captchaFont *opentype.Font
+
+func init() {
+	f, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		panic("failed parsing captcha font: " + err.Error())
+	}
+
+	captchaFont = f
+}
+
+// RandCaptchaCode returns a random code to be rendered by GenerateCaptcha
+func RandCaptchaCode(rng *rand.Rand) string {
+	b := make([]rune, CaptchaLength)
+	for i := range b {
+		b[i] = captchaRunes[rng.Intn(len(captchaRunes))]
+	}
+	return string(b)
+}
+
+// GenerateCaptcha renders the code as a png with each character at a random size and offset,
+// warped along a sine wave and covered with noise
+func GenerateCaptcha(code string, rng *rand.Rand) ([]byte, error) {
+	canvas := image.NewRGBA(image.Rect(0, 0, captchaWidth, captchaHeight))
+	draw.Draw(canvas, canvas.Bounds(), &image.Uniform{color.RGBA{0xf4, 0xf4, 0xf0, 0xff}}, image.Point{}, draw.Src)
+
+	// background noise
+	for i := 0; i < captchaWidth*captchaHeight/12; i++ {
+		canvas.Set(rng.Intn(captchaWidth), rng.Intn(captchaHeight), randomColor(rng, 120, 220))
+	}
+
+	step := (captchaWidth - 30) / len(code)
+	for i, r := range code {
+		face, err := opentype.NewFace(captchaFont, &opentype.FaceOptions{
+			Size:    float64(44 + rng.Intn(16)),
+			DPI:     72,
+			Hinting: font.HintingFull,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		d := &font.Drawer{
+			Dst:  canvas,
+			Src:  image.NewUniform(randomColor(rng, 0, 110)),
+			Face: face,
+			Dot:  fixed.P(15+i*step+rng.Intn(10)-5, 70+rng.Intn(24)-12),
+		}
+		d.DrawString(string(r))
+		face.Close()
+	}
+
+	// lines crossing the text
+	for i := 0; i < 4; i++ {
+		drawWave(canvas, rng, randomColor(rng, 0, 140))
+	}
+
+	warped := warp(canvas, rng)
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, warped)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func randomColor(rng *rand.Rand, min, max int) color.RGBA {
+	c := func() uint8 { return uint8(min + rng.Intn(max-min)) }
+	return color.RGBA{c(), c(), c(), 0xff}
+}
+
+// drawWave draws a 2px thick sine wave across the whole image
+func drawWave(img *image.RGBA, rng *rand.Rand, c color.RGBA) {
+	amplitude := 8 + rng.Float64()*18
+	period := 60 + rng.Float64()*120
+	phase := rng.Float64() * math.Pi * 2
+	base := 20 + rng.Float64()*(captchaHeight-40)
+
+	for x := 0; x < captchaWidth; x++ {
+		y := int(base + amplitude*math.Sin(float64(x)/period*math.Pi*2+phase))
+		img.Set(x, y, c)
+		img.Set(x, y+1, c)
+	}
+}
+
+// warp shifts the rows and columns of the image along sine waves, to make the glyphs harder to segment
+func warp(src *image.RGBA, rng *rand.Rand) *image.RGBA {
+	dst := image.NewRGBA(src.Bounds())
+
+	ampX, ampY := 3+rng.Float64()*3, 4+rng.Float64()*4
+	periodX, periodY := 40+rng.Float64()*30, 80+rng.Float64()*60
+	phaseX, phaseY := rng.Float64()*math.Pi*2, rng.Float64()*math.Pi*2
+
+	for y := 0; y < captchaHeight; y++ {
+		for x := 0; x < captchaWidth; x++ {
+			sx := x + int(ampX*math.Sin(float64(y)/periodX*math.Pi*2+phaseX))
+			sy := y + int(ampY*math.Sin(float64(x)/periodY*math.Pi*2+phaseY))
+
+			if sx < 0 || sy < 0 || sx >= captchaWidth || sy >= captchaHeight {
+				dst.Set(x, y, color.RGBA{0xf4, 0xf4, 0xf0, 0xff})
+				continue
+			}
+
+			dst.Set(x, y, src.At(sx, sy))
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,553 @@
+package verification
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/cirelion/flint/analytics"
+	"github.com/cirelion/flint/bot"
+	"github.com/cirelion/flint/bot/eventsystem"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/pubsub"
+	"github.com/cirelion/flint/common/scheduledevents2"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate"
+	"github.com/cirelion/flint/verification/models"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// The ways members can verify
+const (
+	VerificationModeWeb     = 0 // web page with google's reCAPTCHA
+	VerificationModeQuiz    = 1 // multiple choice questions answered with buttons in discord
+	VerificationModeCaptcha = 2 // image captcha answered through a modal in discord
+)
+
+const (
+	VerifyButton  = "verification_start:"
+	QuizAnswer    = "verification_quiz:"
+	CaptchaButton = "verification_captcha:"
+	CaptchaModal  = "verification_captcha_modal:"
+
+	MaxQuizQuestions = 10
+	MaxQuizAnswers   = 5
+
+	challengeExpiry = 600 // seconds
+)
+
+type QuizQuestion struct {
+	Question string
+	Answers  []string
+	Correct  int
+}
+
+// ParseQuiz parses the quiz as entered in the control panel: the question on the first line, followed by
+// one answer per line with the correct one prefixed by a *, and an empty line between questions
+func ParseQuiz(s string) ([]*QuizQuestion, error) {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	var questions []*QuizQuestion
+	for _, block := range strings.Split(s, "\n\n") {
+		lines := make([]string, 0)
+		for _, l := range strings.Split(block, "\n") {
+			if l = strings.TrimSpace(l); l != "" {
+				lines = append(lines, l)
+			}
+		}
+
+		if len(lines) < 1 {
+			continue
+		}
+
+		q := &QuizQuestion{Question: lines[0], Correct: -1}
+		if len(q.Question) > 500 {
+			return nil, errors.Errorf("Question %d is longer than 500 characters", len(questions)+1)
+		}
+
+		for _, answer := range lines[1:] {
+			if strings.HasPrefix(answer, "*") {
+				if q.Correct != -1 {
+					return nil, errors.Errorf("Question %d has more than one correct answer", len(questions)+1)
+				}
+
+				q.Correct = len(q.Answers)
+				answer = strings.TrimSpace(strings.TrimPrefix(answer, "*"))
+			}
+
+			if answer == "" || len(answer) > 80 {
+				return nil, errors.Errorf("Answers to question %d have to be between 1 and 80 characters", len(questions)+1)
+			}
+
+			q.Answers = append(q.Answers, answer)
+		}
+
+		if len(q.Answers) < 2 || len(q.Answers) > MaxQuizAnswers {
+			return nil, errors.Errorf("Question %d needs between 2 and %d answers", len(questions)+1, MaxQuizAnswers)
+		}
+
+		if q.Correct == -1 {
+			return nil, errors.Errorf("Question %d has no correct answer, mark it with a *", len(questions)+1)
+		}
+
+		questions = append(questions, q)
+	}
+
+	if len(questions) > MaxQuizQuestions {
+		return nil, errors.Errorf("Too many questions, max %d", MaxQuizQuestions)
+	}
+
+	return questions, nil
+}
+
+func quizProgressKey(guildID, userID int64) string {
+	return "verification_quiz:" + strconv.FormatInt(guildID, 10) + ":" + strconv.FormatInt(userID, 10)
+}
+
+func captchaKey(guildID, userID int64) string {
+	return "verification_captcha:" + strconv.FormatInt(guildID, 10) + ":" + strconv.FormatInt(userID, 10)
+}
+
+// VerifyButtonComponents returns the button that starts the verification inside discord
+func VerifyButtonComponents(guildID int64) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+		discordgo.Button{
+			Label:    "Verify",
+			Style:    discordgo.SuccessButton,
+			CustomID: VerifyButton + strconv.FormatInt(guildID, 10),
+		},
+	}}}
+}
+
+func sendVerifyButton(channelID int64, gs *dstate.GuildSet) error {
+	_, err := common.BotSession.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content:    fmt.Sprintf("Press the button below to verify on **%s**.", gs.Name),
+		Components: VerifyButtonComponents(gs.ID),
+	})
+	return err
+}
+
+// PostVerifyPanel sends the message with the verify button to the verify channel, or edits it if it's already there
+func PostVerifyPanel(ctx context.Context, conf *models.VerificationConfig) error {
+	msg := &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{{
+			Title:       "Verification",
+			Description: "Press the button below to verify that you're human and gain access to the server.",
+			Color:       0x49ed47,
+		}},
+		Components: VerifyButtonComponents(conf.GuildID),
+	}
+
+	if conf.VerifyMessageID != 0 {
+		_, err := common.BotSession.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:         conf.VerifyMessageID,
+			Channel:    conf.VerifyChannel,
+			Embeds:     msg.Embeds,
+			Components: msg.Components,
+		})
+		if err == nil {
+			return nil
+		}
+
+		if !common.IsDiscordErr(err, discordgo.ErrCodeUnknownMessage, discordgo.ErrCodeUnknownChannel) {
+			return err
+		}
+	}
+
+	m, err := common.BotSession.ChannelMessageSendComplex(conf.VerifyChannel, msg)
+	if err != nil {
+		return err
+	}
+
+	conf.VerifyMessageID = m.ID
+	_, err = conf.UpdateG(ctx, boil.Whitelist("verify_message_id"))
+	return err
+}
+
+// openSession returns the unsolved verification session of the user, creating one if there is none
+// (e.g they joined while verification was disabled)
+func (p *Plugin) openSession(ctx context.Context, guildID, userID int64) (*models.VerificationSession, error) {
+	session, err := models.VerificationSessions(
+		models.VerificationSessionWhere.GuildID.EQ(guildID),
+		models.VerificationSessionWhere.UserID.EQ(userID),
+		models.VerificationSessionWhere.ExpiredAt.IsNull(),
+		models.VerificationSessionWhere.SolvedAt.IsNull(),
+		qm.OrderBy("created_at desc")).OneG(ctx)
+	if err != sql.ErrNoRows {
+		return session, err
+	}
+
+	token, err := p.createVerificationSession(userID, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.FindVerificationSessionG(ctx, token)
+}
+
+// markVerified marks the session as solved and schedules the verified role to be given
+func (p *Plugin) markVerified(ctx context.Context, session *models.VerificationSession, ip string) error {
+	model := &models.VerifiedUser{
+		UserID:     session.UserID,
+		GuildID:    session.GuildID,
+		VerifiedAt: time.Now(),
+		IP:         ip,
+	}
+
+	err := model.UpsertG(ctx, true, []string{"guild_id", "user_id"}, boil.Infer(), boil.Infer())
+	if err != nil {
+		return err
+	}
+
+	err = scheduledevents2.ScheduleEvent("verification_user_verified", session.GuildID, time.Now(), session.UserID)
+	if err != nil {
+		return err
+	}
+
+	// the role is already scheduled to be given at this point, so don't fail the verification over this
+	session.SolvedAt = null.TimeFrom(time.Now())
+	_, err = session.UpdateG(ctx, boil.Infer())
+	if err != nil {
+		logger.WithError(err).WithField("guild", session.GuildID).Error("failed marking verification session as solved")
+	}
+
+	go analytics.RecordActiveUnit(session.GuildID, p, "completed")
+	return nil
+}
+
+func (p *Plugin) handleInteractionCreate(evt *eventsystem.EventData) {
+	ic := evt.InteractionCreate()
+	if ic.GuildID == 0 {
+		// DM interactions are handled via pubsub
+		return
+	}
+
+	p.handleVerificationInteraction(ic)
+}
+
+func (p *Plugin) handleDMInteraction(evt *pubsub.Event) {
+	ic := evt.Data.(*discordgo.InteractionCreate)
+	if ic.User == nil {
+		return
+	}
+
+	go p.handleVerificationInteraction(ic)
+}
+
+func (p *Plugin) handleVerificationInteraction(ic *discordgo.InteractionCreate) {
+	var customID string
+	switch ic.Type {
+	case discordgo.InteractionMessageComponent:
+		customID = ic.MessageComponentData().CustomID
+	case discordgo.InteractionModalSubmit:
+		if ic.DataModal == nil {
+			return
+		}
+		customID = ic.DataModal.CustomID
+	default:
+		return
+	}
+
+	var prefix string
+	for _, v := range []string{VerifyButton, QuizAnswer, CaptchaButton, CaptchaModal, ReviewButton} {
+		if strings.HasPrefix(customID, v) {
+			prefix = v
+			break
+		}
+	}
+
+	if prefix == "" {
+		return
+	}
+
+	args := strings.Split(strings.TrimPrefix(customID, prefix), ":")
+	guildID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || !bot.ReadyTracker.IsGuildOnProcess(guildID) {
+		return
+	}
+
+	if prefix == ReviewButton {
+		p.handleReviewInteraction(ic, guildID, args[1:])
+		return
+	}
+
+	user := ic.User
+	if ic.Member != nil {
+		user = ic.Member.User
+	}
+
+	conf, ms := p.discordVerificationTarget(ic, guildID, user)
+	if conf == nil {
+		return
+	}
+
+	switch prefix {
+	case VerifyButton:
+		p.startDiscordVerification(ic, conf, ms)
+	case QuizAnswer:
+		p.handleQuizAnswer(ic, conf, ms, args[1:])
+	case CaptchaButton:
+		sendCaptchaModal(ic, guildID)
+	case CaptchaModal:
+		p.handleCaptchaModal(ic, conf, ms)
+	}
+}
+
+// discordVerificationTarget returns the config and member for an interaction, responding with why
+// the member can't verify if they're already verified or verification is not set up for discord
+func (p *Plugin) discordVerificationTarget(ic *discordgo.InteractionCreate, guildID int64, user *discordgo.User) (*models.VerificationConfig, *dstate.MemberState) {
+	conf, err := models.FindVerificationConfigG(context.Background(), guildID)
+	if err != nil && err != sql.ErrNoRows {
+		logger.WithError(err).WithField("guild", guildID).Error("failed retrieving verification config")
+		return nil, nil
+	}
+
+	if conf == nil || !conf.Enabled || conf.Mode == VerificationModeWeb {
+		respondEphemeral(ic, "Verification through discord is not enabled on this server.")
+		return nil, nil
+	}
+
+	ms, err := bot.GetMember(guildID, user.ID)
+	if err != nil {
+		if common.IsDiscordErr(err, discordgo.ErrCodeUnknownMember) {
+			respondEphemeral(ic, "You're not a member of that server anymore.")
+			return nil, nil
+		}
+
+		logger.WithError(err).WithField("guild", guildID).Error("failed retrieving member")
+		return nil, nil
+	}
+
+	if common.ContainsInt64Slice(ms.Member.Roles, conf.VerifiedRole) {
+		respondEphemeral(ic, "You're already verified.")
+		return nil, nil
+	}
+
+	return conf, ms
+}
+
+func (p *Plugin) startDiscordVerification(ic *discordgo.InteractionCreate, conf *models.VerificationConfig, ms *dstate.MemberState) {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	switch conf.Mode {
+	case VerificationModeQuiz:
+		questions, err := ParseQuiz(conf.QuizQuestions)
+		if err != nil || len(questions) < 1 {
+			respondEphemeral(ic, "The verification quiz is not set up properly, contact the server staff.")
+			return
+		}
+
+		err = common.RedisPool.Do(radix.FlatCmd(nil, "SET", quizProgressKey(conf.GuildID, ms.User.ID), 0, "EX", challengeExpiry))
+		if err != nil {
+			logger.WithError(err).WithField("guild", conf.GuildID).Error("failed saving quiz progress")
+			return
+		}
+
+		data := quizQuestionMessage(conf.GuildID, questions, 0, rng)
+		data.Flags = uint64(discordgo.MessageFlagsEphemeral)
+		respond(ic, discordgo.InteractionResponseChannelMessageWithSource, data)
+
+	case VerificationModeCaptcha:
+		code := RandCaptchaCode(rng)
+		img, err := GenerateCaptcha(code, rng)
+		if err != nil {
+			logger.WithError(err).WithField("guild", conf.GuildID).Error("failed generating captcha")
+			return
+		}
+
+		err = common.RedisPool.Do(radix.FlatCmd(nil, "SET", captchaKey(conf.GuildID, ms.User.ID), code, "EX", challengeExpiry))
+		if err != nil {
+			logger.WithError(err).WithField("guild", conf.GuildID).Error("failed saving captcha code")
+			return
+		}
+
+		respond(ic, discordgo.InteractionResponseChannelMessageWithSource, &discordgo.InteractionResponseData{
+			Content: "Enter the characters shown in the image below. Press **Verify** again if you can't read it.",
+			Flags:   uint64(discordgo.MessageFlagsEphemeral),
+			Files:   []*discordgo.File{{Name: "captcha.png", ContentType: "image/png", Reader: bytes.NewReader(img)}},
+			Components: []discordgo.MessageComponent{discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Enter code",
+					Style:    discordgo.PrimaryButton,
+					CustomID: CaptchaButton + strconv.FormatInt(conf.GuildID, 10),
+				},
+			}}},
+		})
+	}
+}
+
+// quizQuestionMessage builds the message for a question, with the answers in random order
+func quizQuestionMessage(guildID int64, questions []*QuizQuestion, index int, rng *rand.Rand) *discordgo.InteractionResponseData {
+	q := questions[index]
+
+	buttons := make([]discordgo.MessageComponent, 0, len(q.Answers))
+	for _, i := range rng.Perm(len(q.Answers)) {
+		buttons = append(buttons, discordgo.Button{
+			Label:    q.Answers[i],
+			Style:    discordgo.SecondaryButton,
+			CustomID: fmt.Sprintf("%s%d:%d:%d", QuizAnswer, guildID, index, i),
+		})
+	}
+
+	return &discordgo.InteractionResponseData{
+		Content:    fmt.Sprintf("**Question %d/%d**\n%s", index+1, len(questions), q.Question),
+		Components: []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}},
+	}
+}
+
+func (p *Plugin) handleQuizAnswer(ic *discordgo.InteractionCreate, conf *models.VerificationConfig, ms *dstate.MemberState, args []string) {
+	if len(args) != 2 {
+		return
+	}
+
+	index, err1 := strconv.Atoi(args[0])
+	answer, err2 := strconv.Atoi(args[1])
+	if err1 != nil || err2 != nil {
+		return
+	}
+
+	var progress string
+	key := quizProgressKey(conf.GuildID, ms.User.ID)
+	err := common.RedisPool.Do(radix.Cmd(&progress, "GET", key))
+	if err != nil {
+		logger.WithError(err).WithField("guild", conf.GuildID).Error("failed retrieving quiz progress")
+		return
+	}
+
+	questions, err := ParseQuiz(conf.QuizQuestions)
+	if progress != strconv.Itoa(index) || err != nil || index >= len(questions) {
+		// expired, answered out of order or the questions were changed in the meantime
+		respondUpdate(ic, "This quiz has expired, press **Verify** to start again.")
+		return
+	}
+
+	if questions[index].Correct != answer {
+		common.RedisPool.Do(radix.Cmd(nil, "DEL", key))
+		respondUpdate(ic, "That's not the right answer. Read the rules again and press **Verify** to retry.")
+		return
+	}
+
+	if index+1 < len(questions) {
+		err = common.RedisPool.Do(radix.FlatCmd(nil, "SET", key, index+1, "EX", challengeExpiry))
+		if err != nil {
+			logger.WithError(err).WithField("guild", conf.GuildID).Error("failed saving quiz progress")
+			return
+		}
+
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+		respond(ic, discordgo.InteractionResponseUpdateMessage, quizQuestionMessage(conf.GuildID, questions, index+1, rng))
+		return
+	}
+
+	common.RedisPool.Do(radix.Cmd(nil, "DEL", key))
+	if p.completeDiscordVerification(conf, ms) {
+		respondUpdate(ic, "You have been verified, welcome!")
+	} else {
+		respondUpdate(ic, "Something went wrong, try again later.")
+	}
+}
+
+func sendCaptchaModal(ic *discordgo.InteractionCreate, guildID int64) {
+	respond(ic, discordgo.InteractionResponseModal, &discordgo.InteractionResponseData{
+		CustomID: CaptchaModal + strconv.FormatInt(guildID, 10),
+		Title:    "Verification",
+		Components: []discordgo.MessageComponent{discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.TextInput{
+				CustomID:  "code",
+				Label:     "The characters in the image",
+				Style:     discordgo.TextInputShort,
+				Required:  true,
+				MinLength: CaptchaLength,
+				MaxLength: CaptchaLength * 2,
+			},
+		}}},
+	})
+}
+
+func (p *Plugin) handleCaptchaModal(ic *discordgo.InteractionCreate, conf *models.VerificationConfig, ms *dstate.MemberState) {
+	if len(ic.DataModal.Components) < 1 {
+		return
+	}
+
+	row, ok := ic.DataModal.Components[0].(*discordgo.ActionsRow)
+	if !ok || len(row.Components) < 1 {
+		return
+	}
+
+	input, ok := row.Components[0].(*discordgo.TextInput)
+	if !ok {
+		return
+	}
+
+	var code string
+	key := captchaKey(conf.GuildID, ms.User.ID)
+	err := common.RedisPool.Do(radix.Cmd(&code, "GET", key))
+	if err != nil {
+		logger.WithError(err).WithField("guild", conf.GuildID).Error("failed retrieving captcha code")
+		return
+	}
+
+	// only one attempt per image
+	common.RedisPool.Do(radix.Cmd(nil, "DEL", key))
+
+	if code == "" {
+		respondEphemeral(ic, "This captcha has expired, press **Verify** to get a new one.")
+		return
+	}
+
+	if !strings.EqualFold(strings.ReplaceAll(input.Value, " ", ""), code) {
+		respondEphemeral(ic, "That's not the right code, press **Verify** to get a new one.")
+		return
+	}
+
+	if p.completeDiscordVerification(conf, ms) {
+		respondEphemeral(ic, "You have been verified, welcome!")
+	} else {
+		respondEphemeral(ic, "Something went wrong, try again later.")
+	}
+}
+
+func (p *Plugin) completeDiscordVerification(conf *models.VerificationConfig, ms *dstate.MemberState) bool {
+	ctx := context.Background()
+	session, err := p.openSession(ctx, conf.GuildID, ms.User.ID)
+	if err == nil {
+		err = p.markVerified(ctx, session, "")
+	}
+
+	if err != nil {
+		logger.WithError(err).WithField("guild", conf.GuildID).WithField("user", ms.User.ID).Error("failed verifying user")
+		return false
+	}
+
+	return true
+}
+
+func respond(ic *discordgo.InteractionCreate, typ discordgo.InteractionResponseType, data *discordgo.InteractionResponseData) {
+	err := common.BotSession.CreateInteractionResponse(ic.ID, ic.Token, &discordgo.InteractionResponse{Type: typ, Data: data})
+	if err != nil {
+		logger.WithError(err).WithField("guild", ic.GuildID).Error("failed responding to verification interaction")
+	}
+}
+
+func respondEphemeral(ic *discordgo.InteractionCreate, content string) {
+	respond(ic, discordgo.InteractionResponseChannelMessageWithSource, &discordgo.InteractionResponseData{
+		Content: content,
+		Flags:   uint64(discordgo.MessageFlagsEphemeral),
+	})
+}
+
+func respondUpdate(ic *discordgo.InteractionCreate, content string) {
+	respond(ic, discordgo.InteractionResponseUpdateMessage, &discordgo.InteractionResponseData{
+		Content:    content,
+		Components: []discordgo.MessageComponent{},
+	})
+}
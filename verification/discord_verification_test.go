@@ -0,0 +1,62 @@
+package verification
+
+import (
+	"bytes"
+	"image/png"
+	"math/rand"
+	"testing"
+)
+
+func TestParseQuiz(t *testing.T) {
+	questions, err := ParseQuiz("Can you advertise?\r\nYes\r\n* No\r\n\r\n\r\nWhere do you ask for help?\n*#help\n#general\n#memes\n")
+	if err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+
+	if len(questions) != 2 {
+		t.Fatalf("expected 2 questions, got %d", len(questions))
+	}
+
+	if q := questions[0]; q.Question != "Can you advertise?" || len(q.Answers) != 2 || q.Correct != 1 || q.Answers[1] != "No" {
+		t.Errorf("unexpected first question: %#v", q)
+	}
+
+	if q := questions[1]; len(q.Answers) != 3 || q.Correct != 0 || q.Answers[0] != "#help" {
+		t.Errorf("unexpected second question: %#v", q)
+	}
+
+	invalid := []string{
+		"Question\nOnly one answer\n",
+		"Question\nA\nB\n",
+		"Question\n*A\n*B\n",
+		"Question\n*A\nB\nC\nD\nE\nF\n",
+	}
+
+	for i, v := range invalid {
+		if _, err := ParseQuiz(v); err == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+	}
+}
+
+func TestGenerateCaptcha(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	code := RandCaptchaCode(rng)
+	if len(code) != CaptchaLength {
+		t.Fatalf("expected code of length %d, got %q", CaptchaLength, code)
+	}
+
+	b, err := GenerateCaptcha(code, rng)
+	if err != nil {
+		t.Fatal("failed generating captcha: ", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal("failed decoding captcha: ", err)
+	}
+
+	if size := img.Bounds().Size(); size.X != captchaWidth || size.Y != captchaHeight {
+		t.Errorf("unexpected captcha size: %v", size)
+	}
+}
@@ -32,6 +32,11 @@ type VerificationConfig struct {
 	WarnMessage         string `boil:"warn_message" json:"warn_message" toml:"warn_message" yaml:"warn_message"`
 	LogChannel          int64  `boil:"log_channel" json:"log_channel" toml:"log_channel" yaml:"log_channel"`
 	DMMessage           string `boil:"dm_message" json:"dm_message" toml:"dm_message" yaml:"dm_message"`
+	Mode                int    `boil:"mode" json:"mode" toml:"mode" yaml:"mode"`
+	QuizQuestions       string `boil:"quiz_questions" json:"quiz_questions" toml:"quiz_questions" yaml:"quiz_questions"`
+	VerifyChannel       int64  `boil:"verify_channel" json:"verify_channel" toml:"verify_channel" yaml:"verify_channel"`
+	VerifyMessageID     int64  `boil:"verify_message_id" json:"verify_message_id" toml:"verify_message_id" yaml:"verify_message_id"`
+	RiskReviewThreshold int    `boil:"risk_review_threshold" json:"risk_review_threshold" toml:"risk_review_threshold" yaml:"risk_review_threshold"`
 
 	R *verificationConfigR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L verificationConfigL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -47,6 +52,11 @@ var VerificationConfigColumns = struct {
 	WarnMessage         string
 	LogChannel          string
 	DMMessage           string
+	Mode                string
+	QuizQuestions       string
+	VerifyChannel       string
+	VerifyMessageID     string
+	RiskReviewThreshold string
 }{
 	GuildID:             "guild_id",
 	Enabled:             "enabled",
@@ -57,6 +67,11 @@ var VerificationConfigColumns = struct {
 	WarnMessage:         "warn_message",
 	LogChannel:          "log_channel",
 	DMMessage:           "dm_message",
+	Mode:                "mode",
+	QuizQuestions:       "quiz_questions",
+	VerifyChannel:       "verify_channel",
+	VerifyMessageID:     "verify_message_id",
+	RiskReviewThreshold: "risk_review_threshold",
 }
 
 // Generated where
@@ -107,6 +122,11 @@ var VerificationConfigWhere = struct {
 	WarnMessage         whereHelperstring
 	LogChannel          whereHelperint64
 	DMMessage           whereHelperstring
+	Mode                whereHelperint
+	QuizQuestions       whereHelperstring
+	VerifyChannel       whereHelperint64
+	VerifyMessageID     whereHelperint64
+	RiskReviewThreshold whereHelperint
 }{
 	GuildID:             whereHelperint64{field: "\"verification_configs\".\"guild_id\""},
 	Enabled:             whereHelperbool{field: "\"verification_configs\".\"enabled\""},
@@ -117,6 +137,11 @@ var VerificationConfigWhere = struct {
 	WarnMessage:         whereHelperstring{field: "\"verification_configs\".\"warn_message\""},
 	LogChannel:          whereHelperint64{field: "\"verification_configs\".\"log_channel\""},
 	DMMessage:           whereHelperstring{field: "\"verification_configs\".\"dm_message\""},
+	Mode:                whereHelperint{field: "\"verification_configs\".\"mode\""},
+	QuizQuestions:       whereHelperstring{field: "\"verification_configs\".\"quiz_questions\""},
+	VerifyChannel:       whereHelperint64{field: "\"verification_configs\".\"verify_channel\""},
+	VerifyMessageID:     whereHelperint64{field: "\"verification_configs\".\"verify_message_id\""},
+	RiskReviewThreshold: whereHelperint{field: "\"verification_configs\".\"risk_review_threshold\""},
 }
 
 // VerificationConfigRels is where relationship names are stored.
@@ -136,9 +161,9 @@ func (*verificationConfigR) NewStruct() *verificationConfigR {
 type verificationConfigL struct{}
 
 var (
-	verificationConfigAllColumns            = []string{"guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message"}
+	verificationConfigAllColumns            = []string{"guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message", "mode", "quiz_questions", "verify_channel", "verify_message_id", "risk_review_threshold"}
 	verificationConfigColumnsWithoutDefault = []string{"guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel"}
-	verificationConfigColumnsWithDefault    = []string{"dm_message"}
+	verificationConfigColumnsWithDefault    = []string{"dm_message", "mode", "quiz_questions", "verify_channel", "verify_message_id", "risk_review_threshold"}
 	verificationConfigPrimaryKeyColumns     = []string{"guild_id"}
 )
 
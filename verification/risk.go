@@ -0,0 +1,378 @@
+package verification
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cirelion/flint/bot"
+	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/lib/confusables"
+	"github.com/cirelion/flint/lib/discordgo"
+	"github.com/cirelion/flint/lib/dstate"
+	"github.com/cirelion/flint/lib/jarowinkler"
+	"github.com/cirelion/flint/moderation"
+	"github.com/cirelion/flint/verification/models"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+const (
+	ReviewButton = "verification_review:"
+
+	MaxRiskScore = 100
+
+	// how long and how many bans are kept around to compare usernames against
+	recentBansMaxAge = time.Hour * 24 * 30
+	recentBansMax    = 500
+
+	usernameSimilarityThreshold = 0.9
+	maxSubnetCandidates         = 10
+
+	// a member is not posted for review again while their review is pending, up to this long
+	pendingReviewMaxAge = time.Hour * 24 * 7
+)
+
+// RiskAssessment is how likely a member is to be an alt of a banned user, or otherwise unwanted
+type RiskAssessment struct {
+	Score   int
+	Reasons []string
+}
+
+func (r *RiskAssessment) add(score int, reason string) {
+	r.Score += score
+	if r.Score > MaxRiskScore {
+		r.Score = MaxRiskScore
+	}
+
+	r.Reasons = append(r.Reasons, fmt.Sprintf("+%d %s", score, reason))
+}
+
+func (r *RiskAssessment) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Risk score: **%d/%d**", r.Score, MaxRiskScore)
+	for _, v := range r.Reasons {
+		b.WriteString("\n- " + v)
+	}
+
+	return b.String()
+}
+
+// assessAccount adds the signals that only depend on the account itself
+func assessAccount(r *RiskAssessment, user *discordgo.User, now time.Time) {
+	age := now.Sub(bot.SnowflakeToTime(user.ID))
+	switch {
+	case age < time.Hour*24:
+		r.add(30, "account created less than a day ago")
+	case age < time.Hour*24*7:
+		r.add(20, "account created less than a week ago")
+	case age < time.Hour*24*30:
+		r.add(10, "account created less than a month ago")
+	}
+
+	if user.Avatar == "" {
+		r.add(15, "default avatar")
+	}
+}
+
+func normalizeUsername(name string) string {
+	return strings.ToLower(confusables.SanitizeText(name))
+}
+
+// similarBannedName returns the banned name most similar to the username, if above the similarity threshold
+func similarBannedName(username string, banned []string) (string, float64) {
+	normalized := []rune(normalizeUsername(username))
+
+	best, bestSimilarity := "", 0.0
+	for _, v := range banned {
+		similarity := jarowinkler.Similarity(normalized, []rune(normalizeUsername(v)))
+		if similarity > bestSimilarity {
+			best, bestSimilarity = v, similarity
+		}
+	}
+
+	if bestSimilarity < usernameSimilarityThreshold {
+		return "", 0
+	}
+
+	return best, bestSimilarity
+}
+
+// ipv4SubnetPrefix returns the /24 prefix of the ip as stored in verified_users, e.g "10.0.0."
+func ipv4SubnetPrefix(ip string) string {
+	parsed := net.ParseIP(ip).To4()
+	if parsed == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d.%d.%d.", parsed[0], parsed[1], parsed[2])
+}
+
+func recentBansKey(guildID int64) string {
+	return "verification_recent_bans:" + strconv.FormatInt(guildID, 10)
+}
+
+// recordRecentBan keeps track of the usernames of recently banned users, to compare new members against
+func recordRecentBan(guildID int64, user *discordgo.User) {
+	key := recentBansKey(guildID)
+	now := time.Now()
+
+	err := common.RedisPool.Do(radix.Pipeline(
+		radix.FlatCmd(nil, "ZADD", key, now.Unix(), strconv.FormatInt(user.ID, 10)+":"+user.Username),
+		radix.FlatCmd(nil, "ZREMRANGEBYSCORE", key, "-inf", now.Add(-recentBansMaxAge).Unix()),
+		radix.FlatCmd(nil, "ZREMRANGEBYRANK", key, 0, -recentBansMax-1),
+		radix.FlatCmd(nil, "EXPIRE", key, int(recentBansMaxAge.Seconds())),
+	))
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed recording recent ban")
+	}
+}
+
+func recentBannedNames(guildID int64) ([]string, error) {
+	var members []string
+	err := common.RedisPool.Do(radix.Cmd(&members, "ZRANGE", recentBansKey(guildID), "0", "-1"))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(members))
+	for _, v := range members {
+		if i := strings.IndexByte(v, ':'); i != -1 {
+			names = append(names, v[i+1:])
+		}
+	}
+
+	return names, nil
+}
+
+// assessRisk scores the user, ip is empty if unknown (e.g they just joined or verified inside discord)
+func (p *Plugin) assessRisk(guildID int64, user *discordgo.User, ip string) (*RiskAssessment, error) {
+	r := &RiskAssessment{}
+	assessAccount(r, user, time.Now())
+
+	names, err := recentBannedNames(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name, similarity := similarBannedName(user.Username, names); name != "" {
+		r.add(30, fmt.Sprintf("username %.0f%% similar to recently banned `%s`", similarity*100, name))
+	}
+
+	if ip == "" || !confVerificationTrackIPs.GetBool() {
+		return r, nil
+	}
+
+	conflicts, err := p.findIPConflicts(guildID, user.ID, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(conflicts) > 0 {
+		ban, err := p.CheckBanned(guildID, conflicts)
+		if err != nil {
+			return nil, err
+		}
+
+		if ban != nil {
+			r.add(50, fmt.Sprintf("same IP as banned user %s (%d)", ban.User.String(), ban.User.ID))
+			return r, nil
+		}
+
+		r.add(15, fmt.Sprintf("same IP as %d other verified users", len(conflicts)))
+	}
+
+	prefix := ipv4SubnetPrefix(ip)
+	if prefix == "" {
+		return r, nil
+	}
+
+	neighbours, err := models.VerifiedUsers(
+		models.VerifiedUserWhere.GuildID.EQ(guildID),
+		models.VerifiedUserWhere.UserID.NEQ(user.ID),
+		models.VerifiedUserWhere.IP.NEQ(ip),
+		qm.Where("ip LIKE ?", prefix+"%"),
+		qm.OrderBy("verified_at desc"),
+		qm.Limit(maxSubnetCandidates)).AllG(context.Background())
+	if err != nil || len(neighbours) < 1 {
+		return r, err
+	}
+
+	userIDs := make([]int64, len(neighbours))
+	for i, v := range neighbours {
+		userIDs[i] = v.UserID
+	}
+
+	ban, err := p.CheckBanned(guildID, bot.GetUsers(guildID, userIDs...))
+	if err != nil {
+		return nil, err
+	}
+
+	if ban != nil {
+		r.add(25, fmt.Sprintf("same /24 subnet as banned user %s (%d)", ban.User.String(), ban.User.ID))
+	}
+
+	return r, nil
+}
+
+func pendingReviewKey(guildID, userID int64) string {
+	return "verification_pending_review:" + strconv.FormatInt(guildID, 10) + ":" + strconv.FormatInt(userID, 10)
+}
+
+// sendRiskReview posts the member to the review queue in the log channel, where staff can approve, kick or ban them.
+// Only one review is posted per member until it's handled.
+func (p *Plugin) sendRiskReview(conf *models.VerificationConfig, ms *dstate.MemberState, risk *RiskAssessment) error {
+	var set string
+	err := common.RedisPool.Do(radix.FlatCmd(&set, "SET", pendingReviewKey(conf.GuildID, ms.User.ID), 1, "EX", int(pendingReviewMaxAge.Seconds()), "NX"))
+	if err != nil {
+		return err
+	}
+
+	if set != "OK" {
+		// already waiting for review
+		return nil
+	}
+
+	customID := func(action string) string {
+		return fmt.Sprintf("%s%d:%d:%s", ReviewButton, conf.GuildID, ms.User.ID, action)
+	}
+
+	_, err = common.BotSession.ChannelMessageSendComplex(conf.LogChannel, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{{
+			Author: &discordgo.MessageEmbedAuthor{
+				IconURL: ms.User.AvatarURL("128"),
+				Name:    fmt.Sprintf("%s (%d)", ms.User.String(), ms.User.ID),
+			},
+			Title:       "Verification needs review",
+			Description: risk.String(),
+			Color:       0xff8228,
+		}},
+		Components: []discordgo.MessageComponent{discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.Button{Label: "Approve", Style: discordgo.SuccessButton, CustomID: customID("approve")},
+			discordgo.Button{Label: "Kick", Style: discordgo.SecondaryButton, CustomID: customID("kick")},
+			discordgo.Button{Label: "Ban", Style: discordgo.DangerButton, CustomID: customID("ban")},
+		}}},
+	})
+	if err != nil {
+		common.RedisPool.Do(radix.Cmd(nil, "DEL", pendingReviewKey(conf.GuildID, ms.User.ID)))
+	}
+
+	return err
+}
+
+func (p *Plugin) handleReviewInteraction(ic *discordgo.InteractionCreate, guildID int64, args []string) {
+	if ic.Member == nil || len(args) != 2 {
+		return
+	}
+
+	userID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return
+	}
+
+	action := args[1]
+	var perm int64
+	switch action {
+	case "approve":
+		perm = discordgo.PermissionManageRoles
+	case "kick":
+		perm = discordgo.PermissionKickMembers
+	case "ban":
+		perm = discordgo.PermissionBanMembers
+	default:
+		return
+	}
+
+	ok, err := bot.AdminOrPermMS(guildID, ic.ChannelID, dstate.MemberStateFromMember(ic.Member), perm)
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed checking permissions for verification review")
+		return
+	}
+
+	if !ok {
+		respondEphemeral(ic, "You don't have permission to do that.")
+		return
+	}
+
+	conf, err := models.FindVerificationConfigG(context.Background(), guildID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed retrieving verification config")
+		return
+	}
+
+	var target *discordgo.User
+	if ms, err := bot.GetMember(guildID, userID); err == nil {
+		target = &ms.User
+	} else if action == "ban" {
+		// they can still be banned after leaving
+		target, err = common.BotSession.User(userID)
+		if err != nil {
+			logger.WithError(err).WithField("guild", guildID).Error("failed retrieving user for verification review")
+			return
+		}
+	} else {
+		p.finishReview(ic, guildID, userID, "Member left the server")
+		return
+	}
+
+	var result string
+	switch action {
+	case "approve":
+		err = p.approveReview(conf, guildID, userID)
+		result = "Approved"
+	case "kick":
+		err = common.BotSession.GuildMemberDelete(guildID, userID)
+		result = "Kicked"
+	case "ban":
+		err = moderation.BanUser(nil, guildID, nil, nil, ic.Member.User, "Rejected in verification review", "", target)
+		result = "Banned"
+	}
+
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed applying verification review")
+		respondEphemeral(ic, "Failed: "+common.CutStringShort(err.Error(), 1000))
+		return
+	}
+
+	p.finishReview(ic, guildID, userID, fmt.Sprintf("%s by %s", result, ic.Member.User.String()))
+}
+
+// approveReview completes the verification the same way as for members not needing review
+func (p *Plugin) approveReview(conf *models.VerificationConfig, guildID, userID int64) error {
+	ms, err := bot.GetMember(guildID, userID)
+	if err != nil {
+		return err
+	}
+
+	model, err := models.FindVerifiedUserG(context.Background(), guildID, userID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return err
+		}
+
+		// approved without having verified, nothing to check alts against
+		model = &models.VerifiedUser{GuildID: guildID, UserID: userID}
+	}
+
+	_, err = p.completeVerification(ms, guildID, conf, model)
+	return err
+}
+
+// finishReview removes the buttons from the review message and notes what happened
+func (p *Plugin) finishReview(ic *discordgo.InteractionCreate, guildID, userID int64, result string) {
+	common.RedisPool.Do(radix.Cmd(nil, "DEL", pendingReviewKey(guildID, userID)))
+
+	embeds := ic.Message.Embeds
+	if len(embeds) > 0 {
+		embeds[0].Fields = append(embeds[0].Fields, &discordgo.MessageEmbedField{Name: "Result", Value: result})
+	}
+
+	respond(ic, discordgo.InteractionResponseUpdateMessage, &discordgo.InteractionResponseData{
+		Embeds:     embeds,
+		Components: []discordgo.MessageComponent{},
+	})
+}
@@ -0,0 +1,53 @@
+package verification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirelion/flint/lib/discordgo"
+)
+
+func TestAssessAccount(t *testing.T) {
+	now := time.Now()
+	snowflake := func(created time.Time) int64 {
+		return (created.UnixNano()/int64(time.Millisecond) - 1420070400000) << 22
+	}
+
+	cases := []struct {
+		user     *discordgo.User
+		expected int
+	}{
+		{&discordgo.User{ID: snowflake(now.Add(-time.Hour)), Avatar: ""}, 45},
+		{&discordgo.User{ID: snowflake(now.Add(-time.Hour * 24 * 3)), Avatar: "abc"}, 20},
+		{&discordgo.User{ID: snowflake(now.Add(-time.Hour * 24 * 20)), Avatar: ""}, 25},
+		{&discordgo.User{ID: snowflake(now.Add(-time.Hour * 24 * 365)), Avatar: "abc"}, 0},
+	}
+
+	for i, c := range cases {
+		r := &RiskAssessment{}
+		assessAccount(r, c.user, now)
+		if r.Score != c.expected {
+			t.Errorf("case %d: expected score %d, got %d (%v)", i, c.expected, r.Score, r.Reasons)
+		}
+	}
+}
+
+func TestSimilarBannedName(t *testing.T) {
+	banned := []string{"spammer_bot", "nicegirl420"}
+
+	if name, _ := similarBannedName("Spammer_Bot2", banned); name != "spammer_bot" {
+		t.Errorf("expected a match with spammer_bot, got %q", name)
+	}
+
+	if name, _ := similarBannedName("jonas", banned); name != "" {
+		t.Errorf("expected no match, got %q", name)
+	}
+
+	if prefix := ipv4SubnetPrefix("192.168.4.20"); prefix != "192.168.4." {
+		t.Errorf("unexpected subnet prefix %q", prefix)
+	}
+
+	if prefix := ipv4SubnetPrefix("2001:db8::1"); prefix != "" {
+		t.Errorf("expected no subnet prefix for ipv6, got %q", prefix)
+	}
+}
@@ -19,6 +19,16 @@ CREATE TABLE IF NOT EXISTS verification_configs  (
 `, `
 ALTER TABLE verification_configs ADD COLUMN IF NOT EXISTS dm_message TEXT NOT NULL DEFAULT '';
 `, `
+ALTER TABLE verification_configs ADD COLUMN IF NOT EXISTS mode INT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE verification_configs ADD COLUMN IF NOT EXISTS quiz_questions TEXT NOT NULL DEFAULT '';
+`, `
+ALTER TABLE verification_configs ADD COLUMN IF NOT EXISTS verify_channel BIGINT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE verification_configs ADD COLUMN IF NOT EXISTS verify_message_id BIGINT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE verification_configs ADD COLUMN IF NOT EXISTS risk_review_threshold INT NOT NULL DEFAULT 0;
+`, `
 CREATE TABLE IF NOT EXISTS verification_sessions  (
 	token TEXT PRIMARY KEY,
 	user_id BIGINT NOT NULL,
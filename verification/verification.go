@@ -5,6 +5,7 @@ package verification
 import (
 	"github.com/cirelion/flint/common"
 	"github.com/cirelion/flint/common/config"
+	"github.com/cirelion/flint/verification/models"
 )
 
 var confGoogleReCAPTCHASiteKey = config.RegisterOption("yagpdb.google.recaptcha_site_key", "Google reCAPTCHA site key", "")
@@ -25,9 +26,8 @@ var logger = common.GetPluginLogger(&Plugin{})
 
 func RegisterPlugin() {
 
-	if confGoogleReCAPTCHASecret.GetString() == "" || confGoogleReCAPTCHASiteKey.GetString() == "" {
-		logger.Warn("no YAGPDB_GOOGLE_RECAPTCHA_SECRET and/or YAGPDB_GOOGLE_RECAPTCHA_SITE_KEY provided, not enabling verification plugin")
-		return
+	if !reCAPTCHAAvailable() {
+		logger.Warn("no YAGPDB_GOOGLE_RECAPTCHA_SECRET and/or YAGPDB_GOOGLE_RECAPTCHA_SITE_KEY provided, only in-discord verification will be available")
 	}
 
 	common.InitSchemas("verification", DBSchemas...)
@@ -35,6 +35,10 @@ func RegisterPlugin() {
 	common.RegisterPlugin(&Plugin{})
 }
 
+func reCAPTCHAAvailable() bool {
+	return confGoogleReCAPTCHASecret.GetString() != "" && confGoogleReCAPTCHASiteKey.GetString() != ""
+}
+
 const (
 	DefaultPageContent = `## Verification
 
@@ -45,3 +49,16 @@ const DefaultDMMessage = `{{sendMessage nil (cembed
 "title" "Are you a bot?"
 "description" (printf "Please solve the CAPTCHA at this link to make sure you're human, before you can enter %s: %s" .Server.Name .Link)
 )}}`
+
+const DefaultDiscordDMMessage = `{{sendMessage nil (cembed
+"title" "Are you a bot?"
+"description" (printf "Please press the Verify button below to make sure you're human, before you can enter %s" .Server.Name)
+)}}`
+
+func defaultDMMessage(conf *models.VerificationConfig) string {
+	if conf.Mode == VerificationModeWeb {
+		return DefaultDMMessage
+	}
+
+	return DefaultDiscordDMMessage
+}
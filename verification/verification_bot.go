@@ -16,6 +16,7 @@ import (
 	"github.com/cirelion/flint/bot"
 	"github.com/cirelion/flint/bot/eventsystem"
 	"github.com/cirelion/flint/common"
+	"github.com/cirelion/flint/common/pubsub"
 	"github.com/cirelion/flint/common/scheduledevents2"
 	seventsmodels "github.com/cirelion/flint/common/scheduledevents2/models"
 	"github.com/cirelion/flint/common/templates"
@@ -42,6 +43,8 @@ func (p *Plugin) BotInit() {
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleMemberJoin, eventsystem.EventGuildMemberAdd)
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleMemberUpdate, eventsystem.EventGuildMemberUpdate)
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleBanAdd, eventsystem.EventGuildBanAdd)
+	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleInteractionCreate, eventsystem.EventInteractionCreate)
+	pubsub.AddHandler("dm_interaction", p.handleDMInteraction, discordgo.InteractionCreate{})
 	scheduledevents2.RegisterHandler("verification_user_verified", int64(0), ScheduledEventMW(p.handleUserVerifiedScheduledEvent))
 	scheduledevents2.RegisterHandler("verification_user_warn", VerificationEventData{}, ScheduledEventMW(p.handleWarnUserVerification))
 	scheduledevents2.RegisterHandler("verification_user_kick", VerificationEventData{}, ScheduledEventMW(p.handleKickUser))
@@ -204,7 +207,7 @@ func (p *Plugin) startVerificationProcess(conf *models.VerificationConfig, guild
 
 	msg := conf.DMMessage
 	if strings.TrimSpace(msg) == "" {
-		msg = DefaultDMMessage
+		msg = defaultDMMessage(conf)
 	}
 
 	ms, err := bot.GetMember(guildID, target.ID)
@@ -230,6 +233,13 @@ func (p *Plugin) startVerificationProcess(conf *models.VerificationConfig, guild
 		logger.WithError(err).WithField("guild", gs.ID).WithField("user", ms.User.ID).Error("failed sending verification dm message")
 	}
 
+	if conf.Mode != VerificationModeWeb {
+		err = sendVerifyButton(channel.ID, gs)
+		if err != nil && !common.IsDiscordErr(err, discordgo.ErrCodeCannotSendMessagesToThisUser) {
+			logger.WithError(err).WithField("guild", gs.ID).WithField("user", ms.User.ID).Error("failed sending verify button")
+		}
+	}
+
 	evt := &VerificationEventData{
 		UserID: target.ID,
 		Token:  token,
@@ -247,7 +257,17 @@ func (p *Plugin) startVerificationProcess(conf *models.VerificationConfig, guild
 		scheduledevents2.ScheduleEvent("verification_user_kick", guildID, time.Now().Add(time.Minute*time.Duration(conf.KickUnverifiedAfter)), evt)
 	}
 
-	p.logAction(guildID, conf.LogChannel, target, "New user joined waiting to be verified as a human", 0x47aaed)
+	logMsg := "New user joined waiting to be verified as a human"
+	if conf.RiskReviewThreshold > 0 {
+		risk, err := p.assessRisk(guildID, target, "")
+		if err != nil {
+			logger.WithError(err).WithField("guild", guildID).WithField("user", target.ID).Error("failed assessing risk")
+		} else {
+			logMsg += "\n\n" + risk.String()
+		}
+	}
+
+	p.logAction(guildID, conf.LogChannel, target, logMsg, 0x47aaed)
 }
 
 func ScheduledEventMW(innerHandler func(ms *dstate.MemberState, guildID int64, conf *models.VerificationConfig, rawData interface{}) (bool, error)) func(evt *seventsmodels.ScheduledEvent, data interface{}) (retry bool, err error) {
@@ -284,11 +304,6 @@ func ScheduledEventMW(innerHandler func(ms *dstate.MemberState, guildID int64, c
 }
 
 func (p *Plugin) handleUserVerifiedScheduledEvent(ms *dstate.MemberState, guildID int64, conf *models.VerificationConfig, rawData interface{}) (retry bool, err error) {
-	err = common.BotSession.GuildMemberRoleAdd(guildID, ms.User.ID, conf.VerifiedRole)
-	if err != nil {
-		return scheduledevents2.CheckDiscordErrRetry(err), err
-	}
-
 	model, err := models.FindVerifiedUserG(context.Background(), guildID, ms.User.ID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -297,6 +312,34 @@ func (p *Plugin) handleUserVerifiedScheduledEvent(ms *dstate.MemberState, guildI
 		return scheduledevents2.CheckDiscordErrRetry(err), err
 	}
 
+	if conf.RiskReviewThreshold > 0 && conf.LogChannel != 0 {
+		risk, err := p.assessRisk(guildID, &ms.User, model.IP)
+		if err != nil {
+			return scheduledevents2.CheckDiscordErrRetry(err), err
+		}
+
+		if risk.Score >= conf.RiskReviewThreshold {
+			// let the staff decide instead, the kick timer no longer applies
+			err = p.clearScheduledEvents(context.Background(), guildID, ms.User.ID)
+			if err != nil {
+				return true, err
+			}
+
+			err = p.sendRiskReview(conf, ms, risk)
+			return scheduledevents2.CheckDiscordErrRetry(err), err
+		}
+	}
+
+	return p.completeVerification(ms, guildID, conf, model)
+}
+
+// completeVerification gives the verified role and checks for alts, used for verified users not needing review and approved reviews
+func (p *Plugin) completeVerification(ms *dstate.MemberState, guildID int64, conf *models.VerificationConfig, model *models.VerifiedUser) (retry bool, err error) {
+	err = common.BotSession.GuildMemberRoleAdd(guildID, ms.User.ID, conf.VerifiedRole)
+	if err != nil {
+		return scheduledevents2.CheckDiscordErrRetry(err), err
+	}
+
 	err = p.clearScheduledEvents(context.Background(), guildID, ms.User.ID)
 	if err != nil {
 		return true, err
@@ -482,6 +525,13 @@ func (p *Plugin) sendWarning(ms *dstate.MemberState, gs *dstate.GuildSet, token
 		logger.WithError(err).WithField("guild", gs.ID).WithField("user", ms.User.ID).Error("failed sending warning message")
 	}
 
+	if conf.Mode != VerificationModeWeb {
+		err = sendVerifyButton(channel.ID, gs)
+		if err != nil && !common.IsDiscordErr(err, discordgo.ErrCodeCannotSendMessagesToThisUser) {
+			logger.WithError(err).WithField("guild", gs.ID).WithField("user", ms.User.ID).Error("failed sending verify button")
+		}
+	}
+
 	return nil
 }
 
@@ -623,6 +673,8 @@ func markRecentlyBannedByVerification(guildID int64, userID int64) {
 func (p *Plugin) handleBanAdd(evt *eventsystem.EventData) {
 	ban := evt.GuildBanAdd()
 
+	recordRecentBan(ban.GuildID, ban.User)
+
 	if !confVerificationTrackIPs.GetBool() {
 		return
 	}
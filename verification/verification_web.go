@@ -10,18 +10,14 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
-	"time"
 
-	"github.com/cirelion/flint/analytics"
 	"github.com/cirelion/flint/common"
 	"github.com/cirelion/flint/common/cplogs"
-	"github.com/cirelion/flint/common/scheduledevents2"
 	"github.com/cirelion/flint/verification/models"
 	"github.com/cirelion/flint/web"
 	"github.com/microcosm-cc/bluemonday"
 	"github.com/russross/blackfriday"
 	"github.com/sirupsen/logrus"
-	"github.com/volatiletech/null"
 	"github.com/volatiletech/sqlboiler/boil"
 	"goji.io/pat"
 )
@@ -41,6 +37,10 @@ type FormData struct {
 	WarnMessage         string `valid:"template,10000"`
 	DMMessage           string `valid:"template,10000"`
 	LogChannel          int64  `valid:"channel,true"`
+	Mode                int    `valid:"0,2"`
+	QuizQuestions       string `valid:",10000"`
+	VerifyChannel       int64  `valid:"channel,true"`
+	RiskReviewThreshold int    `valid:"0,100"`
 }
 
 var panelLogKey = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "verification_updated_settings", FormatString: "Updated verification settings"})
@@ -90,10 +90,11 @@ func (p *Plugin) handleGetSettings(w http.ResponseWriter, r *http.Request) (web.
 	}
 
 	if settings != nil && settings.DMMessage == "" {
-		settings.DMMessage = DefaultDMMessage
+		settings.DMMessage = defaultDMMessage(settings)
 	}
 
 	templateData["DefaultPageContent"] = DefaultPageContent
+	templateData["ReCAPTCHAAvailable"] = reCAPTCHAAvailable()
 	templateData["PluginSettings"] = settings
 	templateData["RoleInvalid"] = roleInvalid
 
@@ -106,6 +107,26 @@ func (p *Plugin) handlePostSettings(w http.ResponseWriter, r *http.Request) (web
 
 	formConfig := ctx.Value(common.ContextKeyParsedForm).(*FormData)
 
+	if formConfig.Enabled && formConfig.Mode == VerificationModeWeb && !reCAPTCHAAvailable() {
+		return templateData, web.NewPublicError("reCAPTCHA is not available, pick one of the verification modes inside discord")
+	}
+
+	if formConfig.Mode == VerificationModeQuiz {
+		questions, err := ParseQuiz(formConfig.QuizQuestions)
+		if err != nil {
+			return templateData, web.NewPublicError(err.Error())
+		}
+
+		if len(questions) < 1 {
+			return templateData, web.NewPublicError("The quiz needs at least one question")
+		}
+	}
+
+	current, err := models.FindVerificationConfigG(ctx, g.ID)
+	if err != nil && err != sql.ErrNoRows {
+		return templateData, err
+	}
+
 	model := &models.VerificationConfig{
 		GuildID:             g.ID,
 		Enabled:             formConfig.Enabled,
@@ -116,15 +137,39 @@ func (p *Plugin) handlePostSettings(w http.ResponseWriter, r *http.Request) (web
 		WarnMessage:         formConfig.WarnMessage,
 		LogChannel:          formConfig.LogChannel,
 		DMMessage:           formConfig.DMMessage,
+		Mode:                formConfig.Mode,
+		QuizQuestions:       formConfig.QuizQuestions,
+		VerifyChannel:       formConfig.VerifyChannel,
+		RiskReviewThreshold: formConfig.RiskReviewThreshold,
 	}
 
-	columns := boil.Whitelist("enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message")
-	columnsCreate := boil.Whitelist("guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message")
-	err := model.UpsertG(ctx, true, []string{"guild_id"}, columns, columnsCreate)
-	if err == nil {
-		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKey))
+	if current != nil && current.VerifyChannel == model.VerifyChannel {
+		model.VerifyMessageID = current.VerifyMessageID
 	}
-	return templateData, err
+
+	columns := boil.Whitelist("enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message", "mode", "quiz_questions", "verify_channel", "verify_message_id", "risk_review_threshold")
+	columnsCreate := boil.Whitelist("guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message", "mode", "quiz_questions", "verify_channel", "verify_message_id", "risk_review_threshold")
+	err = model.UpsertG(ctx, true, []string{"guild_id"}, columns, columnsCreate)
+	if err != nil {
+		return templateData, err
+	}
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKey))
+
+	if current != nil && current.VerifyMessageID != 0 && current.VerifyChannel != model.VerifyChannel {
+		// the verify channel was changed, remove the old button
+		common.BotSession.ChannelMessageDelete(current.VerifyChannel, current.VerifyMessageID)
+	}
+
+	if model.Enabled && model.Mode != VerificationModeWeb && model.VerifyChannel != 0 {
+		err = PostVerifyPanel(ctx, model)
+		if err != nil {
+			web.CtxLogger(ctx).WithError(err).Error("failed posting verify button")
+			templateData.AddAlerts(web.ErrorAlert("Failed posting the verify button in the verify channel, make sure the bot has permissions to send messages there"))
+		}
+	}
+
+	return templateData, nil
 }
 
 func (p *Plugin) handleGetVerifyPage(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
@@ -149,6 +194,11 @@ func (p *Plugin) handleGetVerifyPage(w http.ResponseWriter, r *http.Request) (we
 		return templateData, nil
 	}
 
+	if settings.Mode != VerificationModeWeb {
+		templateData.AddAlerts(web.ErrorAlert("This server verifies members inside discord, press the Verify button the bot sent you"))
+		return templateData, nil
+	}
+
 	if _, ok := templateData["REValid"]; !ok {
 		// check if there's a valid session if we didn't just finish verifying
 		userID, _ := strconv.ParseInt(pat.Param(r, "user_id"), 10, 64)
@@ -205,6 +255,11 @@ func (p *Plugin) handlePostVerifyPage(w http.ResponseWriter, r *http.Request) (w
 		return templateData, nil
 	}
 
+	if settings.Mode != VerificationModeWeb {
+		templateData.AddAlerts(web.ErrorAlert("This server verifies members inside discord, press the Verify button the bot sent you"))
+		return templateData, nil
+	}
+
 	valid, err := p.checkCAPTCHAResponse(r.FormValue("g-recaptcha-response"))
 	if err != nil {
 		logrus.WithError(err).Error("Failed recaptcha response")
@@ -234,25 +289,11 @@ func (p *Plugin) handlePostVerifyPage(w http.ResponseWriter, r *http.Request) (w
 			ip = web.GetRequestIP(r)
 		}
 
-		model := &models.VerifiedUser{
-			UserID:     userID,
-			GuildID:    g.ID,
-			VerifiedAt: time.Now(),
-			IP:         ip,
-		}
-
-		err := model.UpsertG(ctx, true, []string{"guild_id", "user_id"}, boil.Infer(), boil.Infer())
+		err := p.markVerified(ctx, verSession, ip)
 		if err != nil {
 			web.CtxLogger(r.Context()).WithError(err).Error("failed verifying user")
 			return templateData, err
 		}
-
-		scheduledevents2.ScheduleEvent("verification_user_verified", g.ID, time.Now(), userID)
-		verSession.SolvedAt = null.TimeFrom(time.Now())
-		verSession.UpdateG(ctx, boil.Infer())
-
-		go analytics.RecordActiveUnit(g.ID, p, "completed")
-
 	} else {
 		templateData.AddAlerts(web.ErrorAlert("Invalid reCAPTCHA submission."))
 	}
@@ -308,7 +349,7 @@ func (p *Plugin) LoadServerHomeWidget(w http.ResponseWriter, r *http.Request) (w
 	ag, templateData := web.GetBaseCPContextData(r.Context())
 	ctx := r.Context()
 
-	templateData["WidgetTitle"] = "Google reCAPTCHA Verification"
+	templateData["WidgetTitle"] = "Verification"
 	templateData["SettingsPath"] = "/verification"
 
 	settings, err := models.FindVerificationConfigG(ctx, ag.ID)
@@ -18,6 +18,7 @@ import (
 	"github.com/cirelion/flint/bot/botrest"
 	"github.com/cirelion/flint/common"
 	"github.com/cirelion/flint/common/cplogs"
+	"github.com/cirelion/flint/common/i18n"
 	"github.com/cirelion/flint/common/models"
 	"github.com/cirelion/flint/common/patreon"
 	"github.com/cirelion/flint/common/pubsub"
@@ -468,6 +469,22 @@ type CoreConfigPostForm struct {
 	AllowedWriteRoles       []int64 `valid:"role,true"`
 	AllowAllMembersReadOnly bool
 	AllowNonMembersReadOnly bool
+	Language                string
+}
+
+func (f *CoreConfigPostForm) Validate(tmpl TemplateData) (ok bool) {
+	if f.Language != "" && !i18n.HasLanguage(f.Language) {
+		tmpl.AddAlerts(ErrorAlert("Unknown language"))
+		return false
+	}
+
+	return true
+}
+
+func HandleGetCoreSettings(w http.ResponseWriter, r *http.Request) (TemplateData, error) {
+	_, templateData := GetBaseCPContextData(r.Context())
+	templateData["Languages"] = i18n.Languages()
+	return templateData, nil
 }
 
 func HandlePostCoreSettings(w http.ResponseWriter, r *http.Request) (TemplateData, error) {
@@ -482,6 +499,8 @@ func HandlePostCoreSettings(w http.ResponseWriter, r *http.Request) (TemplateDat
 
 		AllowAllMembersReadOnly: form.AllowAllMembersReadOnly,
 		AllowNonMembersReadOnly: form.AllowNonMembersReadOnly,
+
+		Language: form.Language,
 	}
 
 	err := common.CoreConfigSave(r.Context(), m)
@@ -502,13 +521,25 @@ func HandleGetManagedGuilds(w http.ResponseWriter, r *http.Request) (TemplateDat
 	ctx := r.Context()
 	_, templateData := GetBaseCPContextData(ctx)
 
+	accessibleGuilds, err := GetManagedGuilds(ctx, false)
+	if err != nil {
+		return templateData, err
+	}
+
+	templateData["ManagedGuilds"] = accessibleGuilds
+
+	return templateData, nil
+}
+
+// GetManagedGuilds returns the servers the user has access to the settings of, if write is true only the ones they can also change them on
+func GetManagedGuilds(ctx context.Context, write bool) ([]*common.GuildWithConnected, error) {
 	user := ContextUser(ctx)
 
 	// retrieve guilds this user is part of
 	// i really wish there was a easy to to invalidate this cache, but since there's not it just expires after 10 seconds
 	wrapped, err := GetUserGuilds(ctx)
 	if err != nil {
-		return templateData, err
+		return nil, err
 	}
 
 	nilled := make([]*common.GuildWithConnected, len(wrapped))
@@ -519,7 +550,7 @@ func HandleGetManagedGuilds(w http.ResponseWriter, r *http.Request) (TemplateDat
 	for i, g := range wrapped {
 		go func(j int, gwc *common.GuildWithConnected) {
 			conf := common.GetCoreServerConfCached(gwc.ID)
-			if HasAccesstoGuildSettings(user.ID, gwc, conf, basicRoleProvider, false) {
+			if HasAccesstoGuildSettings(user.ID, gwc, conf, basicRoleProvider, write) {
 				nilled[j] = gwc
 			}
 
@@ -536,9 +567,7 @@ func HandleGetManagedGuilds(w http.ResponseWriter, r *http.Request) (TemplateDat
 		}
 	}
 
-	templateData["ManagedGuilds"] = accessibleGuilds
-
-	return templateData, nil
+	return accessibleGuilds, nil
 }
 
 func basicRoleProvider(guildID, userID int64) []int64 {
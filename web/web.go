@@ -331,7 +331,7 @@ func setupRoutes() *goji.Mux {
 	CPMux.Handle(pat.Get("/home"), ControllerHandler(HandleServerHome, "cp_server_home"))
 	CPMux.Handle(pat.Get("/home/"), ControllerHandler(HandleServerHome, "cp_server_home"))
 
-	coreSettingsHandler := RenderHandler(nil, "cp_core_settings")
+	coreSettingsHandler := ControllerHandler(HandleGetCoreSettings, "cp_core_settings")
 
 	CPMux.Handle(pat.Get("/core/"), coreSettingsHandler)
 	CPMux.Handle(pat.Get("/core"), coreSettingsHandler)